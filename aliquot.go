@@ -0,0 +1,74 @@
+package primes
+
+// Abundance classifies a number by comparing its aliquot sum to itself.
+type Abundance int
+
+const (
+	Deficient Abundance = iota // the aliquot sum is less than the number
+	Perfect                    // the aliquot sum equals the number
+	Abundant                   // the aliquot sum is larger than the number
+)
+
+// String returns the name of the class.
+func (a Abundance) String() string {
+	switch a {
+	case Deficient:
+		return "deficient"
+	case Perfect:
+		return "perfect"
+	case Abundant:
+		return "abundant"
+	}
+	return "unknown"
+}
+
+// AliquotSum returns the sum of the proper divisors of n, i.e. σ(n) - n.
+// If the factorizer boundaries are exceeded without a fallback, n is 0 or σ(n) exceeds 64 bits, the second result is
+// false.
+func (f *factorizer) AliquotSum(n uint64) (uint64, bool) {
+	factors, ok := f.Factorize(n)
+	if !ok {
+		return 0, false
+	}
+	sigma, ok := sigmaOf(factors)
+	if !ok {
+		return 0, false
+	}
+	return sigma - n, true
+}
+
+// Classify returns whether n is deficient, perfect or abundant.
+// If the aliquot sum of n cannot be determined, the second result is false.
+func (f *factorizer) Classify(n uint64) (Abundance, bool) {
+	s, ok := f.AliquotSum(n)
+	switch {
+	case !ok:
+		return 0, false
+	case s < n:
+		return Deficient, true
+	case s == n:
+		return Perfect, true
+	}
+	return Abundant, true
+}
+
+// AliquotSequence returns the aliquot sequence of n, i.e. n, s(n), s(s(n)), ... with the aliquot sum s. It ends with
+// 0, which follows 1 and thus every prime, or with the first repeated term, which shows a cycle of perfect, amicable
+// or sociable numbers, or after maxSteps steps. The second result is true iff the sequence ended with 0 or a cycle,
+// i.e. it is false if maxSteps was reached or a term could not be factorized.
+func (f *factorizer) AliquotSequence(n uint64, maxSteps int) ([]uint64, bool) {
+	sequence := []uint64{n}
+	seen := map[uint64]bool{n: true}
+	for step := 0; step < maxSteps && n != 0; step++ {
+		var ok bool
+		if n, ok = f.AliquotSum(n); !ok {
+			return sequence, false
+		}
+		sequence = append(sequence, n)
+		if seen[n] {
+			return sequence, true
+		}
+		seen[n] = true
+	}
+	return sequence, n == 0
+}
@@ -0,0 +1,52 @@
+package primes
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAliquot(t *testing.T) {
+	f := NewPrimeSet(100000).Factorizer(100000)
+	for _, c := range []struct {
+		n, sum uint64
+		class  Abundance
+	}{
+		{1, 0, Deficient}, {2, 1, Deficient}, {6, 6, Perfect}, {12, 16, Abundant}, {28, 28, Perfect},
+		{220, 284, Abundant}, {284, 220, Deficient}, {8128, 8128, Perfect}, {945, 975, Abundant},
+	} {
+		if sum, ok := f.AliquotSum(c.n); !ok || sum != c.sum {
+			t.Errorf("AliquotSum(%d) = %d, %v, expected %d", c.n, sum, ok, c.sum)
+		}
+		if class, ok := f.Classify(c.n); !ok || class != c.class {
+			t.Errorf("Classify(%d) = %v, %v, expected %v", c.n, class, ok, c.class)
+		}
+	}
+	if _, ok := f.AliquotSum(0); ok {
+		t.Error("AliquotSum(0) should fail")
+	}
+	if Perfect.String() != "perfect" {
+		t.Errorf("Perfect.String() = %q", Perfect.String())
+	}
+}
+
+func TestAliquotSequence(t *testing.T) {
+	f := NewPrimeSet(100000).Factorizer(100000)
+	for _, c := range []struct {
+		n        uint64
+		steps    int
+		expected []uint64
+		complete bool
+	}{
+		{10, 100, []uint64{10, 8, 7, 1, 0}, true},
+		{6, 100, []uint64{6, 6}, true},
+		{220, 100, []uint64{220, 284, 220}, true},
+		{12496, 100, []uint64{12496, 14288, 15472, 14536, 14264, 12496}, true}, // sociable numbers
+		{10, 2, []uint64{10, 8, 7}, false},
+		{138, 10, []uint64{138, 150, 222, 234, 312, 528, 960, 2088, 3762, 5598, 6570}, false},
+	} {
+		sequence, complete := f.AliquotSequence(c.n, c.steps)
+		if !slices.Equal(sequence, c.expected) || complete != c.complete {
+			t.Errorf("AliquotSequence(%d, %d) = %v, %v", c.n, c.steps, sequence, complete)
+		}
+	}
+}
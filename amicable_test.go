@@ -0,0 +1,47 @@
+package primes
+
+import "testing"
+
+func TestFindAmicablePairs(t *testing.T) {
+	expected := [][2]uint64{{220, 284}, {1184, 1210}, {2620, 2924}, {5020, 5564}, {6232, 6368}, {10744, 10856},
+		{12285, 14595}, {17296, 18416}, {63020, 76084}, {66928, 66992}, {67095, 71145}, {69615, 87633}, {79750, 88730}}
+	it := FindAmicablePairs(0, 100000)
+	for _, pair := range expected {
+		if a, b, ok := it.Next(); !ok || a != pair[0] || b != pair[1] {
+			t.Fatalf("next pair is (%d, %d, %v) instead of %v", a, b, ok, pair)
+		}
+	}
+	if a, b, ok := it.Next(); ok {
+		t.Errorf("unexpected pair (%d, %d)", a, b)
+	}
+
+	// both members have to lie in the range
+	for _, r := range [][2]uint64{{221, 100000}, {0, 283}, {5, 4}} {
+		if a, b, ok := FindAmicablePairs(r[0], r[1]).Next(); ok && a < 1184 {
+			t.Errorf("unexpected pair (%d, %d) in %v", a, b, r)
+		}
+	}
+	if a, b, ok := FindAmicablePairs(220, 284).Next(); !ok || a != 220 || b != 284 {
+		t.Errorf("FindAmicablePairs(220, 284) yields (%d, %d, %v)", a, b, ok)
+	}
+}
+
+func TestAliquotSums(t *testing.T) {
+	for _, r := range [][2]uint64{{1, 2000}, {1000, 1100}, {999983, 1000100}} {
+		sums := aliquotSums(r[0], r[1])
+		for n := r[0]; n <= r[1]; n++ {
+			expected := uint64(1)
+			for _, pp := range factorize(n) {
+				sum, q := uint64(1), uint64(1)
+				for e := uint(0); e < pp.Exponent; e++ {
+					q *= pp.Prime
+					sum += q
+				}
+				expected *= sum
+			}
+			if s := sums[n-r[0]]; s != expected-n {
+				t.Fatalf("aliquot sum of %d is %d instead of %d", n, s, expected-n)
+			}
+		}
+	}
+}
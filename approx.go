@@ -0,0 +1,94 @@
+package primes
+
+import "math"
+
+// Li returns the logarithmic integral li(x), which is the principal value of the integral of 1/ln t from 0 to x.
+// It is computed with Ramanujan's quickly converging series and returns -Inf for x = 1 and NaN for x < 1.
+func Li(x float64) float64 {
+	if x < 1 {
+		return math.NaN()
+	}
+	if x == 1 {
+		return math.Inf(-1)
+	}
+	const eulerGamma = 0.57721566490153286060651209008240243
+	lnx := math.Log(x)
+	sum := 0.0
+	term := 1.0  // (-1)^(n-1) (ln x)^n / (n! 2^(n-1))
+	inner := 0.0 // sum of 1/(2k+1) for k up to (n-1)/2
+	for n := 1; n < 1000; n++ {
+		term *= lnx / float64(n)
+		if n > 1 {
+			term *= -0.5
+		}
+		if n%2 == 1 {
+			inner += 1 / float64(n)
+		}
+		prev := sum
+		sum += term * inner
+		if sum == prev {
+			break
+		}
+	}
+	return eulerGamma + math.Log(lnx) + math.Sqrt(x)*sum
+}
+
+// ApproximatePi returns an approximation of the number of primes up to n, using Riemann's function
+// R(n) = Σ μ(k)/k li(n^(1/k)).
+func ApproximatePi(n uint64) float64 {
+	if n < 2 {
+		return 0
+	}
+	return riemannR(float64(n))
+}
+
+// riemannMobius holds the Möbius function up to 64 for riemannR, as x^(1/k) < 2 for k > 64 and all x < 2^64.
+var riemannMobius = MobiusSieve(64)
+
+// riemannR returns Riemann's prime counting approximation R(x) for x >= 2.
+func riemannR(x float64) float64 {
+	sum := 0.0
+	mu := riemannMobius
+	for k := 1; k <= 64; k++ {
+		root := math.Pow(x, 1/float64(k))
+		if root < 2 {
+			break
+		}
+		if mu[k] != 0 {
+			sum += float64(mu[k]) / float64(k) * Li(root)
+		}
+	}
+	return sum
+}
+
+// ApproximateNthPrime returns an approximation of the k-th prime, counting from 1 for the prime 2. It inverts
+// Riemann's function R with Newton's method, starting from Cipolla's asymptotic expansion. ApproximateNthPrime(0) is 0.
+func ApproximateNthPrime(k uint64) uint64 {
+	if k < 6 {
+		return [...]uint64{0, 2, 3, 5, 7, 11}[k]
+	}
+	fk := float64(k)
+	lnk := math.Log(fk)
+	lnlnk := math.Log(lnk)
+	x := fk * (lnk + lnlnk - 1 + (lnlnk-2)/lnk)
+	for i := 0; i < 10; i++ {
+		next := x - (riemannR(x)-fk)*math.Log(x)
+		if math.Abs(next-x) < 0.5 {
+			x = next
+			break
+		}
+		x = next
+	}
+	return uint64(math.Round(x))
+}
+
+// NthPrimeUpperBound returns a number which is guaranteed to be at least as large as the k-th prime, using the bound
+// p(k) < k (ln k + ln ln k) for k >= 6 by Rosser and Schoenfeld, plus a small margin against rounding errors.
+func NthPrimeUpperBound(k uint64) uint64 {
+	if k < 6 {
+		return [...]uint64{0, 2, 3, 5, 7, 11}[k]
+	}
+	fk := float64(k)
+	lnk := math.Log(fk)
+	return uint64(fk*(lnk+math.Log(lnk))*(1+1e-12)) + 1
+}
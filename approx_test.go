@@ -0,0 +1,65 @@
+package primes
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLi(t *testing.T) {
+	for _, c := range []struct {
+		x, li float64
+	}{
+		{2, 1.045163780117}, {10, 6.165599504787}, {1000000, 78627.549159462}, {1e18, 24739954309690414.0},
+	} {
+		if li := Li(c.x); math.Abs(li-c.li) > 1e-9*c.li {
+			t.Errorf("Li(%g) = %f instead of %f", c.x, li, c.li)
+		}
+	}
+	if li := Li(1); !math.IsInf(li, -1) {
+		t.Errorf("Li(1) = %f", li)
+	}
+}
+
+func TestApproximatePi(t *testing.T) {
+	set := NewPrimeSet(1000000)
+	count := 0.0
+	it := set.Iterator(0)
+	for p, ok := it.Next(); ok && p <= 1000000; p, ok = it.Next() {
+		count++
+	}
+	if pi := ApproximatePi(1000000); math.Abs(pi-count) > 50 {
+		t.Errorf("ApproximatePi(1000000) = %f, but there are %f primes", pi, count)
+	}
+	if pi := ApproximatePi(1); pi != 0 {
+		t.Errorf("ApproximatePi(1) = %f", pi)
+	}
+}
+
+func TestApproximateNthPrime(t *testing.T) {
+	for _, c := range []struct {
+		k, p uint64
+	}{
+		{1, 2}, {5, 11}, {10, 29}, {1000, 7919}, {1000000, 15485863}, {1000000000, 22801763489},
+	} {
+		if p := ApproximateNthPrime(c.k); math.Abs(float64(p)-float64(c.p)) > 0.001*float64(c.p)+1 {
+			t.Errorf("ApproximateNthPrime(%d) = %d instead of about %d", c.k, p, c.p)
+		}
+	}
+}
+
+func TestNewPrimeSetForCount(t *testing.T) {
+	for _, k := range []uint64{0, 1, 5, 6, 7, 100, 12345, 1000000} {
+		set := NewPrimeSetForCount(k)
+		count := uint64(0)
+		it := set.Iterator(0)
+		for _, ok := it.Next(); ok; _, ok = it.Next() {
+			count++
+		}
+		if count < k {
+			t.Errorf("NewPrimeSetForCount(%d) contains only %d primes", k, count)
+		}
+		if bound := NthPrimeUpperBound(k); bound < ApproximateNthPrime(k) {
+			t.Errorf("NthPrimeUpperBound(%d) = %d is below the approximation", k, bound)
+		}
+	}
+}
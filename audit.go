@@ -0,0 +1,42 @@
+package primes
+
+import (
+	"fmt"
+	"math/rand/v2"
+)
+
+// Audit checks the factor table for corruption, e.g. of a long-lived memory-mapped table. It factorizes the numbers
+// of samples random entries and of the last entry again by trial division, independently of the table and the prime
+// set, and compares their largest prime factors with the table. The returned error wraps ErrCorrupt with the first
+// wrong entry.
+func (f *factorizer) Audit(samples int) error {
+	n := uint64(numberToIndex(f.largestNumber)) // index of the last entry, entry 0 is unused
+	if n == 0 {
+		return nil
+	}
+	for i := 0; i <= samples; i++ {
+		k := n
+		if i < samples {
+			k = 1 + rand.Uint64N(n)
+		}
+		m := indexToNumber(uint(k))
+		if expected, p := largestFactorByTrialDivision(m), f.factors.get(uint(k)); p != expected {
+			return fmt.Errorf("%w: largest prime factor of %d is %d, but the factor table holds %d", ErrCorrupt, m, expected,
+				p)
+		}
+	}
+	return nil
+}
+
+// largestFactorByTrialDivision returns the largest prime factor of n > 1, which must not be divisible by 2 or 3. The
+// trial divisors are all numbers 1 or 5 mod 6 up to the square root of the remaining cofactor.
+func largestFactorByTrialDivision(n uint64) uint64 {
+	largest := uint64(1)
+	for d, step := uint64(5), uint64(2); d <= n/d; d, step = d+step, 6-step {
+		for n%d == 0 {
+			n /= d
+			largest = d
+		}
+	}
+	return max(largest, n)
+}
@@ -0,0 +1,35 @@
+package primes
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAudit(t *testing.T) {
+	f := NewPrimeSet(1000000).Factorizer(1000000).(*factorizer)
+	if err := f.Audit(10000); err != nil {
+		t.Errorf("Audit() of a correct table = %v", err)
+	}
+	last := numberToIndex(1000000)
+	f.factors.set(last, f.factors.get(last)+2)
+	if err := f.Audit(0); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("Audit() of a corrupt last entry = %v", err)
+	}
+	for i := uint(1000); i < last; i += 2 {
+		f.factors.set(i, 7)
+	}
+	if err := f.Audit(100); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("Audit() of a corrupt table = %v", err)
+	}
+	if err := NewPrimeSet(100).Factorizer(4).Audit(10); err != nil {
+		t.Errorf("Audit() of an empty table = %v", err)
+	}
+}
+
+func TestLargestFactorByTrialDivision(t *testing.T) {
+	for n, expected := range map[uint64]uint64{5: 5, 25: 5, 35: 7, 49: 7, 1001: 13, 35035: 13, 999983: 999983} {
+		if p := largestFactorByTrialDivision(n); p != expected {
+			t.Errorf("largestFactorByTrialDivision(%d) = %d instead of %d", n, p, expected)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package primes
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// BigPrimePower is a prime number raised to a positive exponent, i.e. a single factor of a big factorization.
+type BigPrimePower struct {
+	Prime    *big.Int // prime factor
+	Exponent uint     // multiplicity of the prime factor
+}
+
+// maxBigRhoSteps is the number of Pollard rho steps per polynomial after which FactorizeBig gives up.
+const maxBigRhoSteps = 1 << 22
+
+// FactorizeBig returns the prime factorization of n > 0 in ascending order of the prime factors. Small factors are
+// found by trial division with the primes of the set, large cofactors are split with Pollard-Brent rho and checked
+// with probabilistic primality tests. If n is not positive, an error wrapping ErrNotFactored is returned. An error is
+// also returned if a composite cofactor cannot be split, which happens when it has no prime factor below roughly 2^40.
+func (s *set) FactorizeBig(n *big.Int) ([]BigPrimePower, error) {
+	if n.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotFactored, n)
+	}
+	if n.IsUint64() {
+		var factors []BigPrimePower
+		small, _ := NewSetOracle(s).Factor(n.Uint64())
+		for _, pp := range small {
+			factors = append(factors, BigPrimePower{new(big.Int).SetUint64(pp.Prime), pp.Exponent})
+		}
+		return factors, nil
+	}
+
+	// trial division by the primes of the set
+	var factors []BigPrimePower
+	m := new(big.Int).Set(n)
+	q, r := new(big.Int), new(big.Int)
+	bp := new(big.Int)
+	it := s.Iterator(0)
+	p, ok := it.Next()
+	for ; ok && (!m.IsUint64() || p <= m.Uint64()/p); p, ok = it.Next() {
+		bp.SetUint64(p)
+		e := uint(0)
+		for {
+			q.QuoRem(m, bp, r)
+			if r.Sign() != 0 {
+				break
+			}
+			m.Set(q)
+			e++
+		}
+		if e > 0 {
+			factors = append(factors, BigPrimePower{new(big.Int).SetUint64(p), e})
+		}
+	}
+	if m.IsUint64() && m.Uint64() == 1 {
+		return factors, nil
+	}
+	if ok {
+		// all prime factors up to sqrt(m) are divided out, so m is prime
+		return append(factors, BigPrimePower{m, 1}), nil
+	}
+
+	// split the remaining cofactor
+	pending := []*big.Int{m}
+	for len(pending) > 0 {
+		c := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if c.IsUint64() {
+			for _, pp := range factorize(c.Uint64()) {
+				factors = appendBigPrimePower(factors, new(big.Int).SetUint64(pp.Prime), pp.Exponent)
+			}
+			continue
+		}
+		if c.ProbablyPrime(20) {
+			factors = appendBigPrimePower(factors, c, 1)
+			continue
+		}
+		d := pollardBrentBig(c)
+		if d == nil {
+			return nil, fmt.Errorf("primes: cannot split composite factor %s of %s", c, n)
+		}
+		pending = append(pending, d, new(big.Int).Quo(c, d))
+	}
+	sort.Slice(factors, func(i, j int) bool { return factors[i].Prime.Cmp(factors[j].Prime) < 0 })
+	return factors, nil
+}
+
+// appendBigPrimePower adds p^e to the given factors, merging it with an existing entry for p.
+func appendBigPrimePower(factors []BigPrimePower, p *big.Int, e uint) []BigPrimePower {
+	for i := range factors {
+		if factors[i].Prime.Cmp(p) == 0 {
+			factors[i].Exponent += e
+			return factors
+		}
+	}
+	return append(factors, BigPrimePower{p, e})
+}
+
+// errRhoFailed signals that Pollard's rho method did not find a factor within its step limit.
+var errRhoFailed = errors.New("rho failed")
+
+// pollardBrentBig returns a nontrivial factor of the composite number n, or nil if none was found.
+func pollardBrentBig(n *big.Int) *big.Int {
+	for c := int64(1); c <= 3; c++ {
+		if d, err := pollardBrentBigStep(n, big.NewInt(c)); err == nil {
+			return d
+		}
+	}
+	return nil
+}
+
+// pollardBrentBigStep runs Brent's variant of Pollard's rho method with the polynomial x^2 + c on n.
+func pollardBrentBigStep(n, c *big.Int) (*big.Int, error) {
+	const batch = 128 // number of steps between two gcd calculations
+	f := func(x *big.Int) {
+		x.Mul(x, x)
+		x.Add(x, c)
+		x.Mod(x, n)
+	}
+	y, x, ys := big.NewInt(2), new(big.Int), new(big.Int)
+	g, q, diff := big.NewInt(1), big.NewInt(1), new(big.Int)
+	one := big.NewInt(1)
+	steps := 0
+	for r := 1; g.Cmp(one) == 0; r <<= 1 {
+		x.Set(y)
+		for i := 0; i < r; i++ {
+			f(y)
+		}
+		for k := 0; k < r && g.Cmp(one) == 0; k += batch {
+			ys.Set(y)
+			for i := 0; i < batch && i < r-k; i++ {
+				f(y)
+				q.Mul(q, diff.Sub(x, y).Abs(diff))
+				q.Mod(q, n)
+			}
+			g.GCD(nil, nil, q, n)
+			steps += batch
+		}
+		if steps > maxBigRhoSteps {
+			return nil, errRhoFailed
+		}
+	}
+	if g.Cmp(n) == 0 {
+		// the batch overshot, so repeat its steps one at a time
+		for g.Cmp(one) == 0 {
+			f(ys)
+			g.GCD(nil, nil, diff.Sub(x, ys).Abs(diff), n)
+		}
+	}
+	if g.Cmp(n) == 0 {
+		return nil, errRhoFailed
+	}
+	return g, nil
+}
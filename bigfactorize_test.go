@@ -0,0 +1,46 @@
+package primes
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestFactorizeBig(t *testing.T) {
+	set := NewPrimeSet(100000)
+	for _, c := range []struct {
+		n        string
+		expected []string
+	}{
+		{"1", nil},
+		{"360", []string{"2^3", "3^2", "5"}},
+		{"18446744073709551557", []string{"18446744073709551557"}},
+		{"340282366920938463463374607431768211455", []string{"3", "5", "17", "257", "641", "65537", "274177", "6700417", "67280421310721"}}, // 2^128 - 1
+		{"1000000000000000000000000000000000000000000000000000000000000000", []string{"2^63", "5^63"}},
+		{"170141183460469231731687303715884105727", []string{"170141183460469231731687303715884105727"}}, // 2^127 - 1
+		{"99960005979604009801", []string{"99989^2", "99991^2"}},
+	} {
+		n, _ := new(big.Int).SetString(c.n, 10)
+		factors, err := set.FactorizeBig(n)
+		if err != nil {
+			t.Errorf("FactorizeBig(%s) failed: %s", c.n, err)
+			continue
+		}
+		if len(factors) != len(c.expected) {
+			t.Errorf("FactorizeBig(%s) = %v instead of %v", c.n, factors, c.expected)
+			continue
+		}
+		for i, f := range factors {
+			s := f.Prime.String()
+			if f.Exponent > 1 {
+				s += "^" + big.NewInt(int64(f.Exponent)).String()
+			}
+			if s != c.expected[i] {
+				t.Errorf("FactorizeBig(%s) = %v instead of %v", c.n, factors, c.expected)
+			}
+		}
+	}
+	if _, err := set.FactorizeBig(big.NewInt(0)); !errors.Is(err, ErrNotFactored) {
+		t.Error("0 should not have a factorization")
+	}
+}
@@ -0,0 +1,52 @@
+package primes
+
+// BinomialFactorization returns the prime factorization of the binomial coefficient C(n, k) in ascending order of
+// the prime factors. By Legendre's formula, the exponent of p is the number of carries when adding k and n - k in
+// base p (Kummer's theorem). If n exceeds the set or k > n, the second result is false.
+func (s *set) BinomialFactorization(n, k uint64) ([]PrimePower, bool) {
+	if n > s.largestNumber || k > n {
+		return nil, false
+	}
+	var factors []PrimePower
+	it := s.Iterator(0)
+	for p, ok := it.Next(); ok && p <= n; p, ok = it.Next() {
+		if e := legendre(n, p) - legendre(k, p) - legendre(n-k, p); e > 0 {
+			factors = append(factors, PrimePower{p, e})
+		}
+	}
+	return factors, true
+}
+
+// BinomialModPrime returns the binomial coefficient C(n, k) modulo the prime p, using Lucas' theorem to split n and
+// k into their base p digits. Every digit takes up to p/2 modular multiplications, so large primes are only feasible
+// for small digits. BinomialModPrime panics if p is not prime.
+func BinomialModPrime(n, k, p uint64) uint64 {
+	if prime, _ := millerRabin(p); !prime {
+		panic("modulus must be prime")
+	}
+	result := uint64(1)
+	for k > 0 {
+		ni, ki := n%p, k%p
+		if ki > ni {
+			return 0
+		}
+		result = MulMod(result, binomialSmall(ni, ki, p), p)
+		n /= p
+		k /= p
+	}
+	return result
+}
+
+// binomialSmall returns C(n, k) modulo the prime p for k <= n < p.
+func binomialSmall(n, k, p uint64) uint64 {
+	if k > n-k {
+		k = n - k
+	}
+	num, den := uint64(1), uint64(1)
+	for i := uint64(0); i < k; i++ {
+		num = MulMod(num, n-i, p)
+		den = MulMod(den, i+1, p)
+	}
+	// divide by den using Fermat's little theorem
+	return MulMod(num, PowMod(den, p-2, p), p)
+}
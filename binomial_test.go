@@ -0,0 +1,53 @@
+package primes
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestBinomialFactorization(t *testing.T) {
+	set := NewPrimeSet(1000)
+	for n := uint64(0); n <= 100; n++ {
+		for k := uint64(0); k <= n; k++ {
+			factors, ok := set.BinomialFactorization(n, k)
+			if !ok {
+				t.Fatalf("BinomialFactorization(%d, %d) failed", n, k)
+			}
+			product := big.NewInt(1)
+			for _, pp := range factors {
+				power := new(big.Int).Exp(new(big.Int).SetUint64(pp.Prime), big.NewInt(int64(pp.Exponent)), nil)
+				product.Mul(product, power)
+			}
+			if expected := new(big.Int).Binomial(int64(n), int64(k)); product.Cmp(expected) != 0 {
+				t.Fatalf("BinomialFactorization(%d, %d) = %v instead of %s", n, k, factors, expected)
+			}
+		}
+	}
+	if _, ok := set.BinomialFactorization(5, 6); ok {
+		t.Error("BinomialFactorization(5, 6) should fail")
+	}
+}
+
+func TestBinomialModPrime(t *testing.T) {
+	for _, p := range []uint64{2, 3, 7, 101} {
+		for n := uint64(0); n <= 300; n += 7 {
+			for k := uint64(0); k <= n; k += 3 {
+				expected := new(big.Int).Binomial(int64(n), int64(k))
+				expected.Mod(expected, new(big.Int).SetUint64(p))
+				if c := BinomialModPrime(n, k, p); c != expected.Uint64() {
+					t.Errorf("BinomialModPrime(%d, %d, %d) = %d instead of %d", n, k, p, c, expected)
+				}
+			}
+		}
+	}
+	// C(10^18, 10) modulo a large prime, checked with big integers
+	n, k, p := uint64(1000000000000000000), uint64(10), uint64(18446744073709551557)
+	expected := new(big.Int).Binomial(int64(n), int64(k))
+	expected.Mod(expected, new(big.Int).SetUint64(p))
+	if c := BinomialModPrime(n, k, p); c != expected.Uint64() {
+		t.Errorf("BinomialModPrime(%d, %d, %d) = %d instead of %d", n, k, p, c, expected)
+	}
+	if c := BinomialModPrime(5, 6, 7); c != 0 {
+		t.Errorf("BinomialModPrime(5, 6, 7) = %d instead of 0", c)
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Package bitset provides a compact set of bits in an uint64 array, as used by the prime sieves of package primes.
+The operations are kept small enough to be inlined, so a BitSet can be used in the inner loops of custom sieves
+without overhead compared to manipulating the words directly. The bulk operations are accelerated: ClearStride
+clears small strides with precomputed masks word by word, and Count uses POPCNT assembly on amd64. Build with the tag
+purego to use the pure Go versions only.
+
+Example usage:
+
+	b := bitset.New(1000)
+	b.SetAll()
+	for i := uint(4); i < b.Len(); i += 2 {
+		b.Clear(i)
+	}
+	for i, ok := b.NextSet(0); ok; i, ok = b.NextSet(i + 1) {
+		fmt.Println(i)
+	}
+*/
+package bitset
+
+import "math/bits"
+
+// BitSet is a set of bits, where bit i is bit i&63 of word i>>6. The bit indices of the methods must be less than
+// Len unless stated otherwise, just like slice indices.
+type BitSet []uint64
+
+// New creates a bit set with room for at least n bits, which are all cleared.
+func New(n uint) BitSet {
+	return make(BitSet, (n+63)>>6)
+}
+
+// wordMask splits a bit index into an uint64 array index and a bit mask.
+func wordMask(i uint) (uint, uint64) {
+	return i >> 6, 1 << (i & 63)
+}
+
+// Len returns the number of bits in the set, which is always a multiple of 64.
+func (b BitSet) Len() uint {
+	return uint(len(b)) << 6
+}
+
+// Words returns the uint64 array of the bits, which is shared with the bit set.
+func (b BitSet) Words() []uint64 {
+	return b
+}
+
+// Set sets bit i.
+func (b BitSet) Set(i uint) {
+	word, mask := wordMask(i)
+	b[word] |= mask
+}
+
+// Clear clears bit i.
+func (b BitSet) Clear(i uint) {
+	word, mask := wordMask(i)
+	b[word] &^= mask
+}
+
+// Get returns true iff bit i is set.
+func (b BitSet) Get(i uint) bool {
+	word, mask := wordMask(i)
+	return b[word]&mask != 0
+}
+
+// SetAll sets all bits.
+func (b BitSet) SetAll() {
+	for i := range b {
+		b[i] = ^uint64(0)
+	}
+}
+
+// ClearStride clears the bits start, start+stride, start+2*stride etc. up to Len, which is the hot loop of a sieve.
+// Strides below 64 affect every word, so they are cleared with a precomputed pattern of masks, which repeats after
+// stride/gcd(stride, 64) words, instead of bit by bit. ClearStride panics if stride is 0.
+func (b BitSet) ClearStride(start, stride uint) {
+	if stride == 0 {
+		panic("stride must be positive")
+	}
+	n := b.Len()
+	if stride >= 64 {
+		for i := start; i < n; i += stride {
+			b[i>>6] &^= 1 << (i & 63)
+		}
+		return
+	}
+	if start >= n {
+		return
+	}
+
+	// the pattern starts at the word of start, so all words of b from there on are masked with it in turn, except for
+	// the bits before start in its word
+	period := stride / gcd(stride, 64)
+	var pattern [63]uint64
+	for i := (start & 63) % stride; i < period<<6; i += stride {
+		pattern[i>>6] |= 1 << (i & 63)
+	}
+	words := b[start>>6:]
+	words[0] &^= pattern[0] & (^uint64(0) << (start & 63))
+	k := 1 % period
+	for j := 1; j < len(words); j++ {
+		words[j] &^= pattern[k]
+		if k++; k == period {
+			k = 0
+		}
+	}
+}
+
+// gcd returns the greatest common divisor of a and b.
+func gcd(a, b uint) uint {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// NextSet returns the index of the next set bit, starting from i. i may be Len or greater.
+// If there is no bit set at or after index i, the second result is false.
+func (b BitSet) NextSet(i uint) (uint, bool) {
+	word := int(i >> 6)
+	if word >= len(b) {
+		return 0, false
+	}
+	w := b[word] >> (i & 63)
+	if w != 0 {
+		return i + uint(bits.TrailingZeros64(w)), true
+	}
+	for word++; word < len(b); word++ {
+		if b[word] != 0 {
+			return uint(word)<<6 + uint(bits.TrailingZeros64(b[word])), true
+		}
+	}
+	return 0, false
+}
+
+// PrevSet returns the index of the previous set bit, starting from i downwards. i may be Len or greater.
+// If there is no bit set at or before index i, the second result is false.
+func (b BitSet) PrevSet(i uint) (uint, bool) {
+	word := int(i >> 6)
+	if word >= len(b) {
+		word = len(b)
+		i = uint(word) << 6
+	} else if w := b[word] << (63 - i&63); w != 0 {
+		return i - uint(bits.LeadingZeros64(w)), true
+	}
+	for word--; word >= 0; word-- {
+		if b[word] != 0 {
+			return uint(word)<<6 + 63 - uint(bits.LeadingZeros64(b[word])), true
+		}
+	}
+	return 0, false
+}
+
+// Count returns the number of set bits with an index in [from, to). to may be Len or greater.
+func (b BitSet) Count(from, to uint) uint {
+	to = min(to, b.Len())
+	if from >= to {
+		return 0
+	}
+	first, last := from>>6, (to-1)>>6
+	lowMask := ^uint64(0) << (from & 63)
+	highMask := ^uint64(0) >> (63 - (to-1)&63)
+	if first == last {
+		return uint(bits.OnesCount64(b[first] & lowMask & highMask))
+	}
+	n := bits.OnesCount64(b[first]&lowMask) + bits.OnesCount64(b[last]&highMask)
+	return uint(n + popcount(b[first+1:last]))
+}
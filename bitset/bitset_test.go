@@ -0,0 +1,120 @@
+package bitset
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSetClearGet(t *testing.T) {
+	b := New(130)
+	if b.Len() != 192 || len(b.Words()) != 3 {
+		t.Errorf("New(130) has %d bits in %d words", b.Len(), len(b.Words()))
+	}
+	b.Set(0)
+	b.Set(64)
+	b.Set(129)
+	b.Clear(64)
+	for i := uint(0); i < b.Len(); i++ {
+		if b.Get(i) != (i == 0 || i == 129) {
+			t.Errorf("Get(%d) = %v", i, b.Get(i))
+		}
+	}
+	b.SetAll()
+	if n := b.Count(0, b.Len()); n != 192 {
+		t.Errorf("SetAll() sets %d bits", n)
+	}
+}
+
+func TestNextSet(t *testing.T) {
+	b := BitSet{0x8000000000000001, 0, 0x10}
+	for _, c := range []struct {
+		i, expected uint
+		found       bool
+	}{{0, 0, true}, {1, 63, true}, {63, 63, true}, {64, 132, true}, {132, 132, true}, {133, 0, false}, {1000, 0, false}} {
+		if i, found := b.NextSet(c.i); i != c.expected || found != c.found {
+			t.Errorf("NextSet(%d) = %d, %v", c.i, i, found)
+		}
+	}
+}
+
+func TestPrevSet(t *testing.T) {
+	b := BitSet{0x8000000000000001, 0, 0x10}
+	for _, c := range []struct {
+		i, expected uint
+		found       bool
+	}{{0, 0, true}, {1, 0, true}, {63, 63, true}, {100, 63, true}, {132, 132, true}, {1000, 132, true}} {
+		if i, found := b.PrevSet(c.i); i != c.expected || found != c.found {
+			t.Errorf("PrevSet(%d) = %d, %v", c.i, i, found)
+		}
+	}
+	if _, found := (BitSet{0, 2}).PrevSet(64); found {
+		t.Error("PrevSet() should not find a bit")
+	}
+}
+
+func TestCount(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	b := New(37 * 64)
+	for i := range b {
+		b[i] = rnd.Uint64()
+	}
+	for i := 0; i < 2000; i++ {
+		from, to := uint(rnd.Intn(len(b)*64+10)), uint(rnd.Intn(len(b)*64+10))
+		expected := uint(0)
+		for j := from; j < to && j < b.Len(); j++ {
+			if b.Get(j) {
+				expected++
+			}
+		}
+		if n := b.Count(from, to); n != expected {
+			t.Errorf("Count(%d, %d) = %d instead of %d", from, to, n, expected)
+		}
+	}
+}
+
+func TestPopcount(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	words := make([]uint64, 40)
+	for i := range words {
+		words[i] = rnd.Uint64()
+	}
+	words[3] = ^uint64(0)
+	for n := 0; n <= len(words); n++ {
+		expected := 0
+		for _, w := range words[:n] {
+			for ; w != 0; w &= w - 1 {
+				expected++
+			}
+		}
+		if c := popcount(words[:n]); c != expected {
+			t.Errorf("popcount() of %d words = %d instead of %d", n, c, expected)
+		}
+	}
+}
+
+func TestClearStride(t *testing.T) {
+	for _, stride := range []uint{1, 2, 3, 10, 38, 62, 63, 64, 65, 130} {
+		for _, start := range []uint{0, 5, 63, 64, 100, 1000, 1300} {
+			b := New(1300)
+			b.SetAll()
+			b.ClearStride(start, stride)
+			for i := uint(0); i < b.Len(); i++ {
+				if cleared := i >= start && (i-start)%stride == 0; b.Get(i) == cleared {
+					t.Fatalf("ClearStride(%d, %d) leaves bit %d = %v", start, stride, i, b.Get(i))
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkCount(b *testing.B) {
+	s := New(1 << 22)
+	for i := range s {
+		s[i] = uint64(i) * 0x9e3779b97f4a7c15
+	}
+	b.SetBytes(int64(len(s) << 3))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Count(3, s.Len()-5)
+	}
+}
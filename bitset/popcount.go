@@ -0,0 +1,20 @@
+package bitset
+
+import "math/bits"
+
+// popcountGeneric returns the number of set bits in words. The loop is unrolled so that the popcounts of four words
+// can be computed independently; on arm64, the compiler turns each of them into vector instructions anyway.
+func popcountGeneric(words []uint64) int {
+	var n0, n1, n2, n3 int
+	for len(words) >= 4 {
+		n0 += bits.OnesCount64(words[0])
+		n1 += bits.OnesCount64(words[1])
+		n2 += bits.OnesCount64(words[2])
+		n3 += bits.OnesCount64(words[3])
+		words = words[4:]
+	}
+	for _, w := range words {
+		n0 += bits.OnesCount64(w)
+	}
+	return n0 + n1 + n2 + n3
+}
@@ -0,0 +1,26 @@
+//go:build !purego
+
+package bitset
+
+// hasPOPCNT is true iff the CPU supports the POPCNT instruction, which is reported in bit 23 of ECX of CPUID leaf 1.
+var hasPOPCNT = func() bool {
+	_, _, ecx, _ := cpuid(1, 0)
+	return ecx&(1<<23) != 0
+}()
+
+// popcount returns the number of set bits in words, using the assembly loop if the CPU supports it.
+func popcount(words []uint64) int {
+	if hasPOPCNT {
+		return popcountAsm(words)
+	}
+	return popcountGeneric(words)
+}
+
+// popcountAsm returns the number of set bits in words with POPCNT instructions on four words per iteration, which
+// saves the feature check that math/bits.OnesCount64 performs for every single word.
+//
+//go:noescape
+func popcountAsm(words []uint64) int
+
+// cpuid executes the CPUID instruction for the given leaf and subleaf.
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
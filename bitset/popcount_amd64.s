@@ -0,0 +1,64 @@
+//go:build !purego
+
+#include "textflag.h"
+
+// func popcountAsm(words []uint64) int
+TEXT ·popcountAsm(SB), NOSPLIT, $0-32
+	MOVQ words_base+0(FP), SI
+	MOVQ words_len+8(FP), CX
+	XORQ AX, AX
+	XORQ BX, BX
+	XORQ DX, DX
+	XORQ DI, DI
+	CMPQ CX, $4
+	JB   tail
+
+	// four independent accumulators; the destinations are cleared first to break the false dependency of POPCNT on
+	// its destination register on some CPUs
+loop:
+	XORQ    R8, R8
+	XORQ    R9, R9
+	XORQ    R10, R10
+	XORQ    R11, R11
+	POPCNTQ 0(SI), R8
+	POPCNTQ 8(SI), R9
+	POPCNTQ 16(SI), R10
+	POPCNTQ 24(SI), R11
+	ADDQ    R8, AX
+	ADDQ    R9, BX
+	ADDQ    R10, DX
+	ADDQ    R11, DI
+	ADDQ    $32, SI
+	SUBQ    $4, CX
+	CMPQ    CX, $4
+	JAE     loop
+
+tail:
+	TESTQ CX, CX
+	JZ    done
+
+tailloop:
+	XORQ    R8, R8
+	POPCNTQ 0(SI), R8
+	ADDQ    R8, AX
+	ADDQ    $8, SI
+	DECQ    CX
+	JNZ     tailloop
+
+done:
+	ADDQ BX, AX
+	ADDQ DX, AX
+	ADDQ DI, AX
+	MOVQ AX, ret+24(FP)
+	RET
+
+// func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+TEXT ·cpuid(SB), NOSPLIT, $0-24
+	MOVL eaxArg+0(FP), AX
+	MOVL ecxArg+4(FP), CX
+	CPUID
+	MOVL AX, eax+8(FP)
+	MOVL BX, ebx+12(FP)
+	MOVL CX, ecx+16(FP)
+	MOVL DX, edx+20(FP)
+	RET
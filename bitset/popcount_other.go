@@ -0,0 +1,8 @@
+//go:build !amd64 || purego
+
+package bitset
+
+// popcount returns the number of set bits in words.
+func popcount(words []uint64) int {
+	return popcountGeneric(words)
+}
@@ -0,0 +1,19 @@
+package primes
+
+// IsBlumInteger returns true iff n = p * q for distinct primes p and q with p = q = 3 mod 4.
+// If the factorizer boundaries are exceeded or n is 0, the second result is false.
+func (f *factorizer) IsBlumInteger(n uint64) (bool, bool) {
+	factors, ok := f.Factorize(n)
+	if !ok {
+		return false, false
+	}
+	if len(factors) != 2 {
+		return false, true
+	}
+	for _, pp := range factors {
+		if pp.Exponent != 1 || pp.Prime%4 != 3 {
+			return false, true
+		}
+	}
+	return true, true
+}
@@ -0,0 +1,28 @@
+package primes
+
+import "testing"
+
+func TestIsBlumInteger(t *testing.T) {
+	f := NewPrimeSet(1000).Factorizer(1000)
+	var blum []uint64
+	for n := uint64(1); n <= 150; n++ {
+		if b, ok := f.IsBlumInteger(n); !ok {
+			t.Fatalf("IsBlumInteger(%d) failed", n)
+		} else if b {
+			blum = append(blum, n)
+		}
+	}
+	expected := []uint64{21, 33, 57, 69, 77, 93, 129, 133, 141}
+	if len(blum) != len(expected) {
+		t.Fatalf("Blum integers up to 150 are %v instead of %v", blum, expected)
+	}
+	for i := range blum {
+		if blum[i] != expected[i] {
+			t.Errorf("Blum integers up to 150 are %v instead of %v", blum, expected)
+			break
+		}
+	}
+	if _, ok := f.IsBlumInteger(0); ok {
+		t.Error("IsBlumInteger(0) should fail")
+	}
+}
@@ -0,0 +1,20 @@
+package primes
+
+// BrunSum returns the sum of 1/p + 1/(p+2) over all twin primes (p, p+2) in the set, which converges to Brun's
+// constant as the set grows. As usual, 5 is counted twice since it belongs to the twin primes (3, 5) and (5, 7).
+func (s *set) BrunSum() float64 {
+	return brunSum(s.Iterator(3))
+}
+
+// brunSum returns the sum of 1/p + 1/(p+2) over all twin primes (p, p+2) of an iterator over odd primes.
+func brunSum(it Iterator) float64 {
+	sum := 0.0
+	prev := uint64(0)
+	for p, ok := it.Next(); ok; p, ok = it.Next() {
+		if p-prev == 2 {
+			sum += 1/float64(prev) + 1/float64(p)
+		}
+		prev = p
+	}
+	return sum
+}
@@ -0,0 +1,22 @@
+package primes
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBrunSum(t *testing.T) {
+	set := NewPrimeSet(1000000)
+	expected := 0.0
+	for p := uint64(3); p+2 <= set.LargestNumber(); p += 2 {
+		if set.IsPrime(p) && set.IsPrime(p+2) {
+			expected += 1/float64(p) + 1/float64(p+2)
+		}
+	}
+	if sum := set.BrunSum(); math.Abs(sum-expected) > 1e-12 {
+		t.Errorf("BrunSum() = %f instead of %f", sum, expected)
+	}
+	if expected < 1.7107 || expected > 1.711 {
+		t.Errorf("BrunSum() = %f is implausible for primes up to 10^6", expected)
+	}
+}
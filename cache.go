@@ -0,0 +1,73 @@
+package primes
+
+import (
+	"container/list"
+	"sync"
+)
+
+// WithCache lets the factorizer memoize the results of up to size fallback factorizations beyond its table, evicting
+// the least recently used ones. Since Phi and Divisors are derived from Factorize, they benefit as well. The cache
+// implies WithFallback. Cache hits and misses are reported to the metrics of the set.
+func WithCache(size int) FactorizerOption {
+	return func(f *factorizer) {
+		f.fallback = true
+		if size > 0 {
+			f.cache = newLRUCache(size)
+		}
+	}
+}
+
+// lruCache is a size-limited map from numbers to their factorizations, which evicts the least recently used entry.
+// It is safe for concurrent use. A nil *lruCache never holds any entries.
+type lruCache struct {
+	mu      sync.Mutex
+	size    int                      // maximum number of entries
+	order   *list.List               // entries from most to least recently used
+	entries map[uint64]*list.Element // entries by number
+}
+
+// lruEntry is a single entry of an lruCache.
+type lruEntry struct {
+	n       uint64
+	factors []PrimePower
+}
+
+// newLRUCache creates an empty cache for up to size entries.
+func newLRUCache(size int) *lruCache {
+	return &lruCache{size: size, order: list.New(), entries: make(map[uint64]*list.Element, size)}
+}
+
+// get returns the cached factorization of n and marks it as recently used.
+// If n is not in the cache, the second result is false.
+func (c *lruCache) get(n uint64) ([]PrimePower, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[n]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*lruEntry).factors, true
+}
+
+// put stores the factorization of n, evicting the least recently used entry if the cache is full.
+func (c *lruCache) put(n uint64, factors []PrimePower) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[n]; ok {
+		c.order.MoveToFront(e)
+		return
+	}
+	if c.order.Len() >= c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).n)
+	}
+	c.entries[n] = c.order.PushFront(&lruEntry{n, factors})
+}
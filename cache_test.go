@@ -0,0 +1,81 @@
+package primes
+
+import "testing"
+
+func TestLRUCache(t *testing.T) {
+	c := newLRUCache(2)
+	c.put(1, []PrimePower{{1, 1}})
+	c.put(2, []PrimePower{{2, 1}})
+	c.get(1) // 2 is now the least recently used entry
+	c.put(3, []PrimePower{{3, 1}})
+	if _, ok := c.get(2); ok {
+		t.Error("2 should have been evicted")
+	}
+	for _, n := range []uint64{1, 3} {
+		if factors, ok := c.get(n); !ok || factors[0].Prime != n {
+			t.Errorf("get(%d) = %v, %t", n, factors, ok)
+		}
+	}
+	var nilCache *lruCache
+	nilCache.put(1, nil)
+	if _, ok := nilCache.get(1); ok {
+		t.Error("nil cache should be empty")
+	}
+}
+
+func TestFactorizerCache(t *testing.T) {
+	m := &countingMetrics{}
+	set := NewPrimeSet(1000, WithMetrics(m))
+	f := set.Factorizer(1000, WithCache(10))
+	n := uint64(1000000016000000063) // 1000000007 * 1000000009
+	for i := 0; i < 3; i++ {
+		factors, ok := f.Factorize(n)
+		if !ok || len(factors) != 2 || factors[0] != (PrimePower{1000000007, 1}) || factors[1] != (PrimePower{1000000009, 1}) {
+			t.Fatalf("Factorize(%d) = %v, %t", n, factors, ok)
+		}
+		factors[0].Prime = 0 // must not corrupt the cache
+	}
+	if m.cacheHits != 2 || m.cacheMisses != 1 {
+		t.Errorf("%d cache hits and %d misses instead of 2 and 1", m.cacheHits, m.cacheMisses)
+	}
+	if phi, ok := f.Phi(n); !ok || phi != 1000000006*1000000008 {
+		t.Errorf("Phi(%d) = %d, %t", n, phi, ok)
+	}
+	if _, ok := set.Factorizer(1000).Factorize(n); ok {
+		t.Error("Factorize beyond the table should fail without fallback")
+	}
+	if factors, ok := set.Factorizer(1000, WithFallback()).Factorize(n); !ok || len(factors) != 2 {
+		t.Errorf("Factorize(%d) with fallback = %v, %t", n, factors, ok)
+	}
+}
+
+func TestDivisors(t *testing.T) {
+	f := NewPrimeSet(1000).Factorizer(1000)
+	for n := uint64(1); n <= 1000; n++ {
+		var expected []uint64
+		for d := uint64(1); d <= n; d++ {
+			if n%d == 0 {
+				expected = append(expected, d)
+			}
+		}
+		divisors, ok := f.Divisors(n)
+		if !ok || len(divisors) != len(expected) {
+			t.Fatalf("Divisors(%d) = %v instead of %v", n, divisors, expected)
+		}
+		for i := range divisors {
+			if divisors[i] != expected[i] {
+				t.Fatalf("Divisors(%d) = %v instead of %v", n, divisors, expected)
+			}
+		}
+		phi, _ := f.Phi(n)
+		coprime := uint64(0)
+		for k := uint64(1); k <= n; k++ {
+			if gcd(k, n) == 1 {
+				coprime++
+			}
+		}
+		if phi != coprime {
+			t.Fatalf("Phi(%d) = %d instead of %d", n, phi, coprime)
+		}
+	}
+}
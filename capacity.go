@@ -0,0 +1,36 @@
+package primes
+
+import "sort"
+
+// capacityPrimes are the smallest primes above 3 * 2^k for k = 1 ... 62, i.e. each one is about twice as large as
+// the previous one and far away from powers of two. This makes them good capacities for hash tables.
+var capacityPrimes = [...]uint64{
+	7, 13, 29, 53, 97, 193, 389, 769, 1543, 3079, 6151, 12289, 24593, 49157, 98317, 196613, 393241, 786433, 1572869,
+	3145739, 6291469, 12582917, 25165843, 50331653, 100663319, 201326611, 402653189, 805306457, 1610612741,
+	3221225473, 6442450967, 12884901893, 25769803799, 51539607599, 103079215111, 206158430209, 412316860441,
+	824633720837, 1649267441681, 3298534883417, 6597069766657, 13194139533349, 26388279066671, 52776558133303,
+	105553116266509, 211106232533047, 422212465066001, 844424930132057, 1688849860263953, 3377699720527897,
+	6755399441055827, 13510798882111519, 27021597764223071, 54043195528445957, 108086391056891941,
+	216172782113783843, 432345564227567621, 864691128455135281, 1729382256910270481, 3458764513820540933,
+	6917529027641081903, 13835058055282163729,
+}
+
+// CapacityPrimes returns a table of primes with roughly geometric spacing, each about twice as large as the previous
+// one, which are suitable as growing capacities of open-addressing hash tables.
+func CapacityPrimes() []uint64 {
+	primes := make([]uint64, len(capacityPrimes))
+	copy(primes, capacityPrimes[:])
+	return primes
+}
+
+// NextPrimeCapacity returns the smallest prime of CapacityPrimes that is at least n, so that a hash table for n
+// entries can be sized without searching for a prime. Beyond the table, it returns the smallest prime that is at
+// least n, or 0 if there is none within uint64.
+func NextPrimeCapacity(n uint64) uint64 {
+	i := sort.Search(len(capacityPrimes), func(i int) bool { return capacityPrimes[i] >= n })
+	if i < len(capacityPrimes) {
+		return capacityPrimes[i]
+	}
+	p, _ := nextPrimeMillerRabin(n - 1)
+	return p
+}
@@ -0,0 +1,32 @@
+package primes
+
+import "testing"
+
+func TestCapacityPrimes(t *testing.T) {
+	primes := CapacityPrimes()
+	for i, p := range primes {
+		if prime, _ := millerRabin(p); !prime {
+			t.Errorf("capacity %d is not prime", p)
+		}
+		if i > 0 && (p+3000 < 2*primes[i-1] || p > 2*primes[i-1]+3000) {
+			t.Errorf("capacity %d does not double %d", p, primes[i-1])
+		}
+	}
+	primes[0] = 4
+	if CapacityPrimes()[0] != 7 {
+		t.Error("CapacityPrimes() returns the internal table")
+	}
+}
+
+func TestNextPrimeCapacity(t *testing.T) {
+	for _, c := range []struct{ n, p uint64 }{
+		{0, 7}, {7, 7}, {8, 13}, {1000, 1543}, {1 << 40, 1649267441681},
+		{13835058055282163729, 13835058055282163729},
+		{13835058055282163730, 13835058055282163789}, // beyond the table
+		{1<<64 - 58, 0},
+	} {
+		if p := NextPrimeCapacity(c.n); p != c.p {
+			t.Errorf("NextPrimeCapacity(%d) = %d, expected %d", c.n, p, c.p)
+		}
+	}
+}
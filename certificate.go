@@ -0,0 +1,86 @@
+package primes
+
+import "fmt"
+
+// Certificate is a Pratt certificate, which proves the primality of a number without any further computation than
+// modular exponentiation: Witness is a primitive root modulo Prime, which is shown by checking the prime factors of
+// Prime - 1, each of which is again proven by its own certificate.
+type Certificate struct {
+	Prime   uint64         // certified prime number
+	Witness uint64         // primitive root modulo Prime
+	Factors []*Certificate // certificates for the distinct prime factors of Prime - 1 in ascending order
+}
+
+// Certify creates a Pratt certificate for the prime p, using the factorizer for the factorization of p - 1.
+// If p is not prime, an error wrapping ErrNotPrime is returned, and if p - 1 exceeds the factorizer boundaries, an
+// error wrapping ErrOutOfRange.
+func (f *factorizer) Certify(p uint64) (*Certificate, error) {
+	if !f.set.isPrimeExtended(p) {
+		return nil, fmt.Errorf("%w: %d", ErrNotPrime, p)
+	}
+	return f.certify(p)
+}
+
+// certify creates a Pratt certificate for the prime p.
+func (f *factorizer) certify(p uint64) (*Certificate, error) {
+	if p == 2 {
+		return &Certificate{2, 1, nil}, nil
+	}
+	factors, ok := f.Factorize(p - 1)
+	if !ok {
+		return nil, fmt.Errorf("%w: cannot factorize %d for the certificate of %d", ErrOutOfRange, p-1, p)
+	}
+	c := &Certificate{Prime: p, Factors: make([]*Certificate, len(factors))}
+	for i, pp := range factors {
+		fc, err := f.certify(pp.Prime)
+		if err != nil {
+			return nil, err
+		}
+		c.Factors[i] = fc
+	}
+	for a := uint64(2); a < p; a++ {
+		if isPrimitiveRoot(a, p, factors) {
+			c.Witness = a
+			return c, nil
+		}
+	}
+	// unreachable for primes, which always have a primitive root
+	return nil, fmt.Errorf("%w: no primitive root modulo %d", ErrCorrupt, p)
+}
+
+// isPrimitiveRoot returns true iff a is a primitive root modulo the prime p, where factors is the factorization of p - 1.
+func isPrimitiveRoot(a, p uint64, factors []PrimePower) bool {
+	for _, pp := range factors {
+		if PowMod(a, (p-1)/pp.Prime, p) == 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// VerifyCertificate returns true iff the certificate proves the primality of c.Prime.
+func VerifyCertificate(c *Certificate) bool {
+	if c == nil || c.Prime < 2 {
+		return false
+	}
+	p := c.Prime
+	if p == 2 {
+		return len(c.Factors) == 0
+	}
+	if PowMod(c.Witness, p-1, p) != 1 {
+		return false
+	}
+	rest := p - 1
+	for _, fc := range c.Factors {
+		if fc == nil || fc.Prime < 2 || rest%fc.Prime != 0 {
+			return false
+		}
+		for rest%fc.Prime == 0 {
+			rest /= fc.Prime
+		}
+		if PowMod(c.Witness, (p-1)/fc.Prime, p) == 1 || !VerifyCertificate(fc) {
+			return false
+		}
+	}
+	return rest == 1
+}
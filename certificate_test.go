@@ -0,0 +1,47 @@
+package primes
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCertify(t *testing.T) {
+	set := NewPrimeSet(100000)
+	f := set.Factorizer(100000)
+	it := set.Iterator(0)
+	for p, ok := it.Next(); ok && p <= 100001; p, ok = it.Next() {
+		c, err := f.Certify(p)
+		if err != nil {
+			t.Fatalf("Certify(%d) failed: %s", p, err)
+		}
+		if !VerifyCertificate(c) {
+			t.Fatalf("certificate for %d not verified", p)
+		}
+	}
+	if _, err := f.Certify(100001); !errors.Is(err, ErrNotPrime) {
+		t.Error("100001 = 11 * 9091 should not be certified")
+	}
+	if _, err := f.Certify(1000003); !errors.Is(err, ErrOutOfRange) {
+		t.Error("1000003 should not be certified beyond the factorizer boundaries")
+	}
+}
+
+func TestVerifyCertificate(t *testing.T) {
+	// 15 with witness 2 passes neither the Fermat test nor the factorization of 14
+	forged := []*Certificate{
+		nil,
+		{Prime: 1},
+		{Prime: 15, Witness: 2, Factors: []*Certificate{{Prime: 2, Witness: 1}, {Prime: 7, Witness: 3, Factors: []*Certificate{{Prime: 2, Witness: 1}, {Prime: 3, Witness: 2, Factors: []*Certificate{{Prime: 2, Witness: 1}}}}}}},
+		{Prime: 7, Witness: 2, Factors: []*Certificate{{Prime: 2, Witness: 1}, {Prime: 3, Witness: 2, Factors: []*Certificate{{Prime: 2, Witness: 1}}}}}, // 2 has order 3 modulo 7
+		{Prime: 7, Witness: 3, Factors: []*Certificate{{Prime: 2, Witness: 1}}}, // factor 3 of 6 missing
+	}
+	for _, c := range forged {
+		if VerifyCertificate(c) {
+			t.Errorf("forged certificate %v verified", c)
+		}
+	}
+	valid := &Certificate{Prime: 7, Witness: 3, Factors: []*Certificate{{Prime: 2, Witness: 1}, {Prime: 3, Witness: 2, Factors: []*Certificate{{Prime: 2, Witness: 1}}}}}
+	if !VerifyCertificate(valid) {
+		t.Error("valid certificate for 7 not verified")
+	}
+}
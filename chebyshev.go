@@ -0,0 +1,44 @@
+package primes
+
+import "math"
+
+// Theta returns the first Chebyshev function θ(n), which is the sum of log p for all primes p up to n.
+// If n exceeds the set, the second result is false.
+func (s *set) Theta(n uint64) (float64, bool) {
+	if n > s.largestNumber {
+		return 0, false
+	}
+	return theta(s.Iterator(0), n), true
+}
+
+// theta returns the sum of log p for all primes p of an iterator up to n.
+func theta(it Iterator, n uint64) float64 {
+	sum := 0.0
+	for p, ok := it.Next(); ok && p <= n; p, ok = it.Next() {
+		sum += math.Log(float64(p))
+	}
+	return sum
+}
+
+// Psi returns the second Chebyshev function ψ(n), which is the sum of log p for all prime powers p^k up to n.
+// If n exceeds the set, the second result is false.
+func (s *set) Psi(n uint64) (float64, bool) {
+	if n > s.largestNumber {
+		return 0, false
+	}
+	return psi(s.Iterator(0), n), true
+}
+
+// psi returns the sum of log p for all powers p^k up to n of the primes p of an iterator.
+func psi(it Iterator, n uint64) float64 {
+	sum := 0.0
+	for p, ok := it.Next(); ok && p <= n; p, ok = it.Next() {
+		// count the powers p, p², ... up to n
+		k := 0
+		for q := n; q >= p; q /= p {
+			k++
+		}
+		sum += float64(k) * math.Log(float64(p))
+	}
+	return sum
+}
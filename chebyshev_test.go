@@ -0,0 +1,29 @@
+package primes
+
+import (
+	"math"
+	"testing"
+)
+
+func TestChebyshev(t *testing.T) {
+	set := NewPrimeSet(100000)
+	for _, c := range []struct {
+		n          uint64
+		theta, psi float64
+	}{
+		{1, 0, 0},
+		{2, math.Log(2), math.Log(2)},
+		{10, math.Log(210), math.Log(2520)},
+		{100000, 99685.389269, 100051.564026},
+	} {
+		if theta, ok := set.Theta(c.n); !ok || math.Abs(theta-c.theta) > 1e-6 {
+			t.Errorf("Theta(%d) = %f instead of %f", c.n, theta, c.theta)
+		}
+		if psi, ok := set.Psi(c.n); !ok || math.Abs(psi-c.psi) > 1e-6 {
+			t.Errorf("Psi(%d) = %f instead of %f", c.n, psi, c.psi)
+		}
+	}
+	if _, ok := set.Theta(set.LargestNumber() + 1); ok {
+		t.Error("Theta beyond the set should fail")
+	}
+}
@@ -0,0 +1,224 @@
+package primes
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"slices"
+	"time"
+)
+
+// Binary format of a serialized prime set or sieve checkpoint, all numbers in little endian byte order:
+//
+//	magic        4 bytes  "PSET"
+//	version      uint32   1
+//	limit        uint64   limit the set was requested with
+//	next         uint64   bit index of the next prime to be sieved, or setComplete
+//	words        uint64   number of words of prime bits
+//	bits         words * 8 bytes
+//	checksum     uint32   CRC-32 (IEEE) of all preceding bytes
+const (
+	setMagic    = "PSET"
+	setVersion  = 1
+	setComplete = ^uint64(0) // next index of a completely sieved set
+)
+
+// setHeader is the fixed-size header of a serialized prime set.
+type setHeader struct {
+	Magic   [4]byte
+	Version uint32
+	Limit   uint64
+	Next    uint64
+	Words   uint64
+}
+
+// WithCheckpoint lets the sieve of a new set save its state to the file at path about every interval, so that an
+// interrupted construction can be continued with NewPrimeSetResume. The file is replaced atomically and contains the
+// complete set in the end. Since NewPrimeSet cannot report errors, failed checkpoints are skipped.
+func WithCheckpoint(path string, interval time.Duration) Option {
+	return func(o *options) {
+		o.checkpointPath = path
+		o.checkpointInterval = interval
+	}
+}
+
+// checkpointer returns the checkpoint function for the sieve of a set with the given limit, or nil if checkpoints are
+// disabled.
+func (o *options) checkpointer(limit uint64) func(bits []uint64, next uint) {
+	if o.checkpointPath == "" {
+		return nil
+	}
+	last := time.Now()
+	return func(bits []uint64, next uint) {
+		if time.Since(last) >= o.checkpointInterval {
+			writeSetFile(o.checkpointPath, setHeader{Limit: limit, Next: uint64(next)}, bits)
+			last = time.Now()
+		}
+	}
+}
+
+// checkpointComplete saves the completely sieved prime bits of a set with the given limit if checkpoints are enabled.
+func (o *options) checkpointComplete(limit uint64, bits []uint64) {
+	if o.checkpointPath != "" {
+		writeSetFile(o.checkpointPath, setHeader{Limit: limit, Next: setComplete}, bits)
+	}
+}
+
+// NewPrimeSetResume continues the construction of a prime set from the checkpoint file at path, which was written by
+// WithCheckpoint. If the checkpoint already contains the complete set, it is returned right away. The options are
+// applied as for NewPrimeSet; with WithCheckpoint, the resumed sieve continues to save checkpoints.
+func NewPrimeSetResume(path string, opts ...Option) (Set, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	h, bits, err := readSet(file)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	if h.Next != setComplete {
+		sievePrimeBitSet(bits, uint(h.Next), o.checkpointer(h.Limit))
+	}
+	o.checkpointComplete(h.Limit, bits)
+	s := newSet(bits, o.metrics)
+	if o.rankIndex {
+		s.buildRankIndex()
+	}
+	if s.metrics != nil {
+		s.metrics.SetBuilt(h.Limit, time.Since(start))
+	}
+	return s, nil
+}
+
+// ReadPrimeSet reads a prime set written by Set.WriteTo. The options are applied as for NewPrimeSet, except for
+// WithCheckpoint, which has no effect.
+func ReadPrimeSet(r io.Reader, opts ...Option) (Set, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	h, bits, err := readSet(r)
+	if err != nil {
+		return nil, err
+	}
+	if h.Next != setComplete {
+		return nil, fmt.Errorf("%w: prime set is incomplete, use NewPrimeSetResume", ErrCorrupt)
+	}
+	s := newSet(bits, o.metrics)
+	if o.rankIndex {
+		s.buildRankIndex()
+	}
+	return s, nil
+}
+
+// WriteTo writes the set in a binary format to w, which can be read again with ReadPrimeSet.
+func (s *set) WriteTo(w io.Writer) (int64, error) {
+	return writeSet(w, setHeader{Limit: s.largestNumber, Next: setComplete}, s.words())
+}
+
+// writeSet writes a header and the prime bits to w. Magic, version and number of words are filled in.
+func writeSet(w io.Writer, h setHeader, bits []uint64) (int64, error) {
+	copy(h.Magic[:], setMagic)
+	h.Version = setVersion
+	h.Words = uint64(len(bits))
+	crc := crc32.NewIEEE()
+	bw := bufio.NewWriter(io.MultiWriter(w, crc))
+	if err := binary.Write(bw, binary.LittleEndian, &h); err != nil {
+		return 0, err
+	}
+	if err := binary.Write(bw, binary.LittleEndian, bits); err != nil {
+		return 0, err
+	}
+	if err := bw.Flush(); err != nil {
+		return 0, err
+	}
+	if err := binary.Write(w, binary.LittleEndian, crc.Sum32()); err != nil {
+		return 0, err
+	}
+	return int64(binary.Size(h)) + int64(len(bits))*8 + 4, nil
+}
+
+// writeSetFile atomically replaces the file at path with the given set data.
+func writeSetFile(path string, h setHeader, bits []uint64) error {
+	tmp := path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	_, err = writeSet(file, h, bits)
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// readSet reads a header and the prime bits from r and verifies them. Invalid or missing data is reported with an
+// error wrapping ErrCorrupt, also if the header claims more bits than follow.
+func readSet(r io.Reader) (setHeader, []uint64, error) {
+	crc := crc32.NewIEEE()
+	br := io.TeeReader(bufio.NewReader(r), crc)
+	var h setHeader
+	if err := binary.Read(br, binary.LittleEndian, &h); err != nil {
+		return h, nil, err
+	}
+	if string(h.Magic[:]) != setMagic || h.Version != setVersion {
+		return h, nil, fmt.Errorf("%w: no prime set data of version %d", ErrCorrupt, setVersion)
+	}
+	if h.Words > maxSetWords {
+		return h, nil, fmt.Errorf("%w: prime set of %d words exceeds the addressable memory", ErrCorrupt, h.Words)
+	}
+	if h.Limit < 5 || h.Words != uint64(setWords(h.Limit)) {
+		return h, nil, fmt.Errorf("%w: %d words of prime bits for limit %d", ErrCorrupt, h.Words, h.Limit)
+	}
+	if h.Next != setComplete && h.Next >= h.Words<<6 {
+		return h, nil, fmt.Errorf("%w: next sieve index %d beyond the prime bits", ErrCorrupt, h.Next)
+	}
+	bits, err := readWords(br, h.Words)
+	if err != nil {
+		return h, nil, err
+	}
+	sum := crc.Sum32()
+	var checksum uint32
+	if err := binary.Read(br, binary.LittleEndian, &checksum); err != nil {
+		return h, nil, err
+	}
+	if checksum != sum {
+		return h, nil, fmt.Errorf("%w: checksum %#x instead of %#x", ErrCorrupt, checksum, sum)
+	}
+	return h, bits, nil
+}
+
+// readWordsChunk is the number of words readWords reads at once.
+const readWordsChunk = 1 << 16
+
+// readWords reads n words in little endian byte order from r. They are read in chunks, so that the memory only grows
+// with the data that is actually present and a forged count cannot exhaust it. If the data ends before, an error
+// wrapping ErrCorrupt is returned.
+func readWords(r io.Reader, n uint64) ([]uint64, error) {
+	words := make([]uint64, 0, min(n, readWordsChunk))
+	for uint64(len(words)) < n {
+		k := int(min(n-uint64(len(words)), readWordsChunk))
+		words = slices.Grow(words, k)[:len(words)+k]
+		if err := binary.Read(r, binary.LittleEndian, words[len(words)-k:]); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil, fmt.Errorf("%w: data ends before %d words", ErrCorrupt, n)
+			}
+			return nil, err
+		}
+	}
+	return words, nil
+}
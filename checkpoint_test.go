@@ -0,0 +1,122 @@
+package primes
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docwalter/primes/bitset"
+)
+
+func TestSetSerialization(t *testing.T) {
+	set := NewPrimeSet(100000)
+	var buf bytes.Buffer
+	n, err := set.WriteTo(&buf)
+	if err != nil || n != int64(buf.Len()) {
+		t.Fatalf("WriteTo() = %d, %v for %d bytes", n, err, buf.Len())
+	}
+	data := buf.Bytes()
+	read, err := ReadPrimeSet(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.LargestNumber() != set.LargestNumber() || read.LargestPrime() != set.LargestPrime() {
+		t.Errorf("read set up to %d instead of %d", read.LargestNumber(), set.LargestNumber())
+	}
+	for p := uint64(0); p <= set.LargestNumber(); p++ {
+		if read.IsPrime(p) != set.IsPrime(p) {
+			t.Fatalf("read set differs at %d", p)
+		}
+	}
+
+	corrupt := append([]byte(nil), data...)
+	corrupt[len(corrupt)/2] ^= 1
+	if _, err := ReadPrimeSet(bytes.NewReader(corrupt)); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("corrupt data should be rejected with ErrCorrupt instead of %v", err)
+	}
+	if _, err := ReadPrimeSet(bytes.NewReader(data[:len(data)-1])); err == nil {
+		t.Error("truncated data should be rejected")
+	}
+
+	// a header claiming a huge set must be rejected before the bits are allocated
+	var huge bytes.Buffer
+	h := setHeader{Version: setVersion, Limit: 1 << 62, Next: setComplete, Words: 1 << 55}
+	copy(h.Magic[:], setMagic)
+	binary.Write(&huge, binary.LittleEndian, &h)
+	if _, err := ReadPrimeSet(&huge); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("huge set should be rejected with ErrCorrupt instead of %v", err)
+	}
+
+	// a consistent header of 256 MB must be rejected when the bits run out, without allocating all of them
+	huge.Reset()
+	h = setHeader{Version: setVersion, Limit: indexToNumber((1<<25 - 1) << 6), Next: setComplete, Words: 1 << 25}
+	copy(h.Magic[:], setMagic)
+	binary.Write(&huge, binary.LittleEndian, &h)
+	huge.Write(make([]byte, 1000))
+	if _, err := ReadPrimeSet(&huge); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("set without its bits should be rejected with ErrCorrupt instead of %v", err)
+	}
+}
+
+func TestCheckpointResume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sieve.ckpt")
+	expected := NewPrimeSet(1000000)
+
+	// simulate an interrupted sieve by saving the state after the first primes
+	bits := make(bitset.BitSet, setWords(1000000))
+	bits.SetAll()
+	interrupted := func(bits []uint64, next uint) {
+		writeSetFile(path, setHeader{Limit: 1000000, Next: uint64(next)}, bits)
+		panic("interrupted")
+	}
+	func() {
+		defer func() { recover() }()
+		sievePrimeBitSet(bits, 0, interrupted)
+	}()
+	if _, err := ReadPrimeSet(mustOpen(t, path)); err == nil {
+		t.Error("an incomplete checkpoint should not be read as a set")
+	}
+
+	resumed, err := NewPrimeSetResume(path, WithCheckpoint(path, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resumed.LargestPrime() != expected.LargestPrime() {
+		t.Errorf("resumed set has largest prime %d instead of %d", resumed.LargestPrime(), expected.LargestPrime())
+	}
+	for p := uint64(0); p <= expected.LargestNumber(); p++ {
+		if resumed.IsPrime(p) != expected.IsPrime(p) {
+			t.Fatalf("resumed set differs at %d", p)
+		}
+	}
+
+	// the final checkpoint contains the complete set
+	if set, err := ReadPrimeSet(mustOpen(t, path)); err != nil || set.LargestPrime() != expected.LargestPrime() {
+		t.Errorf("final checkpoint cannot be read: %v", err)
+	}
+	if _, err := NewPrimeSetResume(path + ".missing"); err == nil {
+		t.Error("resuming from a missing file should fail")
+	}
+}
+
+func TestCheckpointDuringSieve(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sieve.ckpt")
+	set := NewPrimeSet(1000000, WithCheckpoint(path, 0))
+	resumed, err := NewPrimeSetResume(path)
+	if err != nil || resumed.LargestPrime() != set.LargestPrime() {
+		t.Errorf("NewPrimeSetResume() = %v, %v", resumed, err)
+	}
+}
+
+// mustOpen opens a file for reading and closes it at the end of the test.
+func mustOpen(t *testing.T, path string) *os.File {
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { file.Close() })
+	return file
+}
@@ -0,0 +1,54 @@
+package primes
+
+import (
+	"encoding/binary"
+	"hash/crc64"
+)
+
+// crc64Table is the table for the checksums of prime sets.
+var crc64Table = crc64.MakeTable(crc64.ECMA)
+
+// Checksum returns a CRC-64 (ECMA) checksum of the largest number and the prime bits of the set. Sets with the same
+// checksum are equal with a very high probability, so it can be used to validate a persisted set after loading or to
+// compare the sets of distributed workers.
+func (s *set) Checksum() uint64 {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], s.largestNumber)
+	sum := crc64.Update(0, crc64Table, buf[:])
+	chunk := make([]byte, 0, 8*1024)
+	for k := uint(0); k < s.wordCount(); k++ {
+		if chunk = binary.LittleEndian.AppendUint64(chunk, s.word(k)); len(chunk) == cap(chunk) {
+			sum = crc64.Update(sum, crc64Table, chunk)
+			chunk = chunk[:0]
+		}
+	}
+	return crc64.Update(sum, crc64Table, chunk)
+}
+
+// Equal returns true iff both sets reach up to the same largest number and contain the same primes.
+func Equal(a, b Set) bool {
+	if a.LargestNumber() != b.LargestNumber() || a.LargestPrime() != b.LargestPrime() {
+		return false
+	}
+	if sa, ok := a.(*set); ok {
+		if sb, ok := b.(*set); ok {
+			for k := uint(0); k < sa.wordCount(); k++ {
+				if sa.word(k) != sb.word(k) {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	ia, ib := a.Iterator(0), b.Iterator(0)
+	for {
+		p, ok := ia.Next()
+		q, qok := ib.Next()
+		if p != q || ok != qok {
+			return false
+		}
+		if !ok {
+			return true
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package primes
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestChecksumAndEqual(t *testing.T) {
+	a, b := NewPrimeSet(100000), NewPrimeSet(100000)
+	if a.Checksum() != b.Checksum() || !Equal(a, b) {
+		t.Error("equal sets should have the same checksum")
+	}
+	var buf bytes.Buffer
+	a.WriteTo(&buf)
+	read, err := ReadPrimeSet(&buf)
+	if err != nil || read.Checksum() != a.Checksum() || !Equal(a, read) {
+		t.Errorf("read set differs: %v", err)
+	}
+
+	c := NewPrimeSet(200000)
+	if a.Checksum() == c.Checksum() || Equal(a, c) {
+		t.Error("sets of different size should differ")
+	}
+	corrupt := NewPrimeSet(100000).(*set)
+	corrupt.bits[100] ^= 1 << 10
+	if a.Checksum() == corrupt.Checksum() || Equal(a, corrupt) {
+		t.Error("sets with different primes should differ")
+	}
+}
@@ -0,0 +1,58 @@
+package primes
+
+import (
+	"math/big"
+	"strconv"
+)
+
+// IsCircularPrime returns true iff all rotations of the decimal digits of n are prime, e.g. 197, 971 and 719.
+// Rotations beyond the set are tested with Miller-Rabin, or with a probabilistic test if they exceed an uint64.
+func (s *set) IsCircularPrime(n uint64) bool {
+	if !s.isPrimeExtended(n) {
+		return false
+	}
+	digits := strconv.FormatUint(n, 10)
+	for i := 1; i < len(digits); i++ {
+		if !s.isDecimalPrime(digits[i:] + digits[:i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsEmirp returns true iff n is prime and the reversal of its decimal digits is a different prime, e.g. 13 and 31.
+// Reversals beyond the set are tested with Miller-Rabin, or with a probabilistic test if they exceed an uint64.
+func (s *set) IsEmirp(n uint64) bool {
+	if !s.isPrimeExtended(n) {
+		return false
+	}
+	digits := []byte(strconv.FormatUint(n, 10))
+	reversed := make([]byte, len(digits))
+	for i, d := range digits {
+		reversed[len(digits)-1-i] = d
+	}
+	if string(reversed) == string(digits) {
+		// palindromic primes are not emirps
+		return false
+	}
+	return s.isDecimalPrime(string(reversed))
+}
+
+// isDecimalPrime returns true iff the decimal number represented by digits is prime.
+func (s *set) isDecimalPrime(digits string) bool {
+	if n, err := strconv.ParseUint(digits, 10, 64); err == nil {
+		return s.isPrimeExtended(n)
+	}
+	b, _ := new(big.Int).SetString(digits, 10)
+	return b.ProbablyPrime(20)
+}
+
+// CircularPrimes returns an iterator over all circular primes in the set up to max.
+func (s *set) CircularPrimes(max uint64) Iterator {
+	return s.filterUpTo(0, max, s.IsCircularPrime)
+}
+
+// Emirps returns an iterator over all emirps in the set up to max.
+func (s *set) Emirps(max uint64) Iterator {
+	return s.filterUpTo(0, max, s.IsEmirp)
+}
@@ -0,0 +1,30 @@
+package primes
+
+import "testing"
+
+func TestCircularPrimes(t *testing.T) {
+	set := NewPrimeSet(1000)
+	testIterator(t, "CircularPrimes(1000)", set.CircularPrimes(1000), []uint64{
+		2, 3, 5, 7, 11, 13, 17, 31, 37, 71, 73, 79, 97, 113, 131, 197, 199, 311, 337, 373, 719, 733, 919, 971, 991,
+	})
+	if !set.IsCircularPrime(999331) {
+		t.Error("999331 should be a circular prime")
+	}
+	if set.IsCircularPrime(19) {
+		t.Error("19 should not be a circular prime")
+	}
+}
+
+func TestEmirps(t *testing.T) {
+	set := NewPrimeSet(1000)
+	testIterator(t, "Emirps(200)", set.Emirps(200), []uint64{13, 17, 31, 37, 71, 73, 79, 97, 107, 113, 149, 157, 167, 179, 199})
+	if !set.IsEmirp(1000000000000002901) || !set.IsEmirp(10000000000000000091) {
+		t.Error("1000000000000002901 and 10000000000000000091 should be emirps")
+	}
+	if set.IsEmirp(11) || set.IsEmirp(19) {
+		t.Error("11 and 19 should not be emirps")
+	}
+	if set.IsEmirp(18446744073709551557) {
+		t.Error("the largest 64 bit prime should not be an emirp, since its reversal is divisible by 5")
+	}
+}
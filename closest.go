@@ -0,0 +1,35 @@
+package primes
+
+// ClosestPrime returns the prime nearest to n, or the smaller one of two equally near primes. It scans the prime bits
+// backward and forward from n. If n exceeds the set or the nearest prime may lie beyond it, the second result is
+// false.
+func (s *set) ClosestPrime(n uint64) (uint64, bool) {
+	if n > s.largestNumber {
+		return 0, false
+	}
+	if n <= 2 {
+		return 2, true
+	}
+	below := uint64(2) // largest prime up to n
+	if i, found := s.prevBit(numberToIndex(n)); found {
+		below = indexToNumber(i)
+	}
+	if below == n {
+		return n, true
+	}
+	i := numberToIndex(n)
+	if indexToNumber(i) < n {
+		i++
+	}
+	if i, found := s.nextBit(i); found {
+		if above := indexToNumber(i); above-n < n-below {
+			return above, true
+		}
+		return below, true
+	}
+	// all primes beyond the set are farther away than its largest number
+	if n-below <= s.largestNumber-n {
+		return below, true
+	}
+	return 0, false
+}
@@ -0,0 +1,35 @@
+package primes
+
+import "testing"
+
+func TestClosestPrime(t *testing.T) {
+	set := NewPrimeSet(1000)
+	for _, c := range []struct{ n, p uint64 }{
+		{0, 2}, {1, 2}, {2, 2}, {3, 3}, {4, 3}, {5, 5}, {6, 5}, {9, 7}, {10, 11}, {12, 11}, {15, 13}, {16, 17},
+		{114, 113}, {120, 113}, {121, 127}, {997, 997},
+	} {
+		if p, ok := set.ClosestPrime(c.n); !ok || p != c.p {
+			t.Errorf("ClosestPrime(%d) = %d, %v, expected %d", c.n, p, ok, c.p)
+		}
+	}
+	// compare with a simple search
+	for n := uint64(3); n <= 1000; n++ {
+		for d := uint64(0); ; d++ {
+			if set.IsPrime(n - d) {
+				if p, _ := set.ClosestPrime(n); p != n-d {
+					t.Fatalf("ClosestPrime(%d) = %d instead of %d", n, p, n-d)
+				}
+				break
+			}
+			if set.IsPrime(n + d) {
+				if p, _ := set.ClosestPrime(n); p != n+d {
+					t.Fatalf("ClosestPrime(%d) = %d instead of %d", n, p, n+d)
+				}
+				break
+			}
+		}
+	}
+	if _, ok := set.ClosestPrime(set.LargestNumber() + 1); ok {
+		t.Error("ClosestPrime() beyond the set should fail")
+	}
+}
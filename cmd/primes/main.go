@@ -0,0 +1,290 @@
+/*
+Command primes lists, counts and factorizes prime numbers using package primes.
+
+Usage:
+
+	primes list [--from n] [--format f] [--state s] [--to] n     list all primes in [from, to]
+	primes count [--from n] [--format f] [--to] n                count all primes in [from, to]
+	primes factors [--from n] [--format f] [--state s] [--to] n  factorize all numbers in [from, to]
+	primes isprime [--format f] n...                             check the given numbers for primality
+	primes factor [--format f] n...                              factorize the given numbers
+
+The end of a range can be given with --to or as the only argument, e.g. primes count 1e9. Numbers may be written in
+scientific notation like 1e9. The output format f is one of text (default), json or csv.
+
+Long dumps of list and factors can be resumed with a state file s, which records the last number handled. If it
+exists, the dump continues after that number without a header, so its output can be appended to the earlier one,
+e.g. primes list --state list.state 1e12 >> primes.txt. Resuming is not supported for json output.
+*/
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/docwalter/primes"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "primes:", err)
+		os.Exit(2)
+	}
+}
+
+// run executes the subcommand given by args and writes its output to stdout.
+func run(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing subcommand, use one of list, count, factors, isprime, factor")
+	}
+	flags := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	from := flags.String("from", "0", "smallest number of the range")
+	to := flags.String("to", "", "largest number of the range")
+	format := flags.String("format", "text", "output format: text, json or csv")
+	statePath := flags.String("state", "", "state file for resuming list and factors")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	w := bufio.NewWriter(stdout)
+	out, err := newOutput(w, *format)
+	if err != nil {
+		return err
+	}
+
+	if *statePath != "" && (args[0] != "list" && args[0] != "factors" || *format == "json") {
+		return fmt.Errorf("--state only applies to list and factors with text or csv output")
+	}
+
+	switch args[0] {
+	case "list", "count", "factors":
+		end, err := rangeEnd(*to, flags.Args())
+		if err != nil {
+			return err
+		}
+		lo, hi, err := parseRange(*from, end)
+		if err != nil {
+			return err
+		}
+		switch args[0] {
+		case "count":
+			err = count(out, lo, hi)
+		default:
+			var state *dumpState
+			if state, err = newDumpState(*statePath, args[0], lo, hi, w); err != nil {
+				return err
+			}
+			if args[0] == "list" {
+				err = list(out, state)
+			} else {
+				err = factors(out, state)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	case "isprime", "factor":
+		numbers, err := parseNumbers(flags.Args())
+		if err != nil {
+			return err
+		}
+		if args[0] == "isprime" {
+			isPrime(out, numbers)
+		} else {
+			factor(out, numbers)
+		}
+	default:
+		return fmt.Errorf("unknown subcommand %q", args[0])
+	}
+	out.close()
+	return w.Flush()
+}
+
+// list writes all primes in the range of the dump state that are not handled yet. It streams them from a segmented
+// sieve of the remaining range, so neither memory nor the time until a resumed dump continues depend on the range.
+func list(out output, state *dumpState) error {
+	if !state.resumed {
+		out.header("prime")
+	}
+	if state.done {
+		return nil
+	}
+	var err error
+	primes.SievePrimesRange(state.next, state.hi, func(p uint64) bool {
+		out.row(p)
+		err = state.handled(p)
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+	return state.finish()
+}
+
+// count writes the number of primes in [lo, hi].
+func count(out output, lo, hi uint64) error {
+	set, err := newSet(hi)
+	if err != nil {
+		return err
+	}
+	out.header("from", "to", "count")
+	out.row(lo, hi, set.CountRange(lo, hi))
+	return nil
+}
+
+// factorWindow is the number of consecutive numbers factorized at once by factors.
+const factorWindow = 1 << 16
+
+// factors writes the prime factorizations of all numbers in the range of the dump state that are not handled yet.
+func factors(out output, state *dumpState) error {
+	set, err := newSet(primes.Sqrt(state.hi))
+	if err != nil {
+		return err
+	}
+	if !state.resumed {
+		out.header("n", "factors")
+	}
+	for lo := state.next; !state.done; lo = state.next {
+		hi := state.hi
+		if hi-lo >= factorWindow {
+			hi = lo + factorWindow - 1
+		}
+		f := set.FactorizerRange(lo, hi)
+		for n := lo; ; n++ {
+			out.row(n, factorization(f.Factorize(n)))
+			if err := state.handled(n); err != nil {
+				return err
+			}
+			if n == hi {
+				break
+			}
+		}
+	}
+	return state.finish()
+}
+
+// isPrime writes the primality of the given numbers.
+func isPrime(out output, numbers []uint64) {
+	oracle := primes.NewMillerRabinOracle()
+	out.header("n", "prime")
+	for _, n := range numbers {
+		out.row(n, oracle.IsPrime(n))
+	}
+}
+
+// factor writes the prime factorizations of the given numbers.
+func factor(out output, numbers []uint64) {
+	oracle := primes.NewSetOracle(primes.NewPrimeSet(1 << 16))
+	out.header("n", "factors")
+	for _, n := range numbers {
+		out.row(n, factorization(oracle.Factor(n)))
+	}
+}
+
+// factorization returns the factors for a row of factor or factors, or an error message if the number could not be
+// factorized. The empty factorization of 1 is kept non-nil, so that it is written as an empty JSON array.
+func factorization(factors []primes.PrimePower, ok bool) interface{} {
+	if !ok {
+		return "error: not factorized"
+	}
+	if factors == nil {
+		factors = []primes.PrimePower{}
+	}
+	return factors
+}
+
+// newSet creates a prime set reaching up to hi.
+func newSet(hi uint64) (primes.Set, error) {
+	const maxLimit = 1 << 40 // about 40 GB of prime bits
+	if hi > maxLimit {
+		return nil, fmt.Errorf("range end %d exceeds the maximum of %d", hi, uint64(maxLimit))
+	}
+	if hi < 5 {
+		hi = 5
+	}
+	return primes.NewPrimeSet(hi), nil
+}
+
+// rangeEnd returns the end of a range, which is given either by --to or by the only positional argument.
+func rangeEnd(to string, args []string) (string, error) {
+	if len(args) == 0 {
+		return to, nil
+	}
+	if to != "" || len(args) > 1 {
+		return "", fmt.Errorf("unexpected arguments %q", args)
+	}
+	return args[0], nil
+}
+
+// parseRange parses the boundaries of a range.
+func parseRange(from, to string) (uint64, uint64, error) {
+	if to == "" {
+		return 0, 0, fmt.Errorf("missing range end, use --to n or n")
+	}
+	lo, err := parseNumber(from)
+	if err != nil {
+		return 0, 0, err
+	}
+	hi, err := parseNumber(to)
+	if err != nil {
+		return 0, 0, err
+	}
+	if lo > hi {
+		return 0, 0, fmt.Errorf("empty range [%d, %d]", lo, hi)
+	}
+	return lo, hi, nil
+}
+
+// parseNumbers parses a list of numbers.
+func parseNumbers(args []string) ([]uint64, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("missing numbers")
+	}
+	numbers := make([]uint64, len(args))
+	for i, arg := range args {
+		n, err := parseNumber(arg)
+		if err != nil {
+			return nil, err
+		}
+		numbers[i] = n
+	}
+	return numbers, nil
+}
+
+// parseNumber parses a non-negative integer, which may be written in scientific notation like 1e6 or 2.5e3.
+func parseNumber(s string) (uint64, error) {
+	mantissa, exponent := s, ""
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		mantissa, exponent = s[:i], s[i+1:]
+	}
+	digits, fraction := mantissa, ""
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		digits, fraction = mantissa[:i], mantissa[i+1:]
+	}
+	e := 0
+	if exponent != "" {
+		var err error
+		if e, err = strconv.Atoi(exponent); err != nil || e < 0 {
+			return 0, fmt.Errorf("invalid number %q", s)
+		}
+	}
+	// shift the decimal point by the exponent, the remaining fraction must be zero
+	for e > 0 && fraction != "" {
+		digits += fraction[:1]
+		fraction = fraction[1:]
+		e--
+	}
+	digits += strings.Repeat("0", e)
+	if strings.Trim(fraction, "0") != "" || digits == "" {
+		return 0, fmt.Errorf("invalid number %q", s)
+	}
+	n, err := strconv.ParseUint(digits, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", s)
+	}
+	return n, nil
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseNumber(t *testing.T) {
+	for s, expected := range map[string]uint64{"0": 0, "123": 123, "1e6": 1000000, "2.5e3": 2500, "1E2": 100, "18446744073709551615": 18446744073709551615} {
+		if n, err := parseNumber(s); err != nil || n != expected {
+			t.Errorf("parseNumber(%q) = %d, %v instead of %d", s, n, err, expected)
+		}
+	}
+	for _, s := range []string{"", "-1", "1.5", "1e-3", "abc", "1e20", "2.55e1"} {
+		if n, err := parseNumber(s); err == nil {
+			t.Errorf("parseNumber(%q) = %d should fail", s, n)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	for _, c := range []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"list", "--from", "10", "--to", "30"}, "11\n13\n17\n19\n23\n29\n"},
+		{[]string{"list", "--to", "10", "--format", "json"}, "[2,3,5,7]\n"},
+		{[]string{"list", "--to", "5", "--format", "csv"}, "prime\n2\n3\n5\n"},
+		{[]string{"count", "--to", "1e6"}, "0 1000000 78498\n"},
+		{[]string{"count", "1e6"}, "0 1000000 78498\n"},
+		{[]string{"list", "--from", "20", "30"}, "23\n29\n"},
+		{[]string{"count", "--from", "100", "--to", "200", "--format", "json"}, "[{\"from\":100,\"to\":200,\"count\":21}]\n"},
+		{[]string{"isprime", "1000003", "1000001"}, "1000003 true\n1000001 false\n"},
+		{[]string{"isprime", "--format", "csv", "7"}, "n,prime\n7,true\n"},
+		{[]string{"factor", "123456789", "1"}, "123456789 3^2 3607 3803\n1\n"},
+		{[]string{"factor", "--format", "csv", "0"}, "n,factors\n0,error: not factorized\n"},
+		{[]string{"factors", "--format", "json", "2"}, "[{\"n\":0,\"factors\":\"error: not factorized\"},{\"n\":1,\"factors\":[]},{\"n\":2,\"factors\":[{\"prime\":2,\"exponent\":1}]}]\n"},
+		{[]string{"factor", "--format", "json", "12"}, "[{\"n\":12,\"factors\":[{\"prime\":2,\"exponent\":2},{\"prime\":3,\"exponent\":1}]}]\n"},
+		{[]string{"factor", "--format", "csv", "12"}, "n,factors\n12,2^2*3\n"},
+	} {
+		var out bytes.Buffer
+		if err := run(c.args, &out); err != nil {
+			t.Errorf("%v failed: %s", c.args, err)
+		} else if out.String() != c.expected {
+			t.Errorf("%v returned %q instead of %q", c.args, out.String(), c.expected)
+		}
+	}
+	for _, args := range [][]string{nil, {"unknown"}, {"list"}, {"list", "--to", "x"}, {"count", "--to", "10", "20"},
+		{"count", "10", "20"}, {"factor"}, {"isprime", "--format", "xml", "1"}} {
+		if err := run(args, &bytes.Buffer{}); err == nil {
+			t.Errorf("%v should fail", args)
+		}
+	}
+}
+
+func TestRunResume(t *testing.T) {
+	state := filepath.Join(t.TempDir(), "state")
+	for _, c := range []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"list", "--state", state, "--from", "10", "30"}, "11\n13\n17\n19\n23\n29\n"},
+		{[]string{"list", "--state", state, "--from", "10", "30"}, ""},
+	} {
+		var out bytes.Buffer
+		if err := run(c.args, &out); err != nil {
+			t.Errorf("%v failed: %s", c.args, err)
+		} else if out.String() != c.expected {
+			t.Errorf("%v returned %q instead of %q", c.args, out.String(), c.expected)
+		}
+	}
+
+	// an interrupted dump continues after the last number handled, without repeating the header
+	for _, c := range []struct {
+		state    string
+		args     []string
+		expected string
+	}{
+		{"list 10 30 13\n", []string{"list", "--state", state, "--from", "10", "30"}, "17\n19\n23\n29\n"},
+		{"factors 0 12 9\n", []string{"factors", "--state", state, "--format", "csv", "12"}, "10,2*5\n11,11\n12,2^2*3\n"},
+	} {
+		if err := os.WriteFile(state, []byte(c.state), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		var out bytes.Buffer
+		if err := run(c.args, &out); err != nil {
+			t.Errorf("%v failed: %s", c.args, err)
+		} else if out.String() != c.expected {
+			t.Errorf("%v returned %q instead of %q", c.args, out.String(), c.expected)
+		}
+		if data, _ := os.ReadFile(state); !strings.HasSuffix(string(data), " "+c.args[len(c.args)-1]+"\n") {
+			t.Errorf("%v left the state %q", c.args, data)
+		}
+	}
+
+	for _, args := range [][]string{{"list", "--state", state, "20"}, {"count", "--state", state, "12"},
+		{"list", "--state", state, "--format", "json", "12"}} {
+		if err := run(args, &bytes.Buffer{}); err == nil {
+			t.Errorf("%v should fail", args)
+		}
+	}
+}
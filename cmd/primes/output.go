@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/docwalter/primes"
+)
+
+// output writes tabular results in one of the supported formats.
+type output interface {
+	header(columns ...string)  // starts the output with the given column names
+	row(values ...interface{}) // writes a single row
+	close()                    // finishes the output
+}
+
+// newOutput creates an output for the given format.
+func newOutput(w *bufio.Writer, format string) (output, error) {
+	switch format {
+	case "text":
+		return &textOutput{w}, nil
+	case "json":
+		return &jsonOutput{w: w}, nil
+	case "csv":
+		return &csvOutput{w}, nil
+	}
+	return nil, fmt.Errorf("unknown output format %q", format)
+}
+
+// textOutput writes the values of each row separated by spaces.
+type textOutput struct {
+	w *bufio.Writer
+}
+
+func (o *textOutput) header(columns ...string) {}
+
+func (o *textOutput) row(values ...interface{}) {
+	for i, v := range values {
+		s := fmt.Sprint(v)
+		if factors, ok := v.([]primes.PrimePower); ok {
+			s = formatFactors(factors, " ")
+		}
+		if i > 0 && s != "" {
+			o.w.WriteByte(' ')
+		}
+		o.w.WriteString(s)
+	}
+	o.w.WriteByte('\n')
+}
+
+func (o *textOutput) close() {}
+
+// csvOutput writes comma separated values with a header line.
+type csvOutput struct {
+	w *bufio.Writer
+}
+
+func (o *csvOutput) header(columns ...string) {
+	o.w.WriteString(strings.Join(columns, ","))
+	o.w.WriteByte('\n')
+}
+
+func (o *csvOutput) row(values ...interface{}) {
+	for i, v := range values {
+		if i > 0 {
+			o.w.WriteByte(',')
+		}
+		if factors, ok := v.([]primes.PrimePower); ok {
+			o.w.WriteString(formatFactors(factors, "*"))
+		} else {
+			fmt.Fprint(o.w, v)
+		}
+	}
+	o.w.WriteByte('\n')
+}
+
+func (o *csvOutput) close() {}
+
+// jsonOutput writes a JSON array with one element per row. Single-column rows are written as plain values,
+// all other rows as objects keyed by the column names.
+type jsonOutput struct {
+	w       *bufio.Writer
+	columns []string // column names
+	rows    int      // number of rows written so far
+}
+
+func (o *jsonOutput) header(columns ...string) {
+	o.columns = columns
+	o.w.WriteByte('[')
+}
+
+func (o *jsonOutput) row(values ...interface{}) {
+	if o.rows > 0 {
+		o.w.WriteByte(',')
+	}
+	o.rows++
+	if len(values) == 1 {
+		writeJSON(o.w, values[0])
+		return
+	}
+	o.w.WriteByte('{')
+	for i, v := range values {
+		if i > 0 {
+			o.w.WriteByte(',')
+		}
+		writeJSON(o.w, o.columns[i])
+		o.w.WriteByte(':')
+		writeJSON(o.w, v)
+	}
+	o.w.WriteByte('}')
+}
+
+func (o *jsonOutput) close() {
+	o.w.WriteString("]\n")
+}
+
+// writeJSON writes a single JSON value.
+func writeJSON(w *bufio.Writer, v interface{}) {
+	b, _ := json.Marshal(v)
+	w.Write(b)
+}
+
+// formatFactors formats a factorization like 2^3 5 with the given separator.
+func formatFactors(factors []primes.PrimePower, sep string) string {
+	s := make([]string, len(factors))
+	for i, f := range factors {
+		if f.Exponent == 1 {
+			s[i] = fmt.Sprint(f.Prime)
+		} else {
+			s[i] = fmt.Sprintf("%d^%d", f.Prime, f.Exponent)
+		}
+	}
+	return strings.Join(s, sep)
+}
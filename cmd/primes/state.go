@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// stateInterval is the number of rows after which the progress of a dump is saved.
+const stateInterval = 1 << 16
+
+// dumpState records the progress of a dump of list or factors in a state file, so that an interrupted dump can be
+// resumed after the last number that was handled. The file holds a single line with the subcommand, the range and
+// that number. Since the output is flushed before the state is saved, a resumed dump repeats at most the rows of the
+// last interval, but never misses one.
+type dumpState struct {
+	path    string        // state file, or "" if the progress is not recorded
+	command string        // subcommand of the dump
+	lo, hi  uint64        // range of the dump
+	w       *bufio.Writer // output, which is flushed before saving the state
+	next    uint64        // first number that is not handled yet
+	done    bool          // true iff the whole range is handled
+	resumed bool          // true iff the dump continues an earlier one
+	rows    int           // number of rows since the state was saved last
+}
+
+// newDumpState returns the state of a dump of the range [lo, hi], which is read from the state file if it exists.
+func newDumpState(path, command string, lo, hi uint64, w *bufio.Writer) (*dumpState, error) {
+	s := &dumpState{path: path, command: command, lo: lo, hi: hi, w: w, next: lo}
+	if path == "" {
+		return s, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var command2 string
+	var lo2, hi2, last uint64
+	if _, err := fmt.Sscanf(string(data), "%s %d %d %d\n", &command2, &lo2, &hi2, &last); err != nil ||
+		last < lo2 || last > hi2 {
+		return nil, fmt.Errorf("invalid state file %s", path)
+	}
+	if command2 != command || lo2 != lo || hi2 != hi {
+		return nil, fmt.Errorf("state file %s belongs to %s [%d, %d]", path, command2, lo2, hi2)
+	}
+	s.next, s.done, s.resumed = last+1, last == hi, true
+	return s, nil
+}
+
+// handled records that all numbers up to n are handled and saves the state after every stateInterval rows.
+func (s *dumpState) handled(n uint64) error {
+	s.next, s.done = n+1, n == s.hi
+	if s.rows++; s.rows < stateInterval {
+		return nil
+	}
+	return s.save()
+}
+
+// finish records that the whole range is handled and saves the state.
+func (s *dumpState) finish() error {
+	s.next, s.done = s.hi+1, true
+	return s.save()
+}
+
+// save flushes the output and writes the state file, replacing the previous one at once.
+func (s *dumpState) save() error {
+	s.rows = 0
+	if s.path == "" || s.next == s.lo && !s.done {
+		return nil
+	}
+	if err := s.w.Flush(); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	state := fmt.Sprintf("%s %d %d %d\n", s.command, s.lo, s.hi, s.next-1)
+	if err := os.WriteFile(tmp, []byte(state), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
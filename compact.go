@@ -0,0 +1,355 @@
+package primes
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math/bits"
+	"sort"
+
+	"github.com/docwalter/primes/bitset"
+)
+
+// CompactSet is a read-only prime set in a compressed representation for archival purposes. It stores the gaps
+// between consecutive prime candidates of the set's bit layout with a Golomb-Rice code, together with a small index
+// for lookups. Up to 10^9, this needs 4.6 bits per prime instead of 6.6 bits for a Set, i.e. 30% less memory, and the
+// advantage grows slowly with the size; the price is a lookup time that is proportional to the index block size.
+// A reduction by a factor of 3 or 4 is out of reach for any gap code: the entropy of the gaps up to 10^9 is 4.0 bits
+// per prime, because the 6k±1 layout of a Set already omits all multiples of 2 and 3.
+type CompactSet interface {
+	Expand() Set                        // uncompressed set with the same primes
+	IsPrime(n uint64) bool              // true iff n is prime; n must not exceed the largest number
+	Iterator(start uint64) Iterator     // allows for traversing the set
+	LargestNumber() uint64              // largest number in the set
+	LargestPrime() uint64               // largest prime number in the set
+	MemoryUsage() uint                  // number of bytes used for the compressed primes and the index
+	WriteTo(w io.Writer) (int64, error) // writes the compact set in a binary format
+}
+
+// compactBlockSize is the number of primes per index block of a compact set.
+const compactBlockSize = 256
+
+// Internal implementation of CompactSet.
+type compactSet struct {
+	largestNumber uint64         // largest number in the set
+	largestPrime  uint64         // largest prime number in the set
+	rice          uint           // number of binary digits of the Golomb-Rice code
+	count         uint64         // number of encoded primes, i.e. all primes from 5 on
+	stream        []uint64       // Golomb-Rice codes of the bit index gaps minus 1 of all primes from 5 on
+	index         []compactBlock // start of every block of compactBlockSize primes
+}
+
+// compactBlock marks the start of a block of primes in the stream.
+type compactBlock struct {
+	last uint   // bit index of the prime before the block
+	pos  uint64 // position of the first code of the block in the stream
+}
+
+// Compact returns a compressed copy of the set.
+func (s *set) Compact() CompactSet {
+	// choose the Golomb-Rice parameter from the average gap
+	count := uint64(0)
+	for k := uint(0); k < s.wordCount(); k++ {
+		count += uint64(bits.OnesCount64(s.word(k)))
+	}
+	count-- // bit 0 marks 3, which is not encoded
+	h, _ := s.prevBit(s.wordCount()<<6 - 1)
+	rice := uint(0)
+	if count > 0 {
+		rice = uint(bits.Len64(uint64(h) / count))
+		if rice > 0 {
+			rice--
+		}
+	}
+	c := &compactSet{largestNumber: s.largestNumber, largestPrime: s.largestPrime, rice: rice, count: count}
+	var w bitWriter
+	last := uint(0)
+	for i, found := s.nextBit(1); found; i, found = s.nextBit(i + 1) {
+		w.writeRice(uint64(i-last-1), rice)
+		last = i
+	}
+	c.stream = w.words
+	c.buildIndex()
+	return c
+}
+
+// buildIndex creates the index by decoding the stream. It returns false if the stream is invalid, i.e. if it ends
+// within a code or if its primes do not end with the largest prime.
+func (c *compactSet) buildIndex() bool {
+	c.index = make([]compactBlock, 0, c.count/compactBlockSize+1)
+	r := newRiceReader(c.stream, 0, c.rice)
+	last := uint(0)
+	for k := uint64(0); k < c.count; k++ {
+		if k%compactBlockSize == 0 {
+			c.index = append(c.index, compactBlock{last, r.pos()})
+		}
+		gap, ok := r.next()
+		if !ok {
+			return false
+		}
+		last += uint(gap) + 1
+	}
+	return indexToNumber(last) == c.largestPrime && c.largestPrime <= c.largestNumber && c.largestNumber >= 5
+}
+
+// Expand returns an uncompressed set with the same primes.
+func (c *compactSet) Expand() Set {
+	b := make(bitset.BitSet, setWords(c.largestNumber))
+	b.Set(0)
+	r := newRiceReader(c.stream, 0, c.rice)
+	last := uint(0)
+	for k := uint64(0); k < c.count; k++ {
+		gap, _ := r.next()
+		last += uint(gap) + 1
+		b.Set(last)
+	}
+	return newSet(b, nil)
+}
+
+// IsPrime returns true iff n is a prime number. It panics if n exceeds the largest number of the set.
+func (c *compactSet) IsPrime(n uint64) bool {
+	if n > c.largestNumber {
+		panic("number exceeds the compact set")
+	}
+	if n < 5 || n&1 == 0 || n%3 == 0 {
+		return n == 2 || n == 3
+	}
+	target := numberToIndex(n)
+	b := sort.Search(len(c.index), func(b int) bool { return c.index[b].last >= target }) - 1
+	if b < 0 {
+		return false
+	}
+	r := newRiceReader(c.stream, c.index[b].pos, c.rice)
+	last := c.index[b].last
+	for k := uint64(b) * compactBlockSize; k < c.count && last < target; k++ {
+		gap, _ := r.next()
+		last += uint(gap) + 1
+	}
+	return last == target
+}
+
+// Iterator returns an iterator over the set that returns all primes from start on in ascending order.
+func (c *compactSet) Iterator(start uint64) Iterator {
+	small := []uint64{2, 3}
+	for len(small) > 0 && small[0] < start {
+		small = small[1:]
+	}
+	k, last, pos := uint64(0), uint(0), uint64(0)
+	if start > 5 {
+		target := numberToIndex(start)
+		if b := sort.Search(len(c.index), func(b int) bool { return c.index[b].last >= target }) - 1; b >= 0 {
+			k, last, pos = uint64(b)*compactBlockSize, c.index[b].last, c.index[b].pos
+		}
+	}
+	r := newRiceReader(c.stream, pos, c.rice)
+	return funcIterator(func() (uint64, bool) {
+		if len(small) > 0 {
+			p := small[0]
+			small = small[1:]
+			return p, true
+		}
+		for k < c.count {
+			gap, _ := r.next()
+			last += uint(gap) + 1
+			k++
+			if p := indexToNumber(last); p >= start {
+				return p, true
+			}
+		}
+		return 0, false
+	})
+}
+
+// LargestNumber returns the largest number in the set.
+func (c *compactSet) LargestNumber() uint64 {
+	return c.largestNumber
+}
+
+// LargestPrime returns the largest prime number in the set.
+func (c *compactSet) LargestPrime() uint64 {
+	return c.largestPrime
+}
+
+// MemoryUsage returns the number of bytes used for the compressed primes and the index.
+func (c *compactSet) MemoryUsage() uint {
+	return uint(len(c.stream))*8 + uint(len(c.index))*16
+}
+
+// Binary format of a serialized compact set, all numbers in little endian byte order:
+//
+//	magic        4 bytes  "PCMP"
+//	version      uint32   1
+//	largest      uint64   largest number of the set
+//	prime        uint64   largest prime number of the set
+//	rice         uint32   number of binary digits of the Golomb-Rice code
+//	count        uint64   number of encoded primes
+//	words        uint64   number of words of the stream
+//	stream       words * 8 bytes
+//	checksum     uint32   CRC-32 (IEEE) of all preceding bytes
+//
+// The index is not stored, but rebuilt when reading.
+const (
+	compactMagic   = "PCMP"
+	compactVersion = 1
+)
+
+// compactHeader is the fixed-size header of a serialized compact set.
+type compactHeader struct {
+	Magic         [4]byte
+	Version       uint32
+	LargestNumber uint64
+	LargestPrime  uint64
+	Rice          uint32
+	Count         uint64
+	Words         uint64
+}
+
+// WriteTo writes the compact set in a binary format to w, which can be read again with ReadCompactSet.
+func (c *compactSet) WriteTo(w io.Writer) (int64, error) {
+	h := compactHeader{Version: compactVersion, LargestNumber: c.largestNumber, LargestPrime: c.largestPrime,
+		Rice: uint32(c.rice), Count: c.count, Words: uint64(len(c.stream))}
+	copy(h.Magic[:], compactMagic)
+	crc := crc32.NewIEEE()
+	bw := bufio.NewWriter(io.MultiWriter(w, crc))
+	if err := binary.Write(bw, binary.LittleEndian, &h); err != nil {
+		return 0, err
+	}
+	if err := binary.Write(bw, binary.LittleEndian, c.stream); err != nil {
+		return 0, err
+	}
+	if err := bw.Flush(); err != nil {
+		return 0, err
+	}
+	if err := binary.Write(w, binary.LittleEndian, crc.Sum32()); err != nil {
+		return 0, err
+	}
+	return int64(binary.Size(h)) + int64(len(c.stream))*8 + 4, nil
+}
+
+// ReadCompactSet reads a compact set written by CompactSet.WriteTo. Invalid or missing data is reported with an error
+// wrapping ErrCorrupt, also if the header claims more codes than follow.
+func ReadCompactSet(r io.Reader) (CompactSet, error) {
+	crc := crc32.NewIEEE()
+	br := io.TeeReader(bufio.NewReader(r), crc)
+	var h compactHeader
+	if err := binary.Read(br, binary.LittleEndian, &h); err != nil {
+		return nil, err
+	}
+	if string(h.Magic[:]) != compactMagic || h.Version != compactVersion {
+		return nil, fmt.Errorf("%w: no compact set data of version %d", ErrCorrupt, compactVersion)
+	}
+	if h.Words > maxSetWords {
+		return nil, fmt.Errorf("%w: compact set of %d words exceeds the addressable memory", ErrCorrupt, h.Words)
+	}
+	// every code needs at least rice+1 bits
+	if h.Rice > 63 || h.Count > h.Words*64/(uint64(h.Rice)+1) {
+		return nil, fmt.Errorf("%w: %d primes in %d words of codes with rice parameter %d", ErrCorrupt, h.Count,
+			h.Words, h.Rice)
+	}
+	stream, err := readWords(br, h.Words)
+	if err != nil {
+		return nil, err
+	}
+	sum := crc.Sum32()
+	var checksum uint32
+	if err := binary.Read(br, binary.LittleEndian, &checksum); err != nil {
+		return nil, err
+	}
+	if checksum != sum {
+		return nil, fmt.Errorf("%w: compact set checksum %#x instead of %#x", ErrCorrupt, checksum, sum)
+	}
+	c := &compactSet{largestNumber: h.LargestNumber, largestPrime: h.LargestPrime, rice: uint(h.Rice), count: h.Count,
+		stream: stream}
+	if !c.buildIndex() {
+		return nil, fmt.Errorf("%w: invalid codes in compact set", ErrCorrupt)
+	}
+	return c, nil
+}
+
+// bitWriter appends bits to a stream of uint64 words, starting with the least significant bit of each word.
+type bitWriter struct {
+	words []uint64 // written words
+	n     uint64   // number of written bits
+}
+
+// writeBit appends a single bit.
+func (w *bitWriter) writeBit(b uint64) {
+	if w.n&63 == 0 {
+		w.words = append(w.words, 0)
+	}
+	w.words[w.n>>6] |= b << (w.n & 63)
+	w.n++
+}
+
+// writeRice appends v with a Golomb-Rice code with k binary digits: v>>k in unary as zeros terminated by a one,
+// followed by the lowest k bits of v.
+func (w *bitWriter) writeRice(v uint64, k uint) {
+	for q := v >> k; q > 0; q-- {
+		w.writeBit(0)
+	}
+	w.writeBit(1)
+	for i := uint(0); i < k; i++ {
+		w.writeBit(v >> i & 1)
+	}
+}
+
+// riceReader decodes a stream of Golomb-Rice codes sequentially.
+type riceReader struct {
+	stream []uint64 // encoded stream
+	k      uint     // number of binary digits of the codes
+	word   int      // index of the next word of the stream to be buffered
+	buf    uint64   // buffered unread bits, starting with the least significant one
+	n      uint     // number of buffered unread bits
+}
+
+// newRiceReader creates a reader for the codes with k binary digits at position pos of the stream.
+func newRiceReader(stream []uint64, pos uint64, k uint) *riceReader {
+	r := &riceReader{stream: stream, k: k, word: int(pos >> 6)}
+	if r.word < len(stream) {
+		r.buf, r.n = stream[r.word]>>(pos&63), uint(64-pos&63)
+		r.word++
+	}
+	return r
+}
+
+// pos returns the position of the next code in the stream.
+func (r *riceReader) pos() uint64 {
+	return uint64(r.word)<<6 - uint64(r.n)
+}
+
+// next decodes the next code. If the stream ends within the code, the second result is false.
+func (r *riceReader) next() (uint64, bool) {
+	q := uint64(0)
+	for r.buf == 0 {
+		q += uint64(r.n)
+		if r.word >= len(r.stream) {
+			return 0, false
+		}
+		r.buf, r.n = r.stream[r.word], 64
+		r.word++
+	}
+	z := uint(bits.TrailingZeros64(r.buf))
+	q += uint64(z)
+	r.buf >>= z + 1
+	r.n -= z + 1
+	if r.k == 0 {
+		return q, true
+	}
+	mask := uint64(1)<<r.k - 1
+	if r.n >= r.k {
+		v := r.buf & mask
+		r.buf >>= r.k
+		r.n -= r.k
+		return q<<r.k | v, true
+	}
+	if r.word >= len(r.stream) {
+		return 0, false
+	}
+	w := r.stream[r.word]
+	r.word++
+	v := (r.buf | w<<r.n) & mask
+	r.buf, r.n = w>>(r.k-r.n), 64-(r.k-r.n)
+	return q<<r.k | v, true
+}
@@ -0,0 +1,74 @@
+package primes
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+func TestCompactSet(t *testing.T) {
+	set := NewPrimeSet(1000000)
+	c := set.Compact()
+	if c.LargestNumber() != set.LargestNumber() || c.LargestPrime() != set.LargestPrime() {
+		t.Errorf("compact set reaches up to %d with largest prime %d", c.LargestNumber(), c.LargestPrime())
+	}
+	if c.MemoryUsage() >= set.MemoryUsage() {
+		t.Errorf("compact set uses %d bytes, the set %d bytes", c.MemoryUsage(), set.MemoryUsage())
+	}
+	for n := uint64(0); n <= set.LargestNumber(); n++ {
+		if c.IsPrime(n) != set.IsPrime(n) {
+			t.Fatalf("compact set differs at %d", n)
+		}
+	}
+	for _, start := range []uint64{0, 3, 4, 5, 6, 1000, 999983, 999984} {
+		it, expected := c.Iterator(start), set.Iterator(start)
+		for i := 0; i < 600; i++ {
+			p, ok := it.Next()
+			q, qok := expected.Next()
+			if p != q || ok != qok {
+				t.Fatalf("Iterator(%d) yields %d, %v instead of %d, %v", start, p, ok, q, qok)
+			}
+		}
+	}
+	expanded := c.Expand()
+	for n := uint64(0); n <= set.LargestNumber(); n++ {
+		if expanded.IsPrime(n) != set.IsPrime(n) {
+			t.Fatalf("expanded set differs at %d", n)
+		}
+	}
+}
+
+func TestCompactSetSerialization(t *testing.T) {
+	c := NewPrimeSet(100000).Compact()
+	var buf bytes.Buffer
+	n, err := c.WriteTo(&buf)
+	if err != nil || n != int64(buf.Len()) {
+		t.Fatalf("WriteTo() = %d, %v for %d bytes", n, err, buf.Len())
+	}
+	data := buf.Bytes()
+	read, err := ReadCompactSet(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for n := uint64(0); n <= c.LargestNumber(); n++ {
+		if read.IsPrime(n) != c.IsPrime(n) {
+			t.Fatalf("read compact set differs at %d", n)
+		}
+	}
+	corrupt := append([]byte(nil), data...)
+	corrupt[len(corrupt)/2] ^= 1
+	if _, err := ReadCompactSet(bytes.NewReader(corrupt)); !errors.Is(err, ErrCorrupt) {
+		t.Error("corrupt data should be rejected")
+	}
+
+	// a header claiming 256 MB of codes must be rejected when they run out, without allocating all of them
+	var forged bytes.Buffer
+	h := compactHeader{Version: compactVersion, LargestNumber: 1 << 40, Rice: 3, Words: 1 << 25}
+	copy(h.Magic[:], compactMagic)
+	binary.Write(&forged, binary.LittleEndian, &h)
+	forged.Write(make([]byte, 1000))
+	if _, err := ReadCompactSet(&forged); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("compact set without its codes should be rejected with ErrCorrupt instead of %v", err)
+	}
+}
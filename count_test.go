@@ -0,0 +1,41 @@
+package primes
+
+import "testing"
+
+func TestCountRange(t *testing.T) {
+	set := NewPrimeSet(100000)
+	for _, c := range []struct{ a, b, expected uint64 }{
+		{0, 1, 0}, {0, 2, 1}, {2, 3, 2}, {3, 3, 1}, {4, 4, 0}, {0, 10, 4}, {5, 5, 1}, {6, 6, 0},
+		{0, 100, 25}, {0, 1000, 168}, {0, 100000, 9592}, {90, 96, 0}, {89, 97, 2}, {10, 5, 0},
+	} {
+		if n := set.CountRange(c.a, c.b); n != c.expected {
+			t.Errorf("CountRange(%d, %d) = %d instead of %d", c.a, c.b, n, c.expected)
+		}
+	}
+	for a := uint64(0); a < 1000; a += 37 {
+		for b := a; b < 100000; b += 4999 {
+			expected := uint64(0)
+			set.ForEach(a, b, func(uint64) bool { expected++; return true })
+			if n := set.CountRange(a, b); n != expected {
+				t.Errorf("CountRange(%d, %d) = %d instead of %d", a, b, n, expected)
+			}
+		}
+	}
+	if n := set.SubSet(100, 1000).CountRange(0, 1000); n != 143 {
+		t.Errorf("CountRange() of the subset = %d", n)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("CountRange() beyond the set should panic")
+		}
+	}()
+	set.CountRange(0, set.LargestNumber()+1)
+}
+
+func BenchmarkCountRange(b *testing.B) {
+	set := NewPrimeSet(10000000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		set.CountRange(1000, 9999999)
+	}
+}
@@ -0,0 +1,47 @@
+package primes
+
+import "math/big"
+
+// IsCullenPrime returns true iff the Cullen number n·2^n + 1 is prime, e.g. for n = 1 and 141. Cullen numbers that fit
+// into an uint64 are tested deterministically; larger ones are probable primes according to big.Int.ProbablyPrime
+// after trial division by the primes up to 4096, which is done on n and 2^n modulo each prime without constructing
+// the number. The test takes multiplications of n bit numbers, so it is only feasible for moderately sized n.
+func IsCullenPrime(n uint64) bool {
+	return isCullenWoodallPrime(n, true)
+}
+
+// IsWoodallPrime returns true iff the Woodall number n·2^n - 1 is prime, e.g. for n = 2, 3, 6 and 30. It is tested
+// like the Cullen numbers by IsCullenPrime.
+func IsWoodallPrime(n uint64) bool {
+	return isCullenWoodallPrime(n, false)
+}
+
+// isCullenWoodallPrime returns true iff n·2^n + 1 for plus or n·2^n - 1 otherwise is prime.
+func isCullenWoodallPrime(n uint64, plus bool) bool {
+	if n == 0 {
+		return false
+	}
+	if n <= 58 {
+		// n·2^n + 1 still fits into an uint64
+		m := n<<n - 1
+		if plus {
+			m += 2
+		}
+		prime, _ := millerRabin(m)
+		return prime
+	}
+	initTrialPrimes()
+	for _, p := range trialPrimes {
+		r := MulMod(n%p, PowMod(2, n, p), p)
+		if plus && r == p-1 || !plus && r == 1 {
+			return false
+		}
+	}
+	m := new(big.Int).Lsh(new(big.Int).SetUint64(n), uint(n))
+	if plus {
+		m.Add(m, big.NewInt(1))
+	} else {
+		m.Sub(m, big.NewInt(1))
+	}
+	return m.ProbablyPrime(20)
+}
@@ -0,0 +1,17 @@
+package primes
+
+import "testing"
+
+func TestCullenWoodallPrimes(t *testing.T) {
+	cullen := map[uint64]bool{1: true, 141: true}
+	woodall := map[uint64]bool{2: true, 3: true, 6: true, 30: true, 75: true, 81: true, 115: true, 123: true, 249: true,
+		362: true, 384: true, 462: true}
+	for n := uint64(0); n <= 500; n++ {
+		if IsCullenPrime(n) != cullen[n] {
+			t.Errorf("IsCullenPrime(%d) != %v", n, cullen[n])
+		}
+		if IsWoodallPrime(n) != woodall[n] {
+			t.Errorf("IsWoodallPrime(%d) != %v", n, woodall[n])
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package primes
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Limits of the default set, which starts small and grows on demand for numbers up to a moderate size. Larger
+// numbers do not let it grow, since the package functions handle them with Miller-Rabin tests and Pollard's rho
+// method.
+const (
+	defaultSetInitialLimit = 1 << 16
+	defaultSetMaxLimit     = 1 << 28
+)
+
+// defaultSet holds the lazily created default set for the package functions.
+var defaultSet struct {
+	once   sync.Once
+	mu     sync.Mutex                // serializes the growth of the set
+	oracle atomic.Pointer[setOracle] // oracle backed by the current set
+}
+
+// defaultOracle returns the oracle of the default set, growing the set first if it does not reach up to n yet and n
+// does not exceed the maximum limit.
+func defaultOracle(n uint64) *setOracle {
+	defaultSet.once.Do(func() {
+		defaultSet.oracle.Store(&setOracle{NewPrimeSet(defaultSetInitialLimit)})
+	})
+	o := defaultSet.oracle.Load()
+	if n <= o.set.LargestNumber() || n > defaultSetMaxLimit {
+		return o
+	}
+	defaultSet.mu.Lock()
+	defer defaultSet.mu.Unlock()
+	o = defaultSet.oracle.Load()
+	if limit := o.set.LargestNumber(); n > limit {
+		o = &setOracle{NewPrimeSet(min(max(2*limit, n), defaultSetMaxLimit))}
+		defaultSet.oracle.Store(o)
+	}
+	return o
+}
+
+// IsPrime returns true iff n is a prime number. It uses a default set that is created on first use and grows
+// automatically, so quick scripts need no Set of their own.
+func IsPrime(n uint64) bool {
+	return defaultOracle(n).IsPrime(n)
+}
+
+// Next returns the smallest prime larger than n, using the default set like IsPrime. If there is no such prime
+// within uint64, the second result is false.
+func Next(n uint64) (uint64, bool) {
+	return defaultOracle(n + 1).NextPrime(n)
+}
+
+// Factor returns the prime factorization of n in ascending order of the prime factors, using the default set like
+// IsPrime. For n = 0, the second result is false.
+func Factor(n uint64) ([]PrimePower, bool) {
+	return defaultOracle(Sqrt(n)).Factor(n)
+}
@@ -0,0 +1,30 @@
+package primes
+
+import "testing"
+
+func TestDefaultSet(t *testing.T) {
+	if !IsPrime(65537) || IsPrime(65535) {
+		t.Error("IsPrime() fails for small numbers")
+	}
+	if p, ok := Next(100000); !ok || p != 100003 {
+		t.Errorf("Next(100000) = %d, %v", p, ok)
+	}
+	if limit := defaultOracle(0).set.LargestNumber(); limit < 100001 {
+		t.Errorf("default set has not grown: %d", limit)
+	}
+	if !IsPrime(1<<61-1) || IsPrime(1<<62) {
+		t.Error("IsPrime() fails beyond the default set")
+	}
+	if limit := defaultOracle(0).set.LargestNumber(); limit >= 1<<20 {
+		t.Errorf("default set has grown for a large number: %d", limit)
+	}
+	if p, ok := Next(1<<64 - 59); ok {
+		t.Errorf("Next() beyond uint64 = %d", p)
+	}
+	if factors, ok := Factor(1000000016000000063); !ok || len(factors) != 2 || factors[0].Prime != 1000000007 {
+		t.Errorf("Factor() = %v, %v", factors, ok)
+	}
+	if _, ok := Factor(0); ok {
+		t.Error("Factor(0) should fail")
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Package digits provides iterators over prime numbers with properties of their decimal digits, e.g. a given digit sum.
+Instead of scanning all primes up to a limit, the candidates are constructed digit by digit in ascending order,
+pruning every prefix that cannot be completed to a number with the property. Only the remaining candidates are tested
+with primes.IsPrime, so the work depends on the number of candidates rather than on the size of the range, which
+makes searches up to 10^12 and beyond feasible as long as the property is selective.
+
+Example usage:
+
+	it := digits.PrimesUsingOnlyDigits("137", 1e12)
+	for p, ok := it.Next(); ok; p, ok = it.Next() {
+		fmt.Println(p)
+	}
+*/
+package digits
+
+import "github.com/docwalter/primes"
+
+// PrimesWithDigitSum returns an iterator over all primes up to max with the given decimal digit sum in ascending
+// order. Since a digit sum divisible by 3 makes the number divisible by 3, such sums only yield the prime 3 itself.
+func PrimesWithDigitSum(sum, max uint64) primes.Iterator {
+	length := maxDigits
+	if sum%3 == 0 {
+		length = 1
+	}
+	return newWalker(digitSum(sum), max, length)
+}
+
+// PrimesUsingOnlyDigits returns an iterator over all primes up to max in ascending order whose decimal digits are all
+// contained in the given string, e.g. 3, 7, 11, 13, 17, 31, 37, 71, 73, ... for "137". PrimesUsingOnlyDigits panics
+// if digits contains other characters than decimal digits.
+func PrimesUsingOnlyDigits(digits string, max uint64) primes.Iterator {
+	var allowed [10]bool
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			panic("digits must only contain decimal digits")
+		}
+		allowed[r-'0'] = true
+	}
+	var c allowedDigits
+	for d, ok := range allowed {
+		if ok {
+			c = append(c, uint8(d))
+		}
+	}
+	// numbers with more than one digit need a last digit of 1, 3, 7 or 9, and they are divisible by 3 if all their
+	// digits are
+	length := 1
+	lastDigit := allowed[1] || allowed[3] || allowed[7] || allowed[9]
+	notDivisibleBy3 := allowed[1] || allowed[2] || allowed[4] || allowed[5] || allowed[7] || allowed[8]
+	if lastDigit && notDivisibleBy3 {
+		length = maxDigits
+	}
+	return newWalker(c, max, length)
+}
+
+// digitSum is the constraint of numbers with a given digit sum.
+type digitSum uint64
+
+// choices returns the digits 0 to 9.
+func (s digitSum) choices() []uint8 {
+	return allDigits
+}
+
+// feasible returns true iff the digit sum of the prefix does not exceed s and the remaining digits can make up
+// the difference.
+func (s digitSum) feasible(prefix []uint8, length int) bool {
+	sum := uint64(0)
+	for _, d := range prefix {
+		sum += uint64(d)
+	}
+	return sum <= uint64(s) && uint64(s)-sum <= 9*uint64(length-len(prefix))
+}
+
+// allowedDigits is the constraint of numbers consisting of a given set of digits in ascending order.
+type allowedDigits []uint8
+
+// choices returns the allowed digits.
+func (c allowedDigits) choices() []uint8 {
+	return c
+}
+
+// feasible returns true, since every prefix of allowed digits can be completed.
+func (c allowedDigits) feasible(prefix []uint8, length int) bool {
+	return true
+}
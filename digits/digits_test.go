@@ -0,0 +1,75 @@
+package digits
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/docwalter/primes"
+)
+
+// collect returns all numbers of an iterator.
+func collect(it primes.Iterator) []uint64 {
+	var numbers []uint64
+	for n, ok := it.Next(); ok; n, ok = it.Next() {
+		numbers = append(numbers, n)
+	}
+	return numbers
+}
+
+func TestAgainstSet(t *testing.T) {
+	const max = 1000000
+	set := primes.NewPrimeSet(max)
+	for _, m := range []uint64{0, 1, 10, 997, 1000, 54321, max} {
+		for sum := uint64(0); sum <= 50; sum++ {
+			var expected []uint64
+			it := set.Iterator(0)
+			for p, ok := it.Next(); ok && p <= m; p, ok = it.Next() {
+				s := uint64(0)
+				for _, r := range strconv.FormatUint(p, 10) {
+					s += uint64(r - '0')
+				}
+				if s == sum {
+					expected = append(expected, p)
+				}
+			}
+			if actual := collect(PrimesWithDigitSum(sum, m)); !slices.Equal(actual, expected) {
+				t.Fatalf("PrimesWithDigitSum(%d, %d) = %v instead of %v", sum, m, actual, expected)
+			}
+		}
+		for _, digits := range []string{"", "0", "2", "5", "25", "369", "13", "137", "0123456789", "2468", "90"} {
+			var expected []uint64
+			it := set.Iterator(0)
+			for p, ok := it.Next(); ok && p <= m; p, ok = it.Next() {
+				if strings.Trim(strconv.FormatUint(p, 10), digits) == "" {
+					expected = append(expected, p)
+				}
+			}
+			if actual := collect(PrimesUsingOnlyDigits(digits, m)); !slices.Equal(actual, expected) {
+				t.Fatalf("PrimesUsingOnlyDigits(%q, %d) = %v instead of %v", digits, m, actual, expected)
+			}
+		}
+	}
+}
+
+func TestLargeRanges(t *testing.T) {
+	if p := collect(PrimesWithDigitSum(2, math.MaxUint64)); !slices.Equal(p, []uint64{2, 11, 101}) {
+		t.Errorf("primes with digit sum 2 are %v", p)
+	}
+	p := collect(PrimesWithDigitSum(4, 1e15))
+	if len(p) != 81 || p[0] != 13 || p[80] != 200000010000001 {
+		t.Errorf("%d primes with digit sum 4 up to 10^15", len(p))
+	}
+	p = collect(PrimesUsingOnlyDigits("137", 1e12))
+	if len(p) != 79054 || p[len(p)-1] != 777777777773 {
+		t.Errorf("%d primes with digits 1, 3 and 7 up to 10^12", len(p))
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("PrimesUsingOnlyDigits(\"1a\", 100) does not panic")
+		}
+	}()
+	PrimesUsingOnlyDigits("1a", 100)
+}
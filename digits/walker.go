@@ -0,0 +1,109 @@
+package digits
+
+import "github.com/docwalter/primes"
+
+// maxDigits is the number of decimal digits of the largest uint64.
+const maxDigits = 20
+
+// allDigits are the decimal digits in ascending order.
+var allDigits = []uint8{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+
+// constraint restricts the digits of the candidates.
+type constraint interface {
+	choices() []uint8                         // possible digits in ascending order
+	feasible(prefix []uint8, length int) bool // true iff the prefix can be completed to a candidate of the given length
+}
+
+// walker is the internal implementation of primes.Iterator for the digit constraints. It walks the candidates of
+// each length depth-first, which yields them in ascending order, and tests them for primality.
+type walker struct {
+	c         constraint        // digit constraint of the candidates
+	choices   []uint8           // possible digits of the candidates
+	last      []uint8           // possible last digits of candidates with more than one digit
+	limit     [maxDigits]uint8  // digits of the maximum number
+	limitLen  int               // number of digits of the maximum number
+	maxLength int               // number of digits of the longest candidates
+	length    int               // number of digits of the current candidates
+	depth     int               // number of digits fixed so far, or -1 before the next length
+	index     [maxDigits]int    // index of the current digit in the choices at each position
+	digits    [maxDigits]uint8  // current digits, most significant first
+	values    [maxDigits]uint64 // values of the prefixes of the current digits
+	tight     [maxDigits]bool   // true iff the prefix of the current digits equals the prefix of the maximum
+}
+
+// newWalker creates a walker over the candidates up to max with at most maxLength digits.
+func newWalker(c constraint, max uint64, maxLength int) *walker {
+	w := &walker{c: c, choices: c.choices(), last: lastDigits(c.choices()), depth: -1}
+	n := 0
+	for m := max; m > 0; m /= 10 {
+		n++
+	}
+	for i, m := n-1, max; i >= 0; i, m = i-1, m/10 {
+		w.limit[i] = uint8(m % 10)
+	}
+	w.limitLen, w.maxLength = n, min(maxLength, n)
+	return w
+}
+
+// Next returns the next prime fulfilling the constraint.
+func (w *walker) Next() (uint64, bool) {
+	for {
+		if w.depth < 0 {
+			if w.length == w.maxLength {
+				return 0, false
+			}
+			w.length++
+			w.depth, w.index[0] = 0, -1
+		}
+		pos := w.depth
+		choices := w.choices
+		if pos == w.length-1 && w.length > 1 {
+			choices = w.last
+		}
+		w.index[pos]++
+		if w.index[pos] >= len(choices) {
+			w.depth--
+			continue
+		}
+		d := choices[w.index[pos]]
+		if pos == 0 && d == 0 {
+			// no leading zeros
+			continue
+		}
+		tight := w.length == w.limitLen && (pos == 0 || w.tight[pos-1])
+		if tight && d > w.limit[pos] {
+			// the following digits are even larger
+			w.index[pos] = len(choices)
+			continue
+		}
+		w.digits[pos] = d
+		w.tight[pos] = tight && d == w.limit[pos]
+		w.values[pos] = uint64(d)
+		if pos > 0 {
+			w.values[pos] += 10 * w.values[pos-1]
+		}
+		if !w.c.feasible(w.digits[:pos+1], w.length) {
+			continue
+		}
+		if pos < w.length-1 {
+			w.depth++
+			w.index[w.depth] = -1
+			continue
+		}
+		if n := w.values[pos]; primes.IsPrime(n) {
+			return n, true
+		}
+	}
+}
+
+// lastDigits returns the digits among the given ones that are possible as last digits of primes with more than one
+// digit, i.e. 1, 3, 7 and 9.
+func lastDigits(digits []uint8) []uint8 {
+	var last []uint8
+	for _, d := range digits {
+		if d == 1 || d == 3 || d == 7 || d == 9 {
+			last = append(last, d)
+		}
+	}
+	return last
+}
@@ -0,0 +1,58 @@
+package primes
+
+// DiscreteLog returns the smallest x >= 0 with base^x = target mod m, using the baby-step giant-step algorithm.
+// It takes about sqrt(m) steps and keeps sqrt(m) entries in memory, so it is meant for small moduli. The modulus need
+// not be prime, and base need not be coprime to it. If there is no solution, the second result is false.
+// DiscreteLog panics if m is 0.
+func DiscreteLog(base, target, m uint64) (uint64, bool) {
+	if m == 0 {
+		panic("modulus must not be zero")
+	}
+	base %= m
+	target %= m
+
+	// divide the common factors g of base and m out of coef * base^x = target mod m, which leaves
+	// coef * base/g * base^(x-1) = target/g mod m/g, until base is coprime to m and the inverses exist
+	coef, k := uint64(1)%m, uint64(0)
+	for g := gcd(base, m); g > 1; g = gcd(base, m) {
+		if target == coef {
+			return k, true
+		}
+		if target%g != 0 {
+			return 0, false
+		}
+		target, m, k = target/g, m/g, k+1
+		coef = MulMod(coef, base/g, m)
+	}
+	if target == coef {
+		return k, true
+	}
+	coefInv, _ := ModInverse(coef, m)
+	target = MulMod(target, coefInv, m)
+	inv, _ := ModInverse(base, m)
+	n := Sqrt(m - 1)
+	if n*n < m {
+		n++
+	}
+
+	// baby steps: remember the smallest j with base^j = v for all j < n
+	baby := make(map[uint64]uint64, n)
+	v := uint64(1) % m
+	for j := uint64(0); j < n; j++ {
+		if _, found := baby[v]; !found {
+			baby[v] = j
+		}
+		v = MulMod(v, base, m)
+	}
+
+	// giant steps: target * base^(-in) for all i < n, where the first hit yields the smallest solution
+	giant := PowMod(inv, n, m)
+	v = target
+	for i := uint64(0); i < n; i++ {
+		if j, found := baby[v]; found {
+			return k + i*n + j, true
+		}
+		v = MulMod(v, giant, m)
+	}
+	return 0, false
+}
@@ -0,0 +1,35 @@
+package primes
+
+import "testing"
+
+func TestDiscreteLog(t *testing.T) {
+	for _, m := range []uint64{1, 2, 3, 17, 72, 101, 1000, 65537} {
+		for base := uint64(0); base < 12; base++ {
+			// the smallest exponent for every reachable target, found by brute force
+			expected := make(map[uint64]uint64)
+			v := uint64(1) % m
+			for x := uint64(0); x < m; x++ {
+				if _, found := expected[v]; !found {
+					expected[v] = x
+				}
+				v = v * base % m
+			}
+			for target := uint64(0); target < m; target += 1 + m/100 {
+				x, ok := DiscreteLog(base, target, m)
+				e, reachable := expected[target]
+				if ok != reachable || ok && x != e {
+					t.Fatalf("DiscreteLog(%d, %d, %d) = %d, %t instead of %d, %t", base, target, m, x, ok, e, reachable)
+				}
+			}
+		}
+	}
+	for _, c := range []struct{ base, target, m, x uint64 }{{0, 0, 7, 1}, {2, 8, 1000, 3}, {6, 0, 36, 2}, {12, 144, 1728, 2}} {
+		if x, ok := DiscreteLog(c.base, c.target, c.m); !ok || x != c.x {
+			t.Errorf("DiscreteLog(%d, %d, %d) = %d, %t instead of %d", c.base, c.target, c.m, x, ok, c.x)
+		}
+	}
+	p := uint64(1000000007)
+	if x, ok := DiscreteLog(5, PowMod(5, 123456789, p), p); !ok || PowMod(5, x, p) != PowMod(5, 123456789, p) {
+		t.Errorf("DiscreteLog(5, 5^123456789, %d) = %d, %t", p, x, ok)
+	}
+}
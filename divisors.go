@@ -0,0 +1,163 @@
+package primes
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+	"sort"
+	"sync"
+)
+
+// Phi returns Euler's totient φ(n), i.e. the number of integers in [1, n] that are coprime to n.
+// If the factorizer boundaries are exceeded without a fallback or n is 0, the second result is false.
+func (f *factorizer) Phi(n uint64) (uint64, bool) {
+	factors, ok := f.Factorize(n)
+	if !ok {
+		return 0, false
+	}
+	phi := n
+	for _, pp := range factors {
+		phi = phi / pp.Prime * (pp.Prime - 1)
+	}
+	return phi, true
+}
+
+// Divisors returns all positive divisors of n in ascending order.
+// If the factorizer boundaries are exceeded without a fallback or n is 0, the second result is false.
+func (f *factorizer) Divisors(n uint64) ([]uint64, bool) {
+	factors, ok := f.Factorize(n)
+	if !ok {
+		return nil, false
+	}
+	divisors := []uint64{1}
+	for _, pp := range factors {
+		count := len(divisors)
+		q := uint64(1)
+		for e := uint(0); e < pp.Exponent; e++ {
+			q *= pp.Prime
+			for _, d := range divisors[:count] {
+				divisors = append(divisors, d*q)
+			}
+		}
+	}
+	sort.Slice(divisors, func(i, j int) bool { return divisors[i] < divisors[j] })
+	return divisors, true
+}
+
+// sigmaOf returns the sum of divisors of the number with the given factorization. If the sum exceeds 64 bits, the
+// second result is false.
+func sigmaOf(factors []PrimePower) (uint64, bool) {
+	sigma := uint64(1)
+	for _, pp := range factors {
+		// 1 + p + p^2 + ... + p^e, which is less than 2n and thus overflows only for the largest numbers
+		sum, q := uint64(1), uint64(1)
+		for e := uint(0); e < pp.Exponent; e++ {
+			q *= pp.Prime
+			sum += q
+		}
+		hi, lo := bits.Mul64(sigma, sum)
+		if hi != 0 || sum < q {
+			return 0, false
+		}
+		sigma = lo
+	}
+	return sigma, true
+}
+
+// divisorCountPrimes returns a set of the first 64 primes, which suffice for the exponent patterns of
+// SmallestWithDivisorCount. It is created on first use.
+var divisorCountPrimes = sync.OnceValue(func() Set {
+	return NewPrimeSet(320)
+})
+
+// SmallestWithDivisorCount returns the smallest number with exactly k divisors, e.g. 12 for k = 6. Such a number is
+// 2^(e1-1)·3^(e2-1)·5^(e3-1)·... for a factorization k = e1·e2·e3·... with e1 >= e2 >= e3 >= ..., so only these
+// exponent patterns are searched, pruned by the logarithm of the best number found so far. The result may exceed 64
+// bits by far, e.g. for prime k it is 2^(k-1). If k is 0, an error wrapping ErrOutOfRange is returned.
+func SmallestWithDivisorCount(k uint64) (*big.Int, error) {
+	if k == 0 {
+		return nil, fmt.Errorf("%w: no number has %d divisors", ErrOutOfRange, k)
+	}
+	factors := factorize(k)
+	// divisors of k in descending order as candidates for the factors e1, e2, ...
+	divisors := []uint64{1}
+	for _, pp := range factors {
+		count := len(divisors)
+		q := uint64(1)
+		for e := uint(0); e < pp.Exponent; e++ {
+			q *= pp.Prime
+			for _, d := range divisors[:count] {
+				divisors = append(divisors, d*q)
+			}
+		}
+	}
+	sort.Slice(divisors, func(i, j int) bool { return divisors[i] > divisors[j] })
+	// k has at most 63 prime factors, so the first 64 primes suffice
+	var primes []uint64
+	var logs []float64
+	it := divisorCountPrimes().Iterator(0)
+	for p, ok := it.Next(); ok && len(primes) < 64; p, ok = it.Next() {
+		primes = append(primes, p)
+		logs = append(logs, math.Log(float64(p)))
+	}
+	// weight returns the sum of q-1 over the prime factors q of a divisor x of k, counted with multiplicity. It is a
+	// lower bound of the sum of e-1 over any factorization x = e1·e2·..., since ab-1 >= (a-1)+(b-1).
+	weight := func(x uint64) float64 {
+		w := 0.0
+		for _, pp := range factors {
+			for ; x%pp.Prime == 0; x /= pp.Prime {
+				w += float64(pp.Prime - 1)
+			}
+		}
+		return w
+	}
+
+	// the prime factors of k in descending order give a first pattern to prune with, and a slight tolerance keeps
+	// patterns with equal logarithms, which are compared exactly
+	bestLog := 0.0
+	for i, j := len(factors)-1, 0; i >= 0; i-- {
+		for e := uint(0); e < factors[i].Exponent; e, j = e+1, j+1 {
+			bestLog += float64(factors[i].Prime-1) * logs[j]
+		}
+	}
+	bestLog *= 1 + 1e-12
+	var best *big.Int
+	exponents := make([]uint64, 0, 64)
+	var search func(rest, maxFactor uint64, log float64)
+	search = func(rest, maxFactor uint64, log float64) {
+		if rest == 1 {
+			n := big.NewInt(1)
+			for i, e := range exponents {
+				n.Mul(n, new(big.Int).Exp(new(big.Int).SetUint64(primes[i]), new(big.Int).SetUint64(e), nil))
+			}
+			if best == nil || n.Cmp(best) < 0 {
+				best, bestLog = n, min(bestLog, log*(1+1e-12))
+			}
+			return
+		}
+		i := len(exponents)
+		start := sort.Search(len(divisors), func(j int) bool { return divisors[j] <= maxFactor })
+		for _, d := range divisors[start:] {
+			if d < 2 {
+				break
+			}
+			if rest%d != 0 {
+				continue
+			}
+			l := log + float64(d-1)*logs[i]
+			bound := l
+			if rest != d {
+				bound += weight(rest/d) * logs[i+1]
+			}
+			if bound > bestLog {
+				continue
+			}
+			exponents = append(exponents, d-1)
+			search(rest/d, d, l)
+			exponents = exponents[:len(exponents)-1]
+		}
+	}
+	search(k, k, 0)
+	return best, nil
+}
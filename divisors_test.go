@@ -0,0 +1,24 @@
+package primes
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSmallestWithDivisorCount(t *testing.T) {
+	for _, test := range []struct {
+		k        uint64
+		expected string
+	}{
+		{1, "1"}, {2, "2"}, {6, "12"}, {12, "60"}, {16, "120"}, {24, "360"}, {60, "5040"}, {64, "7560"},
+		{100, "45360"}, {128, "83160"}, {1000, "810810000"}, {1024, "294053760"}, {5040, "293318625600"},
+		{65536, "106858629141264000"}, {720720, "39974690726904757824000000"}, {97, "79228162514264337593543950336"},
+	} {
+		if n, err := SmallestWithDivisorCount(test.k); err != nil || n.String() != test.expected {
+			t.Errorf("SmallestWithDivisorCount(%d) = %v, %v instead of %s", test.k, n, err, test.expected)
+		}
+	}
+	if _, err := SmallestWithDivisorCount(0); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("SmallestWithDivisorCount(0) returns error %v", err)
+	}
+}
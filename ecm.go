@@ -0,0 +1,151 @@
+package primes
+
+import "sync"
+
+// Bounds of the elliptic curve method. With these bounds, a curve finds a prime factor p if the group order of the
+// curve modulo p is a product of primes up to ecmB1 and at most one larger prime up to ecmB2, which is sufficient for
+// the factors of up to 32 bits that remain after trial division and Pollard's rho method.
+const (
+	ecmB1    = 2000   // bound for stage 1
+	ecmB2    = 150000 // bound for stage 2
+	ecmGiant = 210    // giant step of stage 2, i.e. 2 * 3 * 5 * 7
+)
+
+var (
+	ecmPrimesOnce sync.Once // guards the initialization of ecmPrimes
+	ecmPrimes     Set       // primes up to ecmB2
+)
+
+// ecmPoint is a point on a Montgomery curve in projective X:Z coordinates, both in Montgomery form.
+type ecmPoint struct {
+	x, z uint64
+}
+
+// ecmCurve holds the arithmetic for a Montgomery curve By^2 = x^3 + Ax^2 + x modulo n.
+// The curve constant is stored projectively as (A + 2C) : 4C to avoid modular inversions.
+type ecmCurve struct {
+	mg  montgomery // arithmetic modulo n
+	a24 uint64     // A + 2C in Montgomery form
+	c24 uint64     // 4C in Montgomery form
+}
+
+// FactorECM tries to find a nontrivial factor of n with Lenstra's elliptic curve method, using up to the given number
+// of curves. If n is prime or no factor was found, the second result is false.
+func FactorECM(n uint64, curves int) (uint64, bool) {
+	if n < 4 {
+		return 0, false
+	}
+	if n&1 == 0 {
+		return 2, true
+	}
+	if prime, _ := millerRabin(n); prime {
+		return 0, false
+	}
+	ecmPrimesOnce.Do(func() {
+		ecmPrimes = NewPrimeSet(ecmB2)
+	})
+	mg := newMontgomery(n)
+	for sigma := uint64(6); sigma < uint64(curves)+6; sigma++ {
+		if d := ecmTryCurve(mg, sigma); d != 1 && d != n {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// ecmTryCurve runs both stages of the elliptic curve method on the curve with Suyama parameter sigma.
+// The result is a divisor of n, which is 1 or n if the curve failed.
+func ecmTryCurve(mg montgomery, sigma uint64) uint64 {
+	n := mg.m
+
+	// Suyama's parametrization: u = sigma^2 - 5, v = 4 sigma, P = u^3 : v^3,
+	// (A + 2C) : 4C = (v - u)^3 (3u + v) : 16 u^3 v
+	s := mg.to(sigma)
+	u := subMod(mg.mul(s, s), mg.to(5), n)
+	v := mg.mul(mg.to(4), s)
+	u3 := mg.mul(mg.mul(u, u), u)
+	vu := subMod(v, u, n)
+	c := ecmCurve{mg,
+		mg.mul(mg.mul(mg.mul(vu, vu), vu), addMod(mg.mul(mg.to(3), u), v, n)),
+		mg.mul(mg.mul(mg.to(16), u3), v)}
+	if d := gcd(c.c24, n); d != 1 {
+		return d
+	}
+	p := ecmPoint{u3, mg.mul(mg.mul(v, v), v)}
+
+	// stage 1: multiply by all prime powers up to B1
+	it := ecmPrimes.Iterator(0)
+	for q, ok := it.Next(); ok && q <= ecmB1; q, ok = it.Next() {
+		qe := q
+		for qe <= ecmB1/q {
+			qe *= q
+		}
+		p = c.multiply(p, qe)
+	}
+	if d := gcd(p.z, n); d != 1 {
+		return d
+	}
+
+	// stage 2: find a single prime q in (B1, B2] with q = m * giant +- j and [m * giant]P = -+[j]P modulo a factor
+	var baby [ecmGiant / 2]ecmPoint // baby[j] = [j]P for odd j
+	p2 := c.double(p)
+	baby[1] = p
+	baby[3] = c.add(p2, p, p)
+	for j := 5; j < len(baby); j += 2 {
+		baby[j] = c.add(baby[j-2], p2, baby[j-4])
+	}
+	step := c.multiply(p, ecmGiant)
+	m := uint64(ecmB1 / ecmGiant)
+	prev := c.multiply(p, (m-1)*ecmGiant)
+	giant := c.multiply(p, m*ecmGiant)
+	stage2 := func(q uint64) bool {
+		return q > ecmB1 && q <= ecmB2 && ecmPrimes.IsPrime(q)
+	}
+	acc := mg.one
+	for ; m*ecmGiant <= ecmB2+ecmGiant; m++ {
+		for j := uint64(1); j < uint64(len(baby)); j += 2 {
+			if stage2(m*ecmGiant-j) || stage2(m*ecmGiant+j) {
+				b := baby[j]
+				acc = mg.mul(acc, subMod(mg.mul(giant.x, b.z), mg.mul(b.x, giant.z), n))
+			}
+		}
+		giant, prev = c.add(giant, step, prev), giant
+	}
+	return gcd(acc, n)
+}
+
+// double returns [2]P.
+func (c ecmCurve) double(p ecmPoint) ecmPoint {
+	mg, n := c.mg, c.mg.m
+	s := addMod(p.x, p.z, n)
+	d := subMod(p.x, p.z, n)
+	s2 := mg.mul(s, s)
+	d2 := mg.mul(d, d)
+	t := subMod(s2, d2, n) // 4XZ
+	x := mg.mul(mg.mul(c.c24, d2), s2)
+	z := mg.mul(addMod(mg.mul(c.c24, d2), mg.mul(c.a24, t), n), t)
+	return ecmPoint{x, z}
+}
+
+// add returns P + Q, given the difference P - Q.
+func (c ecmCurve) add(p, q, diff ecmPoint) ecmPoint {
+	mg, n := c.mg, c.mg.m
+	u := mg.mul(subMod(p.x, p.z, n), addMod(q.x, q.z, n))
+	v := mg.mul(addMod(p.x, p.z, n), subMod(q.x, q.z, n))
+	s := addMod(u, v, n)
+	d := subMod(u, v, n)
+	return ecmPoint{mg.mul(diff.z, mg.mul(s, s)), mg.mul(diff.x, mg.mul(d, d))}
+}
+
+// multiply returns [k]P for k > 0 using the Montgomery ladder.
+func (c ecmCurve) multiply(p ecmPoint, k uint64) ecmPoint {
+	r0, r1 := p, c.double(p)
+	for bit := 62 - int(numberOfLeadingZeroes(k)); bit >= 0; bit-- {
+		if k&(1<<uint(bit)) != 0 {
+			r0, r1 = c.add(r1, r0, p), c.double(r1)
+		} else {
+			r0, r1 = c.double(r0), c.add(r1, r0, p)
+		}
+	}
+	return r0
+}
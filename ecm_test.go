@@ -0,0 +1,18 @@
+package primes
+
+import "testing"
+
+func TestFactorECM(t *testing.T) {
+	for _, c := range []struct{ p, q uint64 }{
+		{3, 5}, {2, 999999999989}, {1000003, 1000033}, {65521, 4294967291}, {4294967291, 4294967279}, {3, 6148914691236517199},
+	} {
+		n := c.p * c.q
+		d, ok := FactorECM(n, 500)
+		if !ok || (d != c.p && d != c.q) {
+			t.Errorf("FactorECM(%d = %d * %d) = %d, %t", n, c.p, c.q, d, ok)
+		}
+	}
+	if d, ok := FactorECM(18446744073709551557, 10); ok {
+		t.Errorf("FactorECM returned factor %d of a prime", d)
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Package embedded provides a precomputed prime set up to 10^8, which is embedded into the binary in compressed form.
+Loading it takes well below 100ms instead of about 600ms for sieving, which matters for command-line tools and
+serverless functions. The embedded data adds about 2.8 MB to the binary; it is generated with go generate.
+
+Example usage:
+
+	set := embedded.Set() // decompressed on first use
+	fmt.Println(set.IsPrime(99999989))
+*/
+package embedded
+
+//go:generate go run gen.go
+
+import (
+	"bytes"
+	_ "embed"
+	"sync"
+
+	"github.com/docwalter/primes"
+)
+
+// Limit is the number up to which the embedded set reaches at least.
+const Limit = 100000000
+
+// data is the embedded compact set, written by gen.go.
+//
+//go:embed primes.pcmp
+var data []byte
+
+var (
+	compactOnce sync.Once
+	compact     primes.CompactSet // embedded compact set, read on first use
+	setOnce     sync.Once
+	set         primes.Set // decompressed embedded set, created on first use
+)
+
+// CompactSet returns the embedded set in its compressed form, which needs less memory than Set, but has slower
+// lookups.
+func CompactSet() primes.CompactSet {
+	compactOnce.Do(func() {
+		c, err := primes.ReadCompactSet(bytes.NewReader(data))
+		if err != nil {
+			panic("embedded prime set is corrupt: " + err.Error())
+		}
+		compact = c
+	})
+	return compact
+}
+
+// Set returns the embedded set, which is decompressed on first use.
+func Set() primes.Set {
+	setOnce.Do(func() {
+		set = CompactSet().Expand()
+	})
+	return set
+}
@@ -0,0 +1,23 @@
+package embedded
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docwalter/primes"
+)
+
+func TestEmbeddedSet(t *testing.T) {
+	start := time.Now()
+	set := Set()
+	t.Logf("embedded set loaded after %v", time.Since(start))
+	if set.LargestNumber() < Limit || set.LargestPrime() < 99999989 {
+		t.Errorf("embedded set reaches up to %d with largest prime %d", set.LargestNumber(), set.LargestPrime())
+	}
+	if !primes.Equal(set, primes.NewPrimeSet(Limit)) {
+		t.Error("embedded set differs from a sieved one")
+	}
+	if c := CompactSet(); c.LargestPrime() != set.LargestPrime() || !c.IsPrime(99999989) {
+		t.Error("embedded compact set differs")
+	}
+}
@@ -0,0 +1,25 @@
+//go:build ignore
+
+// gen writes the compact prime set embedded by package embedded. Run it with go generate.
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/docwalter/primes"
+)
+
+func main() {
+	set := primes.NewPrimeSet(100000000)
+	file, err := os.Create("primes.pcmp")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err := set.Compact().WriteTo(file); err != nil {
+		log.Fatal(err)
+	}
+	if err := file.Close(); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,14 @@
+package primes
+
+import "errors"
+
+// Sentinel errors of the package. Functions that return errors wrap them together with the offending value, so they
+// can be distinguished with errors.Is.
+var (
+	ErrOutOfRange    = errors.New("primes: number out of range")         // number exceeds a set or table
+	ErrOverflow      = errors.New("primes: overflow")                    // result does not fit into its type
+	ErrNotFactored   = errors.New("primes: number has no factorization") // number is 0 and thus has no prime factors
+	ErrLimitTooSmall = errors.New("primes: limit too small")             // limit is below the minimum of a constructor
+	ErrCorrupt       = errors.New("primes: corrupt data")                // prime bits, factors or stored data do not match the actual primes
+	ErrNotPrime      = errors.New("primes: number is not prime")         // number was expected to be prime
+)
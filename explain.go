@@ -0,0 +1,50 @@
+package primes
+
+// Verdict is the result of a primality explanation.
+type Verdict int
+
+const (
+	VerdictNeither Verdict = iota // the number is neither prime nor composite, i.e. 0 or 1
+	VerdictPrime                  // the number is prime
+	VerdictFactor                 // the number is composite, proven by its smallest prime factor
+	VerdictWitness                // the number is composite, proven by a Miller-Rabin witness
+)
+
+// String returns a short description of the verdict.
+func (v Verdict) String() string {
+	switch v {
+	case VerdictNeither:
+		return "neither prime nor composite"
+	case VerdictPrime:
+		return "prime"
+	case VerdictFactor:
+		return "composite (factor)"
+	case VerdictWitness:
+		return "composite (witness)"
+	}
+	return "unknown"
+}
+
+// Explain determines whether n is prime and tells why. For composite numbers whose smallest prime factor can be
+// found in the set, the second result is this factor (VerdictFactor). Larger composites are rejected by the
+// Miller-Rabin test, and the second result is the witness base (VerdictWitness). For all other verdicts, the second
+// result is 0.
+func (s *set) Explain(n uint64) (Verdict, uint64) {
+	if n < 2 {
+		return VerdictNeither, 0
+	}
+	if n <= s.largestNumber && s.isPrime(n) {
+		return VerdictPrime, 0
+	}
+	f, ok := s.SmallestFactorOf(n)
+	if ok {
+		if f == n {
+			return VerdictPrime, 0
+		}
+		return VerdictFactor, f
+	}
+	if prime, witness := millerRabin(n); !prime {
+		return VerdictWitness, witness
+	}
+	return VerdictPrime, 0
+}
@@ -0,0 +1,35 @@
+package primes
+
+import "testing"
+
+func TestExplain(t *testing.T) {
+	set := NewPrimeSet(1000)
+	for _, c := range []struct {
+		n       uint64
+		verdict Verdict
+		reason  uint64
+	}{
+		{0, VerdictNeither, 0},
+		{1, VerdictNeither, 0},
+		{2, VerdictPrime, 0},
+		{997, VerdictPrime, 0},
+		{999, VerdictFactor, 3},
+		{1201 * 1213, VerdictWitness, 2},
+		{1000003, VerdictPrime, 0},
+		{3825123056546413051, VerdictWitness, 37}, // strong pseudoprime to all bases up to 31
+		{18446744073709551557, VerdictPrime, 0},
+	} {
+		if v, r := set.Explain(c.n); v != c.verdict || r != c.reason {
+			t.Errorf("Explain(%d) = %s, %d instead of %s, %d", c.n, v, r, c.verdict, c.reason)
+		}
+	}
+}
+
+func TestMillerRabin(t *testing.T) {
+	set := NewPrimeSet(100000)
+	for n := uint64(0); n <= set.LargestNumber(); n++ {
+		if prime, _ := millerRabin(n); prime != set.IsPrime(n) {
+			t.Errorf("millerRabin(%d) should be %t", n, set.IsPrime(n))
+		}
+	}
+}
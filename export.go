@@ -0,0 +1,199 @@
+package primes
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Column is a column of an export with ExportCSV or ExportNDJSON.
+type Column int
+
+const (
+	ColumnN       Column = iota // the number itself
+	ColumnFactors               // prime factorization, like 2^2*3 in CSV and as array of prime powers in NDJSON
+	ColumnPhi                   // Euler's totient φ(n)
+	ColumnTau                   // number of divisors τ(n)
+	ColumnSigma                 // sum of divisors σ(n)
+)
+
+// String returns the name of the column, which is used in the CSV header and as NDJSON key.
+func (c Column) String() string {
+	switch c {
+	case ColumnN:
+		return "n"
+	case ColumnFactors:
+		return "factors"
+	case ColumnPhi:
+		return "phi"
+	case ColumnTau:
+		return "tau"
+	case ColumnSigma:
+		return "sigma"
+	}
+	return "Column(" + strconv.Itoa(int(c)) + ")"
+}
+
+// ExportCSV writes the numbers of the iterator as CSV with a header line to w, one row per number with the given
+// columns. Without columns, n and factors are exported. A prime range is exported with an iterator of a Set and
+// ColumnN only, a batch of factorizations with e.g. Numbers and a Factorizer, which is required for all columns but
+// ColumnN. Values that cannot be determined, because a number cannot be factorized or σ(n) exceeds 64 bits, are
+// left empty. ExportCSV panics if a column is unknown or the factorizer is missing.
+func ExportCSV(w io.Writer, f Factorizer, it Iterator, columns ...Column) error {
+	columns = exportColumns(f, columns)
+	cw := csv.NewWriter(w)
+	record := make([]string, len(columns))
+	for i, c := range columns {
+		record[i] = c.String()
+	}
+	if err := cw.Write(record); err != nil {
+		return err
+	}
+	err := exportRows(f, it, columns, func(values []interface{}) error {
+		for i, v := range values {
+			switch v := v.(type) {
+			case nil:
+				record[i] = ""
+			case uint64:
+				record[i] = strconv.FormatUint(v, 10)
+			case []PrimePower:
+				record[i] = formatPrimePowers(v)
+			}
+		}
+		return cw.Write(record)
+	})
+	if err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ExportNDJSON writes the numbers of the iterator as newline-delimited JSON to w, one object per number with the
+// given columns as keys in their order. Columns, factorizer and undeterminable values, which are written as null,
+// are handled like in ExportCSV.
+func ExportNDJSON(w io.Writer, f Factorizer, it Iterator, columns ...Column) error {
+	columns = exportColumns(f, columns)
+	bw := bufio.NewWriter(w)
+	err := exportRows(f, it, columns, func(values []interface{}) error {
+		bw.WriteByte('{')
+		for i, v := range values {
+			if i > 0 {
+				bw.WriteByte(',')
+			}
+			bw.WriteString(strconv.Quote(columns[i].String()))
+			bw.WriteByte(':')
+			b, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			bw.Write(b)
+		}
+		_, err := bw.WriteString("}\n")
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// ExportBFile writes the numbers of the iterator in the b-file format of the OEIS to w, i.e. one line "n a(n)" per
+// number with the indices counting up from startIndex, which is the offset of the sequence, e.g. 1 for the primes.
+func ExportBFile(w io.Writer, it Iterator, startIndex int) error {
+	bw := bufio.NewWriter(w)
+	var line []byte
+	i := startIndex
+	for n, ok := it.Next(); ok; n, ok = it.Next() {
+		line = strconv.AppendInt(line[:0], int64(i), 10)
+		line = append(line, ' ')
+		line = strconv.AppendUint(line, n, 10)
+		line = append(line, '\n')
+		if _, err := bw.Write(line); err != nil {
+			return err
+		}
+		i++
+	}
+	return bw.Flush()
+}
+
+// exportColumns checks the columns of an export and returns the default columns if there are none.
+func exportColumns(f Factorizer, columns []Column) []Column {
+	if len(columns) == 0 {
+		columns = []Column{ColumnN, ColumnFactors}
+	}
+	for _, c := range columns {
+		if c < ColumnN || c > ColumnSigma {
+			panic("unknown export column")
+		}
+		if c != ColumnN && f == nil {
+			panic("export of column " + c.String() + " requires a factorizer")
+		}
+	}
+	return columns
+}
+
+// exportRows determines the values of the columns for each number of the iterator and passes them to row. Values
+// that cannot be determined are nil.
+func exportRows(f Factorizer, it Iterator, columns []Column, row func(values []interface{}) error) error {
+	values := make([]interface{}, len(columns))
+	for n, ok := it.Next(); ok; n, ok = it.Next() {
+		var factors []PrimePower
+		factorized := false
+		if f != nil {
+			factors, factorized = f.Factorize(n)
+		}
+		for i, c := range columns {
+			values[i] = nil
+			switch {
+			case c == ColumnN:
+				values[i] = n
+			case !factorized:
+			case c == ColumnFactors:
+				if factors == nil {
+					factors = []PrimePower{}
+				}
+				values[i] = factors
+			case c == ColumnPhi:
+				phi := n
+				for _, pp := range factors {
+					phi = phi / pp.Prime * (pp.Prime - 1)
+				}
+				values[i] = phi
+			case c == ColumnTau:
+				tau := uint64(1)
+				for _, pp := range factors {
+					tau *= uint64(pp.Exponent) + 1
+				}
+				values[i] = tau
+			case c == ColumnSigma:
+				if sigma, ok := sigmaOf(factors); ok {
+					values[i] = sigma
+				}
+			}
+		}
+		if err := row(values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatPrimePowers formats a factorization like 2^2*3.
+func formatPrimePowers(factors []PrimePower) string {
+	var sb strings.Builder
+	for i, pp := range factors {
+		if i > 0 {
+			sb.WriteByte('*')
+		}
+		sb.WriteString(strconv.FormatUint(pp.Prime, 10))
+		if pp.Exponent > 1 {
+			sb.WriteByte('^')
+			sb.WriteString(strconv.FormatUint(uint64(pp.Exponent), 10))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,83 @@
+package primes
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestExportCSV(t *testing.T) {
+	set := NewPrimeSet(1000)
+	f := set.Factorizer(1000)
+	var buf bytes.Buffer
+	if err := ExportCSV(&buf, f, Numbers(0, 12), ColumnN, ColumnFactors, ColumnPhi, ColumnTau, ColumnSigma); err != nil {
+		t.Fatal(err)
+	}
+	expected := "n,factors,phi,tau,sigma\n0,,,,\n1,,1,1,1\n2,2,1,2,3\n3,3,2,2,4\n4,2^2,2,3,7\n5,5,4,2,6\n6,2*3,2,4,12\n" +
+		"7,7,6,2,8\n8,2^3,4,4,15\n9,3^2,6,3,13\n10,2*5,4,4,18\n11,11,10,2,12\n12,2^2*3,4,6,28\n"
+	if buf.String() != expected {
+		t.Errorf("ExportCSV() = %q, expected %q", buf.String(), expected)
+	}
+
+	buf.Reset()
+	if err := ExportCSV(&buf, nil, set.Filter(10, func(p uint64) bool { return p < 20 }), ColumnN); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "n\n11\n13\n17\n19\n"; buf.String() != expected {
+		t.Errorf("ExportCSV() of primes = %q, expected %q", buf.String(), expected)
+	}
+}
+
+func TestExportNDJSON(t *testing.T) {
+	f := NewPrimeSet(1000).Factorizer(100)
+	var buf bytes.Buffer
+	if err := ExportNDJSON(&buf, f, Numbers(11, 12)); err != nil {
+		t.Fatal(err)
+	}
+	expected := "{\"n\":11,\"factors\":[{\"prime\":11,\"exponent\":1}]}\n" +
+		"{\"n\":12,\"factors\":[{\"prime\":2,\"exponent\":2},{\"prime\":3,\"exponent\":1}]}\n"
+	if buf.String() != expected {
+		t.Errorf("ExportNDJSON() = %q, expected %q", buf.String(), expected)
+	}
+
+	buf.Reset()
+	if err := ExportNDJSON(&buf, f, Numbers(1, 1), ColumnSigma, ColumnN); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "{\"sigma\":1,\"n\":1}\n"; buf.String() != expected {
+		t.Errorf("ExportNDJSON() = %q, expected %q", buf.String(), expected)
+	}
+
+	buf.Reset()
+	if err := ExportNDJSON(&buf, f, Numbers(1000003, 1000003), ColumnN, ColumnTau); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "{\"n\":1000003,\"tau\":null}\n"; buf.String() != expected {
+		t.Errorf("ExportNDJSON() beyond the factorizer = %q, expected %q", buf.String(), expected)
+	}
+}
+
+func TestSigmaOverflow(t *testing.T) {
+	// σ(2^63) = 2^64 - 1 still fits, σ(2^62 * 3) does not
+	if sigma, ok := sigmaOf([]PrimePower{{2, 63}}); !ok || sigma != 1<<64-1 {
+		t.Errorf("sigmaOf(2^63) = %d, %v", sigma, ok)
+	}
+	if _, ok := sigmaOf([]PrimePower{{2, 62}, {3, 1}}); ok {
+		t.Error("sigmaOf(2^62 * 3) should overflow")
+	}
+}
+
+func TestExportBFile(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportBFile(&buf, NewPrimeSet(191).Iterator(0), 1); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(buf.String(), "\n")
+	if len(lines) != 44 || lines[0] != "1 2" || lines[24] != "25 97" || lines[42] != "43 191" || lines[43] != "" {
+		t.Errorf("unexpected b-file %q", buf.String())
+	}
+	buf.Reset()
+	if err := ExportBFile(&buf, Numbers(5, 7), -1); err != nil || buf.String() != "-1 5\n0 6\n1 7\n" {
+		t.Errorf("b-file of 5 to 7 is %q, %v", buf.String(), err)
+	}
+}
@@ -0,0 +1,155 @@
+package primes
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// FactorStore is a persistent store for factorizations, which a factorizer consults before it runs an expensive
+// fallback factorization. Implementations must be safe for concurrent use.
+type FactorStore interface {
+	Load(n uint64) ([]PrimePower, bool)         // stored factorization of n, if any
+	Store(n uint64, factors []PrimePower) error // stores the factorization of n
+}
+
+// WithStore lets the factorizer look up factorizations beyond its table in the given store and add new ones to it.
+// The store implies WithFallback. Since Factorize cannot report errors, failures of the store are ignored by the
+// factorizer; FactorDB reports them when it is closed.
+func WithStore(store FactorStore) FactorizerOption {
+	return func(f *factorizer) {
+		f.fallback = true
+		f.store = store
+	}
+}
+
+// FactorDB is a FactorStore that keeps all factorizations in memory and appends new ones to a text file, one line
+// per number in the form "n p1^e1 p2^e2 ...". A partially written last line, e.g. after a crash, is ignored and
+// truncated, so new lines are appended after the last complete one.
+type FactorDB struct {
+	mu      sync.Mutex
+	file    *os.File
+	w       *bufio.Writer
+	entries map[uint64][]PrimePower
+	err     error // first write error
+}
+
+// OpenFactorDB opens the factor database at the given path, creating it if necessary, and reads all stored
+// factorizations. Each of them is verified by multiplying out its factors.
+func OpenFactorDB(path string) (*FactorDB, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	entries := make(map[uint64][]PrimePower)
+	r := bufio.NewReader(file)
+	offset := int64(0) // end of the last complete line
+	for line := 1; ; line++ {
+		s, err := r.ReadString('\n')
+		if err == io.EOF {
+			break // an incomplete last line is ignored
+		}
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		n, factors, err := parseFactorDBLine(strings.TrimSuffix(s, "\n"))
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("primes: %s:%d: %v", path, line, err)
+		}
+		entries[n] = factors
+		offset += int64(len(s))
+	}
+	if err := file.Truncate(offset); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &FactorDB{file: file, w: bufio.NewWriter(file), entries: entries}, nil
+}
+
+// parseFactorDBLine parses and verifies a line of a factor database.
+func parseFactorDBLine(s string) (uint64, []PrimePower, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return 0, nil, fmt.Errorf("empty line")
+	}
+	n, err := strconv.ParseUint(fields[0], 10, 64)
+	if err != nil {
+		return 0, nil, err
+	}
+	factors := make([]PrimePower, 0, len(fields)-1)
+	for _, field := range fields[1:] {
+		i := strings.IndexByte(field, '^')
+		if i < 0 {
+			return 0, nil, fmt.Errorf("invalid prime power %q", field)
+		}
+		p, err := strconv.ParseUint(field[:i], 10, 64)
+		if err != nil {
+			return 0, nil, err
+		}
+		e, err := strconv.ParseUint(field[i+1:], 10, 8)
+		if err != nil {
+			return 0, nil, err
+		}
+		factors = append(factors, PrimePower{p, uint(e)})
+	}
+	if err := (Factorization{n, factors}).validate(); err != nil {
+		return 0, nil, err
+	}
+	return n, factors, nil
+}
+
+// Load returns the stored factorization of n. If n is not stored, the second result is false.
+func (db *FactorDB) Load(n uint64) ([]PrimePower, bool) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	factors, ok := db.entries[n]
+	return factors, ok
+}
+
+// Store adds the factorization of n to the database, unless it is already stored. The line is buffered and written
+// to the file by Flush or Close.
+func (db *FactorDB) Store(n uint64, factors []PrimePower) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if _, ok := db.entries[n]; ok {
+		return nil
+	}
+	db.entries[n] = factors
+	line := strconv.FormatUint(n, 10)
+	for _, pp := range factors {
+		line += " " + strconv.FormatUint(pp.Prime, 10) + "^" + strconv.FormatUint(uint64(pp.Exponent), 10)
+	}
+	if _, err := db.w.WriteString(line + "\n"); err != nil && db.err == nil {
+		db.err = err
+	}
+	return db.err
+}
+
+// Flush writes all buffered factorizations to the file.
+func (db *FactorDB) Flush() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if err := db.w.Flush(); err != nil && db.err == nil {
+		db.err = err
+	}
+	return db.err
+}
+
+// Close flushes the database and closes its file. It returns the first error that occurred while writing.
+func (db *FactorDB) Close() error {
+	err := db.Flush()
+	if cerr := db.file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -0,0 +1,72 @@
+package primes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFactorDB(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "factors.db")
+	db, err := OpenFactorDB(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	set := NewPrimeSet(1000)
+	n := uint64(1000000016000000063) // 1000000007 * 1000000009
+	if factors, ok := set.Factorizer(1000, WithStore(db)).Factorize(n); !ok || len(factors) != 2 {
+		t.Fatalf("Factorize(%d) = %v, %t", n, factors, ok)
+	}
+	if err := db.Store(12, []PrimePower{{2, 2}, {3, 1}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// append a partial line as if the process crashed while writing
+	file, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	file.WriteString("35 5^1 7")
+	file.Close()
+
+	db, err = OpenFactorDB(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if factors, ok := db.Load(n); !ok || factors[0] != (PrimePower{1000000007, 1}) || factors[1] != (PrimePower{1000000009, 1}) {
+		t.Errorf("Load(%d) = %v, %t", n, factors, ok)
+	}
+	if factors, ok := db.Load(12); !ok || len(factors) != 2 {
+		t.Errorf("Load(12) = %v, %t", factors, ok)
+	}
+	if _, ok := db.Load(35); ok {
+		t.Error("the partial line should have been ignored")
+	}
+
+	// the partial line must not corrupt the lines appended after reopening
+	if err := db.Store(35, []PrimePower{{5, 1}, {7, 1}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+	db, err = OpenFactorDB(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if factors, ok := db.Load(35); !ok || len(factors) != 2 {
+		t.Errorf("Load(35) = %v, %t", factors, ok)
+	}
+	if factors, ok := db.Load(12); !ok || len(factors) != 2 {
+		t.Errorf("Load(12) after appending = %v, %t", factors, ok)
+	}
+}
+
+func TestFactorDBCorrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "factors.db")
+	os.WriteFile(path, []byte("12 2^2 3^1\n13 2^2 3^1\n"), 0644)
+	if _, err := OpenFactorDB(path); err == nil {
+		t.Error("a wrong factorization should be detected")
+	}
+}
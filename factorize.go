@@ -0,0 +1,143 @@
+package primes
+
+import "sort"
+
+// PrimePower is a prime number raised to a positive exponent, i.e. a single factor of a factorization.
+type PrimePower struct {
+	Prime    uint64 // prime factor
+	Exponent uint   // multiplicity of the prime factor
+}
+
+// factorize returns the prime factorization of n > 0 in ascending order of the prime factors. Small factors are
+// removed by trial division, the remaining cofactor is split with Pollard-Brent rho and the pieces are checked with
+// the deterministic Miller-Rabin test.
+func factorize(n uint64) []PrimePower {
+	var factors []PrimePower
+	for _, p := range millerRabinBases {
+		if n%p == 0 {
+			e := uint(0)
+			for n%p == 0 {
+				n /= p
+				e++
+			}
+			factors = append(factors, PrimePower{p, e})
+		}
+	}
+	return appendLargeFactors(factors, n)
+}
+
+// appendLargeFactors appends the prime factorization of n to factors, where n has no prime factors up to 37.
+// The result is sorted in ascending order of the prime factors.
+func appendLargeFactors(factors []PrimePower, n uint64) []PrimePower {
+	if n == 1 {
+		return factors
+	}
+	start := len(factors)
+	var split func(m uint64)
+	split = func(m uint64) {
+		if prime, _ := millerRabin(m); prime {
+			for i := start; i < len(factors); i++ {
+				if factors[i].Prime == m {
+					factors[i].Exponent++
+					return
+				}
+			}
+			factors = append(factors, PrimePower{m, 1})
+			return
+		}
+		d := findFactor(m)
+		split(d)
+		split(m / d)
+	}
+	split(n)
+	sort.Slice(factors, func(i, j int) bool { return factors[i].Prime < factors[j].Prime })
+	return factors
+}
+
+// findFactor returns a nontrivial factor of the odd composite number n. It tries Pollard's rho method with a few
+// polynomials first and the elliptic curve method as second stage.
+func findFactor(n uint64) uint64 {
+	if r := Sqrt(n); r*r == n {
+		return r
+	}
+	for c := uint64(1); c <= 4; c++ {
+		if d := pollardBrent(n, c); d != n {
+			return d
+		}
+	}
+	if d, ok := FactorECM(n, 50); ok {
+		return d
+	}
+	for c := uint64(5); ; c++ {
+		if d := pollardBrent(n, c); d != n {
+			return d
+		}
+	}
+}
+
+// pollardBrent tries to find a factor of the odd composite number n with Brent's variant of Pollard's rho method,
+// using the polynomial x^2 + c. If it fails, n is returned.
+func pollardBrent(n, c uint64) uint64 {
+	const batch = 128 // number of steps between two gcd calculations
+	mg := newMontgomery(n)
+	mc := mg.to(c)
+	f := func(x uint64) uint64 {
+		x = mg.mul(x, x) + mc
+		if x >= n || x < mc {
+			x -= n
+		}
+		return x
+	}
+	y, x, ys := mg.to(2), uint64(0), uint64(0)
+	g, q := uint64(1), mg.one
+	for r := 1; g == 1; r <<= 1 {
+		x = y
+		for i := 0; i < r; i++ {
+			y = f(y)
+		}
+		for k := 0; k < r && g == 1; k += batch {
+			ys = y
+			for i := 0; i < batch && i < r-k; i++ {
+				y = f(y)
+				q = mg.mul(q, absDiff(x, y))
+			}
+			g = gcd(q, n)
+		}
+	}
+	if g == n {
+		// the batch overshot, so repeat its steps one at a time
+		for g = 1; g == 1; {
+			ys = f(ys)
+			g = gcd(absDiff(x, ys), n)
+		}
+	}
+	return g
+}
+
+// absDiff returns |a - b|.
+func absDiff(a, b uint64) uint64 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+// gcd returns the greatest common divisor of a and b using the binary algorithm.
+func gcd(a, b uint64) uint64 {
+	if a == 0 {
+		return b
+	}
+	if b == 0 {
+		return a
+	}
+	shift := numberOfTrailingZeroes(a | b)
+	a >>= numberOfTrailingZeroes(a)
+	for b != 0 {
+		b >>= numberOfTrailingZeroes(b)
+		if a > b {
+			a, b = b, a
+		}
+		b -= a
+	}
+	return a << shift
+}
@@ -1,24 +1,109 @@
 package primes
 
+import (
+	"context"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
 // Factorizer holds precalculated factors for a given range of numbers, thus allowing for their factorization in near-constant time.
 type Factorizer interface {
-	LargestFactorOf(n uint64) (uint64, bool) // largest prime factor of a given number
+	AliquotSequence(n uint64, maxSteps int) ([]uint64, bool)    // iterated aliquot sums starting with n
+	AliquotSum(n uint64) (uint64, bool)                         // sum of the proper divisors, σ(n) - n
+	Audit(samples int) error                                    // checks the factor table for corruption
+	Certify(p uint64) (*Certificate, error)                     // Pratt certificate for a prime number
+	Classify(n uint64) (Abundance, bool)                        // deficient, perfect or abundant
+	DistinctFactorCount(n uint64) (uint, bool)                  // number of distinct prime factors, ω(n)
+	DistinctFactorCounts() []uint8                              // ω(n) for all numbers up to the largest one
+	Divisors(n uint64) ([]uint64, bool)                         // all positive divisors in ascending order
+	Factorization(n uint64) (Factorization, error)              // prime factorization with an error on failure
+	Factorize(n uint64) ([]PrimePower, bool)                    // prime factorization of a given number
+	GroupBySignature(lo, hi uint64) (map[string][]uint64, bool) // numbers of a range grouped by prime signature
+	InversePhi(m, max uint64) ([]uint64, bool)                  // all n up to max with φ(n) = m
+	IsBlumInteger(n uint64) (bool, bool)                        // true iff n is the product of two distinct primes = 3 mod 4
+	IsCarmichael(n uint64) (bool, bool)                         // true iff n is a Carmichael number
+	IsSmooth(n, b uint64) (bool, bool)                          // true iff n has no prime factor larger than b
+	IsSquareFree(n uint64) (bool, bool)                         // true iff n is not divisible by a square
+	LargestFactorOf(n uint64) (uint64, bool)                    // largest prime factor of a given number
+	Phi(n uint64) (uint64, bool)                                // Euler's totient φ(n)
+	Radical(n uint64) (uint64, bool)                            // product of the distinct prime factors
+	Signature(n uint64) ([]uint, bool)                          // exponents of the factorization in descending order
+	SmoothNumbers(b, max uint64) Iterator                       // all b-smooth numbers up to max
+	TotalFactorCount(n uint64) (uint, bool)                     // number of prime factors with multiplicity, Ω(n)
+	TotalFactorCounts() []uint8                                 // Ω(n) for all numbers up to the largest one
+	WriteTo(w io.Writer) (int64, error)                         // writes the factorizer in a binary format
 }
 
 // Internal implementation of Factorizer.
 type factorizer struct {
-	set           *set     // underlying prime set
-	factors       []uint64 // largest prime factors of all numbers not divisible by 2 or 3
-	largestNumber uint64   // largest number that can be factorized by this Factorizer
+	set           *set                     // underlying prime set
+	factors       factorTable              // largest prime factors of all numbers not divisible by 2 or 3
+	largestNumber uint64                   // largest number that can be factorized by this Factorizer
+	fallback      bool                     // true iff numbers beyond largestNumber are factorized with Pollard's rho and ECM
+	cache         *lruCache                // results of fallback factorizations, or nil
+	store         FactorStore              // persistent results of fallback factorizations, or nil
+	buildWorkers  int                      // number of goroutines building the table, or 0 for GOMAXPROCS
+	buildProgress func(done, total uint64) // receiver of the build progress, or nil
+}
+
+// FactorizerOption configures the construction of a factorizer.
+type FactorizerOption func(*factorizer)
+
+// WithFallback lets the factorizer factorize numbers beyond its table with Pollard's rho method and the elliptic
+// curve method instead of giving up.
+func WithFallback() FactorizerOption {
+	return func(f *factorizer) {
+		f.fallback = true
+	}
+}
+
+// WithBuildWorkers lets the given number of goroutines build the table of the factorizer, or GOMAXPROCS goroutines if
+// workers is not positive. By default, the table is built by the calling goroutine only.
+func WithBuildWorkers(workers int) FactorizerOption {
+	return func(f *factorizer) {
+		f.buildWorkers = workers
+	}
+}
+
+// WithBuildProgress lets the construction of the factorizer report its progress to fn, which is called with the
+// number of finished windows of the table and their total number after each window. The calls are serialized, but
+// they may come from different goroutines.
+func WithBuildProgress(fn func(done, total uint64)) FactorizerOption {
+	return func(f *factorizer) {
+		f.buildProgress = fn
+	}
 }
 
 // Factorizer returns a new factorizer for numbers in the range up to n.
-func (s *set) Factorizer(max uint64) Factorizer {
-	return newFactorizerBuilder(s, max).build()
+func (s *set) Factorizer(max uint64, opts ...FactorizerOption) Factorizer {
+	f, _ := s.FactorizerContext(context.Background(), max, opts...)
+	return f
 }
 
-// LargestFactorOf returns the largest prime factor of a given number.
-// If the factorizer boundaries are exceeded during search, the second result is false.
+// FactorizerContext returns a new factorizer for numbers up to max like Factorizer, but stops building the table as
+// soon as ctx is done and returns the error of ctx then.
+func (s *set) FactorizerContext(ctx context.Context, max uint64, opts ...FactorizerOption) (Factorizer, error) {
+	start := time.Now()
+	f := &factorizer{set: s, largestNumber: max, buildWorkers: 1}
+	for _, opt := range opts {
+		opt(f)
+	}
+	if err := newFactorizerBuilder(f).build(ctx); err != nil {
+		return nil, err
+	}
+	if s.metrics != nil {
+		s.metrics.FactorizerBuilt(max, time.Since(start))
+	}
+	return f, nil
+}
+
+// LargestFactorOf returns the largest prime factor of a given number. Beyond the table, it uses the fallback if it is
+// enabled, and otherwise it divides out the primes of the set until the cofactor is within the table or known to be
+// prime. If the set does not suffice for that or n is 0, the second result is false.
 func (f *factorizer) LargestFactorOf(n uint64) (uint64, bool) {
 	n >>= numberOfTrailingZeroes(n)
 	if n == 0 {
@@ -34,151 +119,381 @@ func (f *factorizer) LargestFactorOf(n uint64) (uint64, bool) {
 		return 3, true
 	}
 	if n > f.largestNumber {
-		return 0, false
+		return f.largestFactorBeyond(n)
 	}
 	i := numberToIndex(n)
-	return f.factors[i], true
+	return f.factors.get(i), true
 }
 
-// factorizerBuilder is a temporary structure which creates a factorizer and precalculates its factors.
-type factorizerBuilder struct {
-	set      *set // underlying prime set
-	factors  []uint64
-	max      uint64
-	stack    []uint64
-	sp       int
-	maxDepth int
+// largestFactorBeyond returns the largest prime factor of n beyond the table, where n is not divisible by 2 or 3.
+func (f *factorizer) largestFactorBeyond(n uint64) (uint64, bool) {
+	if f.fallback {
+		factors, _ := f.factorizeBeyond(n)
+		return factors[len(factors)-1].Prime, true
+	}
+	largest := uint64(0) // largest prime divided out so far
+	it := f.set.Iterator(5)
+	for {
+		if n == 1 {
+			return largest, true
+		}
+		if n <= f.largestNumber {
+			// the remaining prime factors are all larger than the ones divided out
+			return f.factors.get(numberToIndex(n)), true
+		}
+		p, ok := it.Next()
+		if !ok {
+			// all prime factors up to the end of the set are divided out, so n is prime if it is below its square
+			if L := f.set.largestNumber; n/L <= L {
+				return max(largest, n), true
+			}
+			return 0, false
+		}
+		if p > n/p {
+			return n, true
+		}
+		for n%p == 0 {
+			n /= p
+			largest = p
+		}
+	}
 }
 
-const maxuint = uint64(0xffffffffffffffff) // maximum value of an uint64
-
-// newFactorizerBuilder creates a new factorizerBuilder with empty factors.
-func newFactorizerBuilder(set *set, max uint64) *factorizerBuilder {
+// Factorize returns the prime factorization of a given number in ascending order of the prime factors.
+// If the factorizer boundaries are exceeded without a fallback or n is 0, the second result is false.
+func (f *factorizer) Factorize(n uint64) ([]PrimePower, bool) {
+	if n == 0 {
+		return nil, false
+	}
+	orig := n
+	twos := numberOfTrailingZeroes(n)
+	n >>= twos
+	threes := uint(0)
+	for n%3 == 0 {
+		n /= 3
+		threes++
+	}
+	if n > f.largestNumber {
+		return f.factorizeBeyond(orig)
+	}
 
-	// create empty factors array
-	factors := make([]uint64, numberToIndex(max)+1)
+	// collect the remaining prime factors from largest to smallest
+	var large []PrimePower
+	for n > 1 {
+		p := f.factors.get(numberToIndex(n))
+		e := uint(0)
+		for n%p == 0 {
+			n /= p
+			e++
+		}
+		large = append(large, PrimePower{p, e})
+	}
 
-	// determine maximum recursion depth and initialize recursion stack
-	maxDepth := 0
-	test := uint64(1)
-	it := set.Iterator(5)
-	p, ok := it.Next()
-	for ok && test < max && test <= maxuint/p {
-		maxDepth++
-		test *= p
-		p, ok = it.Next()
+	factors := make([]PrimePower, 0, len(large)+2)
+	if twos > 0 {
+		factors = append(factors, PrimePower{2, twos})
 	}
-	stack := make([]uint64, maxDepth+1)
+	if threes > 0 {
+		factors = append(factors, PrimePower{3, threes})
+	}
+	for i := len(large) - 1; i >= 0; i-- {
+		factors = append(factors, large[i])
+	}
+	if f.set.metrics != nil {
+		f.set.metrics.Factorized()
+	}
+	return factors, true
+}
 
-	return &factorizerBuilder{set, factors, max, stack, 1, maxDepth}
+// Factorization returns the prime factorization of a given number like Factorize, but reports failures with an error
+// wrapping ErrNotFactored for 0 or ErrOutOfRange if the factorizer boundaries are exceeded without a fallback.
+func (f *factorizer) Factorization(n uint64) (Factorization, error) {
+	if n == 0 {
+		return Factorization{}, fmt.Errorf("%w: %d", ErrNotFactored, n)
+	}
+	factors, ok := f.Factorize(n)
+	if !ok {
+		return Factorization{}, fmt.Errorf("%w: %d exceeds the factorizer reaching up to %d", ErrOutOfRange, n, f.largestNumber)
+	}
+	return Factorization{n, factors}, nil
 }
 
-// build precalculates the factors in the factorizerBuilder.
-func (b *factorizerBuilder) build() *factorizer {
-	// fill factors recursively
-	it := b.set.Iterator(5)
-	p, ok := it.Next()
-	for ok && p <= b.max {
-		b.factors[numberToIndex(p)] = p // the prime number has itself as the only (and thus the largest) prime factor
-		if p < b.max/2 {
-			b.stack[0] = p
-			b.stack[1] = 5
-			b.initRecursively(p)
+// factorizeBeyond factorizes a number beyond the table if the fallback is enabled, consulting the cache and the store
+// first.
+func (f *factorizer) factorizeBeyond(n uint64) ([]PrimePower, bool) {
+	if !f.fallback {
+		return nil, false
+	}
+	factors, ok := f.cache.get(n)
+	if f.cache != nil && f.set.metrics != nil {
+		if ok {
+			f.set.metrics.CacheHit()
+		} else {
+			f.set.metrics.CacheMiss()
 		}
-		p, ok = it.Next()
 	}
-
-	// build and return the factorizer
-	return &factorizer{b.set, b.factors, b.max}
+	if !ok && f.store != nil {
+		factors, ok = f.store.Load(n)
+		if ok {
+			f.cache.put(n, factors)
+		}
+	}
+	if !ok {
+		factors = factorize(n)
+		f.cache.put(n, factors)
+		if f.store != nil {
+			f.store.Store(n, factors)
+		}
+	}
+	if f.set.metrics != nil {
+		f.set.metrics.Factorized()
+	}
+	// the cached slice must not be modified by the caller
+	return append([]PrimePower(nil), factors...), true
 }
 
-/*
-Initializes a part of {@link #factors} by recursively setting the largest prime factor of all multiples of {@code base}.
-
-Simple example with base=18797
-
-Prime factors 2 and 3 are treated transparently by skipping all multiples of 2 and 3 in factors, so we start with prime factor 5.
+// factorTable stores the largest prime factors of a factorizer. If all factors fit into 32 bits, they are stored
+// as uint32, which halves the memory footprint; otherwise they are stored as uint64.
+type factorTable struct {
+	narrow []uint32 // factors if all of them are smaller than 2^32, else nil
+	wide   []uint64 // factors if some of them may exceed 2^32, else nil
+}
 
-Recursion level 1: prime=5 => n1, 5 x n1, 5^2 x n1, 5^3 x n1, ...
-Recursion level 2: prime=7 => n2, 7 x n2, 7^2 x n2, 7^3 x n2, ...
+// newFactorTable creates an empty table of the given length for factors up to max.
+func newFactorTable(length uint, max uint64) factorTable {
+	if max < 1<<32 {
+		return factorTable{narrow: make([]uint32, length)}
+	}
+	return factorTable{wide: make([]uint64, length)}
+}
 
-At every level, recursion is stopped as soon as the result of the next multiplication would exceed max. Nonetheless, this
-approach quickly leads to a stack overflow, since every available prime number leads to another recursion. That's why recursion must
-be truncated carefully so that there are no missing results in the end.
+// get returns the factor at index i.
+func (t factorTable) get(i uint) uint64 {
+	if t.narrow != nil {
+		return uint64(t.narrow[i])
+	}
+	return t.wide[i]
+}
 
-Determining the longest possible chain of prime factors
+// set stores the factor p at index i.
+func (t factorTable) set(i uint, p uint64) {
+	if t.narrow != nil {
+		t.narrow[i] = uint32(p)
+	} else {
+		t.wide[i] = p
+	}
+}
 
-Considering that max is the largest number that should be calculated, the longest chain of distinct prime factors that can
-appear is 3 x 5 x 7 x 11 x 13 x 17 x ... < max. This number is also the maximum recursion level and is calculated in newFactorizerBuilder()
-above.
+// bytes returns the memory used by the table in bytes.
+func (t factorTable) bytes() uint {
+	return uint(len(t.narrow))<<2 + uint(len(t.wide))<<3
+}
 
-Reducing recursion levels
+// setMax stores the factor p at index i unless the factor there is already larger.
+func (t factorTable) setMax(i uint, p uint64) {
+	if t.narrow != nil {
+		t.narrow[i] = max(t.narrow[i], uint32(p))
+	} else {
+		t.wide[i] = max(t.wide[i], p)
+	}
+}
 
-In the simple example, prime factors are skipped by pushing an unmodified base downward. This happens regularly, so the stack
-size explodes until the first actual multiplication takes place. In the advanced example, prime factors are skipped by iterating over
-them at the same recursion level. stack keeps track of the prime factors already used, so that upon entering a
-new recursion level, prime factor iteration starts at the next higher prime number after
-stack[sp] and stops, of course, when max will be exceeded.
+// factorWindow is the number of factors that the builder fills at once, chosen so that a window of the table fits
+// into the L2 cache.
+const factorWindow = 1 << 16
+
+// factorChunk is the number of primes in a chunk of a bucket of the factorizerBuilder.
+const factorChunk = 1 << 10
+
+// factorizerBuilder is a temporary structure which precalculates the factors of a factorizer.
+//
+// The factors are filled with a bucket sieve: every prime p marks its multiples p*m with m = 1 or 5 mod 6, which are
+// the numbers of the table, and the largest prime marking a number is its largest prime factor. Instead of marking
+// all multiples of one prime after another, which touches the whole table for every prime, the table is processed in
+// windows of factorWindow entries. Every prime waits in the bucket of the window of its next multiple; when the
+// window is processed, the prime marks its multiples within the window and moves on to the bucket of a later window.
+// So the marks go to a window that stays in the cache, and the buckets are read and written sequentially.
+//
+// For several workers, the table is split into segments of whole windows, which are built independently from a work
+// queue. Every segment starts with the first multiples of the smaller primes within the segment, which costs one
+// division per prime, so a single worker builds the whole table as one segment.
+type factorizerBuilder struct {
+	f        *factorizer // factorizer whose factors are built
+	n        uint        // number of factors
+	segments uint        // number of segments of the table
+	windows  uint        // number of windows of the table
+	mu       sync.Mutex  // serializes the progress reports
+	done     uint64      // number of finished windows, guarded by mu
+}
 
-Recursion invariant
+// factorSegment is a worker's state for building a segment of the factors.
+type factorSegment struct {
+	*factorizerBuilder
+	lo, hi  uint              // indices of the segment's factors, lo is a multiple of factorWindow
+	small   []factorEntry     // primes with multiples in every window, which need no buckets
+	buckets [][][]factorEntry // chunks of primes waiting for each window of the segment
+	free    [][]factorEntry   // processed chunks, whose memory is reused
+}
 
-- Upon entering initRecursively, stack[sp] is set to the start of prime factor iteration at this recursion level.
-- Before entering another recursion level, the calling level is responsible for pushing a new starting prime factor on the stack. If the
-  maximum stack size maxDepth is exceeded, recursion is stopped. If the first multiplication on the next level
-  would already exceed max, recursion must not be performed.
-- After the callee returned, the caller must pop the stack.
-- stack[0] always contains the largest prime factor with which all multiples should be marked in factors</li>
-*/
-func (b *factorizerBuilder) initRecursively(base uint64) {
+// factorEntry is a prime in a bucket of the factorizerBuilder.
+type factorEntry struct {
+	p uint64 // prime factor
+	i uint   // index of the next multiple of p to be marked
+}
 
-	p := b.stack[b.sp]
-	it := b.set.Iterator(p)
-	prime, ok := it.Next()
+// newFactorizerBuilder creates a new factorizerBuilder, which fills the empty factors of f according to its options.
+func newFactorizerBuilder(f *factorizer) *factorizerBuilder {
+	n := numberToIndex(f.largestNumber) + 1
+	f.factors = newFactorTable(n, f.largestNumber)
+	b := &factorizerBuilder{f: f, n: n, segments: 1, windows: (n + factorWindow - 1) / factorWindow}
+	workers := f.buildWorkers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > 1 {
+		// more segments than workers balance the different costs of the segments
+		b.segments = min(b.windows, uint(4*workers))
+	}
+	f.buildWorkers = workers
+	return b
+}
 
-	for ok && base <= b.max/prime && prime <= b.stack[0] {
+// build precalculates the factors with the configured number of workers. It stops and returns the error of ctx as
+// soon as ctx is done.
+func (b *factorizerBuilder) build(ctx context.Context) error {
+	var next atomic.Uint64 // next segment to be built
+	var failed atomic.Pointer[error]
+	var wg sync.WaitGroup
+	for w := 0; w < b.f.buildWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k := uint(next.Add(1) - 1); k < b.segments && failed.Load() == nil; k = uint(next.Add(1) - 1) {
+				seg := &factorSegment{
+					factorizerBuilder: b,
+					lo:                k * b.windows / b.segments * factorWindow,
+					hi:                min((k+1)*b.windows/b.segments*factorWindow, b.n),
+				}
+				if err := seg.build(ctx); err != nil {
+					failed.CompareAndSwap(nil, &err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if err := failed.Load(); err != nil {
+		return *err
+	}
+	return nil
+}
 
-		// this is the following prime factor with which the next recursion level will start
-		next, nextok := it.Next()
-		if b.sp < b.maxDepth {
-			b.stack[b.sp+1] = next
+// build precalculates the factors of the segment window by window.
+func (s *factorSegment) build(ctx context.Context) error {
+	limit := s.f.largestNumber
+	s.buckets = make([][][]factorEntry, (s.hi-s.lo+factorWindow-1)/factorWindow)
+
+	// the smaller primes start with their first multiple p*m within the segment
+	it := s.f.set.Iterator(5)
+	if s.lo > 0 {
+		first := indexToNumber(s.lo)
+		for p, ok := it.Next(); ok && p <= limit/5 && p < first; p, ok = it.Next() {
+			m := max(5, (first+p-1)/p)
+			m += [6]uint64{1, 0, 3, 2, 1, 0}[m%6] // next m = 1 or 5 mod 6
+			if m <= limit/p {
+				s.add(factorEntry{p, numberToIndex(p * m)})
+			}
 		}
+		it = s.f.set.Iterator(first)
+	}
 
-		// iterate over powers of prime, starting with prime^1
-		for i := base * prime; i <= b.max; i *= prime {
-
-			// mark the current number
-			b.factors[numberToIndex(i)] = b.stack[0]
+	p, ok := it.Next()
+	for w := range s.buckets {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		hi := min(s.lo+uint(w+1)*factorWindow, s.hi)
 
-			// stop iteration if there would be an integer overflow at the next recursion level
-			if i > maxuint/next {
-				break
+		// the primes of the window are their own largest prime factors, and they start marking with their multiple 5p
+		for ; ok && p <= limit && numberToIndex(p) < hi; p, ok = it.Next() {
+			s.f.factors.set(numberToIndex(p), p)
+			if p <= limit/5 {
+				s.add(factorEntry{p, numberToIndex(5 * p)})
 			}
+		}
 
-			// do not recurse if
-			// 1. the first mutiplication at the next recursion level would already exceed max,
-			// 2. the maximum recursion depth is already reached, or
-			// 3. the nextPrime would be larger than the largest prime factor in stack[0].
-			if i > b.max/next || b.sp == b.maxDepth || next > b.stack[0] {
-				continue
+		for k := range s.small {
+			s.mark(&s.small[k], hi)
+		}
+		for _, chunk := range s.buckets[w] {
+			for k := range chunk {
+				if e := &chunk[k]; s.mark(e, hi) < s.hi {
+					s.push(*e)
+				}
 			}
+			s.free = append(s.free, chunk[:0])
+		}
+		s.buckets[w] = nil
+		s.report()
+	}
+	return nil
+}
 
-			// recurse
-			b.sp++ // stack element is already set
-			b.initRecursively(i)
-			b.sp--
+// add adds a prime with the index of its next multiple to the segment, unless the multiple is beyond the segment.
+func (s *factorSegment) add(e factorEntry) {
+	if e.i >= s.hi {
+		return
+	}
+	if 4*e.p < factorWindow {
+		s.small = append(s.small, e)
+	} else {
+		s.push(e)
+	}
+}
 
-			// avoid integer overflow in the next iteration of prime powers
-			if i > maxuint/prime {
-				break
-			}
-		}
+// report reports a finished window to the progress receiver.
+func (b *factorizerBuilder) report() {
+	if b.f.buildProgress == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.done++
+	b.f.buildProgress(b.done, uint64(b.windows))
+}
 
-		// avoid integer overflow in the next iteration of prime factors
-		if base > maxuint/next {
-			break
-		}
+// mark marks the multiples of a prime up to the index hi and returns the index of its next multiple.
+func (s *factorSegment) mark(e *factorEntry, hi uint) uint {
+	// the multiples alternate between m = 5 and m = 1 mod 6, where the steps to the next multiple are 2p and 4p, and
+	// the indices of numbers 5 mod 6 are odd
+	short := (2*e.p + 1 - e.p%6/2) / 3 // index step from p*m to p*(m+2) for m = 5 mod 6
+	step, other := uint(short), uint(2*e.p-short)
+	if (e.i&1 == 1) != (e.p%6 == 1) {
+		step, other = other, step
+	}
+	for e.i < hi {
+		s.f.factors.setMax(e.i, e.p)
+		e.i += step
+		step, other = other, step
+	}
+	return e.i
+}
 
-		prime = next
-		ok = nextok
+// push puts a prime into the bucket of the window of its next multiple. The buckets consist of chunks of fixed size,
+// so they never need to be copied when they grow.
+func (s *factorSegment) push(e factorEntry) {
+	w := (e.i - s.lo) / factorWindow
+	chunks := s.buckets[w]
+	if len(chunks) == 0 || len(chunks[len(chunks)-1]) == factorChunk {
+		if len(s.free) > 0 {
+			chunks = append(chunks, s.free[len(s.free)-1])
+			s.free = s.free[:len(s.free)-1]
+		} else {
+			chunks = append(chunks, make([]factorEntry, 0, factorChunk))
+		}
+		s.buckets[w] = chunks
 	}
+	last := &chunks[len(chunks)-1]
+	*last = append(*last, e)
 }
@@ -1,7 +1,10 @@
 package primes
 
 import (
+	"context"
+	"errors"
 	"log"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -35,6 +38,62 @@ func TestFactorizer(t *testing.T) {
 	testLargestFactor(t, f, 3750000, 5)
 }
 
+func TestFactorizerWindows(t *testing.T) {
+	// the table spans several windows of the bucket sieve, and the last one is only partially filled
+	max := uint64(3*factorWindow*3 + 1000)
+	largest := make([]uint64, max+1)
+	for p := uint64(2); p <= max; p++ {
+		if largest[p] == 0 {
+			for n := p; n <= max; n += p {
+				largest[n] = p
+			}
+		}
+	}
+	f := NewPrimeSet(max).Factorizer(max)
+	for n := uint64(2); n <= max; n++ {
+		if pf, ok := f.LargestFactorOf(n); !ok || pf != largest[n] {
+			t.Fatalf("LargestFactorOf(%d) = %d, %v instead of %d", n, pf, ok, largest[n])
+		}
+	}
+}
+
+func TestFactorizerWorkers(t *testing.T) {
+	max := uint64(5000000)
+	set := NewPrimeSet(max)
+	expected := set.Factorizer(max).(*factorizer)
+	for _, workers := range []int{0, 2, 3, 7} {
+		var done, total uint64
+		f := set.Factorizer(max, WithBuildWorkers(workers), WithBuildProgress(func(d, t uint64) {
+			if d != done+1 {
+				panic("progress must be reported for every window")
+			}
+			done, total = d, t
+		})).(*factorizer)
+		if done != total || total != uint64(len(f.factors.narrow)+factorWindow-1)/factorWindow {
+			t.Errorf("%d workers report %d of %d windows", workers, done, total)
+		}
+		if !reflect.DeepEqual(f.factors, expected.factors) {
+			t.Errorf("factors built by %d workers differ", workers)
+		}
+	}
+}
+
+func TestFactorizerContext(t *testing.T) {
+	set := NewPrimeSet(5000000)
+	ctx, cancel := context.WithCancel(context.Background())
+	f, err := set.FactorizerContext(ctx, 5000000, WithBuildWorkers(2), WithBuildProgress(func(done, total uint64) {
+		if done == 3 {
+			cancel()
+		}
+	}))
+	if f != nil || !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled build returned %v", err)
+	}
+	if f, err := set.FactorizerContext(context.Background(), 1000); err != nil || f.(*factorizer).largestNumber != 1000 {
+		t.Errorf("FactorizerContext() failed: %v", err)
+	}
+}
+
 func testLargestFactor(t *testing.T, factorizer Factorizer, n, factor uint64) {
 	f, ok := factorizer.LargestFactorOf(n)
 	if !ok {
@@ -43,3 +102,47 @@ func testLargestFactor(t *testing.T, factorizer Factorizer, n, factor uint64) {
 		t.Errorf("LargestFactorOf(%d) = %d instead of %d", n, f, factor)
 	}
 }
+
+func TestLargestFactorBeyond(t *testing.T) {
+	set := NewPrimeSet(100000)
+	f := set.Factorizer(1000)
+	for _, c := range []struct{ n, expected uint64 }{
+		{6 * 1009 * 1013, 1013}, {7 * 999983, 999983}, {991 * 997, 997}, {95367431640625, 5}, {1024 * 1001, 13},
+	} {
+		testLargestFactor(t, f, c.n, c.expected)
+	}
+	if p, ok := f.LargestFactorOf(1000003 * 1000033); ok {
+		t.Errorf("LargestFactorOf() beyond the set = %d", p)
+	}
+	testLargestFactor(t, set.Factorizer(1000, WithFallback()), 1000003*1000033, 1000033)
+	// both factors are beyond the set, but the smaller one is below its end
+	small := NewPrimeSet(1000)
+	testLargestFactor(t, small.Factorizer(100), 1151*1153, 1153)
+	testLargestFactor(t, small.Factorizer(100), 2*3*1151*1153, 1153)
+}
+
+func TestFactorTable(t *testing.T) {
+	for _, max := range []uint64{1000, 1<<32 - 1, 1 << 32} {
+		table := newFactorTable(10, max)
+		table.set(3, max)
+		if f := table.get(3); f != max {
+			t.Errorf("factor table for %d returned %d", max, f)
+		}
+		if expected := uint(40); max >= 1<<32 && table.bytes() != 2*expected || max < 1<<32 && table.bytes() != expected {
+			t.Errorf("factor table for %d uses %d bytes", max, table.bytes())
+		}
+	}
+}
+
+func TestFactorization(t *testing.T) {
+	f := NewPrimeSet(1000).Factorizer(1000)
+	if result, err := f.Factorization(360); err != nil || result.N != 360 || len(result.Factors) != 3 {
+		t.Errorf("Factorization(360) = %v, %v", result, err)
+	}
+	if _, err := f.Factorization(0); !errors.Is(err, ErrNotFactored) {
+		t.Errorf("Factorization(0) = %v", err)
+	}
+	if _, err := f.Factorization(1000003); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Factorization(1000003) = %v", err)
+	}
+}
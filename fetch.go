@@ -0,0 +1,66 @@
+package primes
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// FetchPrimeSet downloads a set written by Set.WriteTo from the given URL and verifies it against the expected
+// checksum of Set.Checksum, so that many services can share a centrally built set instead of sieving at startup.
+// The options are applied as for ReadPrimeSet. A checksum mismatch is reported with an error wrapping ErrCorrupt, as
+// is a set whose header does not match the Content-Length of the response, which is checked before the prime bits are
+// allocated.
+func FetchPrimeSet(ctx context.Context, url string, expectedChecksum uint64, opts ...Option) (Set, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("primes: fetching prime set from %s: %s", url, resp.Status)
+	}
+	body := bufio.NewReader(resp.Body)
+	if resp.ContentLength >= 0 {
+		if err := checkSetLength(body, resp.ContentLength); err != nil {
+			return nil, fmt.Errorf("%w from %s", err, url)
+		}
+	}
+	s, err := ReadPrimeSet(body, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if sum := s.Checksum(); sum != expectedChecksum {
+		return nil, fmt.Errorf("%w: checksum %#x of prime set from %s instead of %#x", ErrCorrupt, sum, url, expectedChecksum)
+	}
+	return s, nil
+}
+
+// checkSetLength peeks at the header of a serialized set and verifies that it has the given length in bytes, so that a
+// forged header cannot make ReadPrimeSet allocate more memory than was actually sent.
+func checkSetLength(r *bufio.Reader, length int64) error {
+	var h setHeader
+	data, err := r.Peek(binary.Size(h))
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	if err := binary.Read(bytes.NewReader(data), binary.LittleEndian, &h); err != nil {
+		return err
+	}
+	bitsLength := length - int64(len(data)) - 4 // without header and checksum
+	if bitsLength < 0 || bitsLength%8 != 0 || h.Words != uint64(bitsLength/8) {
+		return fmt.Errorf("%w: prime set of %d words in %d bytes", ErrCorrupt, h.Words, length)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package primes
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestFetchPrimeSet(t *testing.T) {
+	set := NewPrimeSet(100000)
+	var buf bytes.Buffer
+	set.WriteTo(&buf)
+	data := buf.Bytes()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/forged.pset" {
+			// a header claiming a huge set, but only the bits of the small set
+			forged := append([]byte(nil), data...)
+			binary.LittleEndian.PutUint64(forged[8:], indexToNumber((1<<20-1)<<6))
+			binary.LittleEndian.PutUint64(forged[24:], 1<<20)
+			w.Header().Set("Content-Length", strconv.Itoa(len(forged)))
+			w.Write(forged)
+			return
+		}
+		if r.URL.Path != "/primes.pset" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.Write(data)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	fetched, err := FetchPrimeSet(ctx, server.URL+"/primes.pset", set.Checksum())
+	if err != nil || !Equal(fetched, set) {
+		t.Errorf("FetchPrimeSet() = %v", err)
+	}
+	if _, err := FetchPrimeSet(ctx, server.URL+"/primes.pset", set.Checksum()+1); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("FetchPrimeSet() with wrong checksum = %v", err)
+	}
+	if _, err := FetchPrimeSet(ctx, server.URL+"/forged.pset", set.Checksum()); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("FetchPrimeSet() with forged header = %v", err)
+	}
+	if _, err := FetchPrimeSet(ctx, server.URL+"/missing", set.Checksum()); err == nil {
+		t.Error("FetchPrimeSet() of a missing set should fail")
+	}
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := FetchPrimeSet(canceled, server.URL+"/primes.pset", set.Checksum()); !errors.Is(err, context.Canceled) {
+		t.Errorf("FetchPrimeSet() with canceled context = %v", err)
+	}
+}
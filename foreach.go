@@ -0,0 +1,101 @@
+package primes
+
+import (
+	"math/bits"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// forEachChunk is the number of words of prime bits that a worker of ForEachParallel processes at once.
+const forEachChunk = 1024
+
+// ForEach calls fn for all primes of the set in [start, end] in ascending order, until fn returns false. It scans
+// the prime bits word by word, which is much faster than an Iterator for aggregations over whole ranges.
+func (s *set) ForEach(start, end uint64, fn func(p uint64) bool) {
+	end = min(end, s.largestNumber)
+	if start > end || start <= 2 && end >= 2 && !fn(2) {
+		return
+	}
+	s.forEachBits(start, end, fn)
+}
+
+// ForEachParallel calls fn for all primes of the set in [start, end] like ForEach, but distributes chunks of the
+// range to the given number of goroutines, or to GOMAXPROCS goroutines if workers is not positive. So fn is called
+// concurrently and in no particular order. If fn returns false, all workers stop soon, but calls that are already
+// underway are finished.
+func (s *set) ForEachParallel(start, end uint64, fn func(p uint64) bool, workers int) {
+	end = min(end, s.largestNumber)
+	if start > end || start <= 2 && end >= 2 && !fn(2) {
+		return
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	var stopped atomic.Bool
+	guarded := func(p uint64) bool {
+		if stopped.Load() {
+			return false
+		}
+		if !fn(p) {
+			stopped.Store(true)
+			return false
+		}
+		return true
+	}
+	first, last := numberToIndex(start)/(forEachChunk<<6), numberToIndex(end)/(forEachChunk<<6)
+	var next atomic.Uint64 // next chunk to be processed
+	next.Store(uint64(first))
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for c := uint(next.Add(1) - 1); c <= last && !stopped.Load(); c = uint(next.Add(1) - 1) {
+				lo := max(start, indexToNumber(c*forEachChunk<<6))
+				hi := min(end, indexToNumber((c+1)*forEachChunk<<6-1))
+				s.forEachBits(lo, hi, guarded)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// forEachBits calls fn for all primes in [lo, hi] except 2 in ascending order, until fn returns false, and returns
+// false in that case. hi must not exceed the largest number of the set.
+func (s *set) forEachBits(lo, hi uint64, fn func(p uint64) bool) bool {
+	if lo > hi {
+		return true
+	}
+	from, to := numberToIndex(lo), numberToIndex(hi)
+	for word := from >> 6; word <= to>>6; word++ {
+		w := s.word(word)
+		if word == from>>6 {
+			w &= ^uint64(0) << (from & 63)
+		}
+		if word == to>>6 {
+			w &= ^uint64(0) >> (63 - to&63)
+		}
+		for w != 0 {
+			i := word<<6 + uint(bits.TrailingZeros64(w))
+			w &= w - 1
+			if p := indexToNumber(i); p >= lo && p <= hi && !fn(p) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Find returns the first prime of the set from start on that fulfils the given predicate. If there is no such prime
+// in the set, the second result is false.
+func (s *set) Find(start uint64, pred func(p uint64) bool) (uint64, bool) {
+	found, ok := uint64(0), false
+	s.ForEach(start, s.largestNumber, func(p uint64) bool {
+		if pred(p) {
+			found, ok = p, true
+		}
+		return !ok
+	})
+	return found, ok
+}
@@ -0,0 +1,86 @@
+package primes
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+func TestForEach(t *testing.T) {
+	set := NewPrimeSet(1000000)
+	for _, c := range [][2]uint64{{0, 1000000}, {0, 1}, {2, 2}, {3, 3}, {4, 4}, {5, 100}, {89, 97}, {999000, 2000000}, {100, 50}} {
+		var expected []uint64
+		it := set.Iterator(c[0])
+		for p, ok := it.Next(); ok && p <= c[1]; p, ok = it.Next() {
+			expected = append(expected, p)
+		}
+		var actual []uint64
+		set.ForEach(c[0], c[1], func(p uint64) bool {
+			actual = append(actual, p)
+			return true
+		})
+		if len(actual) != len(expected) {
+			t.Fatalf("ForEach(%d, %d) yields %d primes instead of %d", c[0], c[1], len(actual), len(expected))
+		}
+		for i := range actual {
+			if actual[i] != expected[i] {
+				t.Fatalf("ForEach(%d, %d) yields %d instead of %d", c[0], c[1], actual[i], expected[i])
+			}
+		}
+
+		var count, sum atomic.Uint64
+		set.ForEachParallel(c[0], c[1], func(p uint64) bool {
+			count.Add(1)
+			sum.Add(p)
+			return true
+		}, 4)
+		expectedSum := uint64(0)
+		for _, p := range expected {
+			expectedSum += p
+		}
+		if count.Load() != uint64(len(expected)) || sum.Load() != expectedSum {
+			t.Errorf("ForEachParallel(%d, %d) yields %d primes with sum %d", c[0], c[1], count.Load(), sum.Load())
+		}
+	}
+}
+
+func TestForEachStop(t *testing.T) {
+	set := NewPrimeSet(1000000)
+	count := 0
+	set.ForEach(0, 1000000, func(p uint64) bool {
+		count++
+		return p < 100
+	})
+	if count != 26 {
+		t.Errorf("ForEach() calls fn %d times after stop", count)
+	}
+	var calls atomic.Int64
+	set.ForEachParallel(0, 1000000, func(p uint64) bool {
+		return calls.Add(1) < 10
+	}, 0)
+	if calls.Load() > 1000 {
+		t.Errorf("ForEachParallel() calls fn %d times after stop", calls.Load())
+	}
+}
+
+func TestFind(t *testing.T) {
+	set := NewPrimeSet(20000000)
+	digitSum := func(p uint64) uint64 {
+		sum := uint64(0)
+		for ; p > 0; p /= 10 {
+			sum += p % 10
+		}
+		return sum
+	}
+	if p, ok := set.Find(10000000, func(p uint64) bool { return digitSum(p) == 13 }); !ok || p != 10000363 {
+		t.Errorf("Find() = %d, %v", p, ok)
+	}
+	if p, ok := set.Find(0, func(p uint64) bool { return p > 1 }); !ok || p != 2 {
+		t.Errorf("Find() = %d, %v", p, ok)
+	}
+	if p, ok := set.Find(0, func(p uint64) bool { return p%10 == 4 }); ok {
+		t.Errorf("Find() of an impossible prime = %d", p)
+	}
+	if p, ok := set.SubSet(100, 200).Find(0, func(p uint64) bool { return p > 190 }); !ok || p != 191 {
+		t.Errorf("Find() in a subset = %d, %v", p, ok)
+	}
+}
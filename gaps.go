@@ -0,0 +1,44 @@
+package primes
+
+// GapRecord describes a maximal prime gap, i.e. a gap which is larger than all gaps between smaller primes.
+type GapRecord = GapReport
+
+// MaximalGaps returns all maximal prime gaps in the set in ascending order.
+func (s *set) MaximalGaps() []GapRecord {
+	return maximalGaps(s.Iterator(0))
+}
+
+// maximalGaps returns all maximal prime gaps between the primes of an iterator.
+func maximalGaps(it Iterator) []GapRecord {
+	var records []GapRecord
+	prev, largest := uint64(0), uint64(0)
+	for p, ok := it.Next(); ok; p, ok = it.Next() {
+		if prev != 0 && p-prev > largest {
+			largest = p - prev
+			records = append(records, GapRecord{prev, p})
+		}
+		prev = p
+	}
+	return records
+}
+
+// FirstOccurrenceGaps returns the gaps between consecutive primes up to max, mapped to the prime where they occur for
+// the first time, i.e. the smaller prime of the first pair with that distance, as listed in the tables of Nicely.
+// The primes are scanned once, up to the end of the set at most.
+func (s *set) FirstOccurrenceGaps(max uint64) map[uint64]uint64 {
+	return firstOccurrenceGaps(s.Iterator(0), max)
+}
+
+// firstOccurrenceGaps returns the gaps between consecutive primes of an iterator up to max, mapped to the prime where
+// they occur for the first time.
+func firstOccurrenceGaps(it Iterator, max uint64) map[uint64]uint64 {
+	first := make(map[uint64]uint64)
+	prev := uint64(0)
+	for p, ok := it.Next(); ok && p <= max; p, ok = it.Next() {
+		if _, seen := first[p-prev]; prev != 0 && !seen {
+			first[p-prev] = prev
+		}
+		prev = p
+	}
+	return first
+}
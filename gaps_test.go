@@ -0,0 +1,40 @@
+package primes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMaximalGaps(t *testing.T) {
+	expected := []GapRecord{
+		{2, 3}, {3, 5}, {7, 11}, {23, 29}, {89, 97}, {113, 127}, {523, 541}, {887, 907}, {1129, 1151}, {1327, 1361},
+		{9551, 9587}, {15683, 15727}, {19609, 19661}, {31397, 31469}, {155921, 156007}, {360653, 360749},
+		{370261, 370373}, {492113, 492227}, {1349533, 1349651}, {1357201, 1357333}, {2010733, 2010881},
+	}
+	gaps := NewPrimeSet(3000000).MaximalGaps()
+	if len(gaps) != len(expected) {
+		t.Fatalf("MaximalGaps() = %v instead of %v", gaps, expected)
+	}
+	for i := range gaps {
+		if gaps[i] != expected[i] {
+			t.Errorf("MaximalGaps()[%d] = %v instead of %v", i, gaps[i], expected[i])
+		}
+	}
+}
+
+func TestFirstOccurrenceGaps(t *testing.T) {
+	expected := map[uint64]uint64{1: 2, 2: 3, 4: 7, 6: 23, 8: 89, 10: 139, 12: 199, 14: 113, 16: 1831, 18: 523, 20: 887,
+		22: 1129, 24: 1669, 26: 2477, 28: 2971, 30: 4297, 32: 5591, 34: 1327, 36: 9551}
+	s := NewPrimeSet(3000000)
+	first := s.FirstOccurrenceGaps(10000)
+	if !reflect.DeepEqual(first, expected) {
+		t.Errorf("FirstOccurrenceGaps(10000) = %v", first)
+	}
+	// every maximal gap occurs for the first time
+	first = s.FirstOccurrenceGaps(s.LargestNumber())
+	for _, r := range s.MaximalGaps() {
+		if p, ok := first[r.Gap()]; !ok || p != r.Start {
+			t.Errorf("first occurrence of gap %d is %d instead of %d", r.Gap(), p, r.Start)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package primes
+
+import "math/bits"
+
+// IsGaussianPrime returns true iff a+bi is a prime in the Gaussian integers Z[i]. These are the numbers with a
+// prime norm a²+b², and the rational primes p = 3 mod 4 times a unit, i.e. ±p and ±pi. The norm of up to 127 bits
+// is tested with IsPrime128.
+func IsGaussianPrime(a, b int64) bool {
+	x, y := absInt64(a), absInt64(b)
+	if x == 0 || y == 0 {
+		// a rational integer times a unit, which stays prime in Z[i] only if it is inert
+		p := x | y
+		return p%4 == 3 && IsPrime128(Uint128{0, p})
+	}
+	hi, lo := bits.Mul64(x, x)
+	norm := Uint128{hi, lo}
+	hi, lo = bits.Mul64(y, y)
+	return IsPrime128(norm.add(Uint128{hi, lo}))
+}
+
+// absInt64 returns the absolute value of n, which also fits for the smallest int64.
+func absInt64(n int64) uint64 {
+	if n < 0 {
+		return uint64(-n)
+	}
+	return uint64(n)
+}
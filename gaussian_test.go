@@ -0,0 +1,36 @@
+package primes
+
+import (
+	"math"
+	"testing"
+)
+
+func TestIsGaussianPrime(t *testing.T) {
+	for _, test := range []struct {
+		a, b     int64
+		expected bool
+	}{
+		{0, 0, false}, {1, 0, false}, {0, -1, false}, {1, 1, true}, {-1, 1, true}, {2, 0, false}, {3, 0, true},
+		{0, -3, true}, {5, 0, false}, {2, 1, true}, {1, -2, true}, {7, 0, true}, {2, 2, false}, {3, 2, true},
+		{4, 1, true}, {3, 3, false}, {5, 4, true}, {6, 5, true}, {4, 3, false}, {11, 0, true}, {0, 13, false},
+		{math.MaxInt64, 4611686018427387938, true}, {math.MaxInt64, -4611686018427387938, true},
+		{-9223372036854775783, 0, true}, {math.MinInt64, 0, false}, {math.MinInt64, math.MinInt64, false},
+	} {
+		if IsGaussianPrime(test.a, test.b) != test.expected {
+			t.Errorf("IsGaussianPrime(%d, %d) != %v", test.a, test.b, test.expected)
+		}
+	}
+
+	// the Gaussian primes with norm up to 100 in the first quadrant, excluding the imaginary axis
+	count := 0
+	for a := int64(1); a <= 10; a++ {
+		for b := int64(0); a*a+b*b <= 100; b++ {
+			if IsGaussianPrime(a, b) {
+				count++
+			}
+		}
+	}
+	if count != 25 {
+		t.Errorf("%d Gaussian primes instead of 25", count)
+	}
+}
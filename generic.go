@@ -0,0 +1,129 @@
+package primes
+
+// Unsigned is the constraint for the number types of the generic facade.
+type Unsigned interface {
+	~uint32 | ~uint64
+}
+
+// SetOf is a generic facade of Set for numbers of type T, e.g. for 32-bit workloads that do not want to convert
+// between uint32 and uint64 everywhere. The facade is only a typed wrapper: the prime bits are shared with the
+// underlying Set, and the factor tables are those of its Factorizer, which already stores 32-bit entries when max is
+// below 2^32. SetOf[uint32] therefore needs no less memory than a Set of the same limit.
+type SetOf[T Unsigned] interface {
+	Factorizer(max T, opts ...FactorizerOption) FactorizerOf[T] // allows for quick factorization of numbers
+	IsPrime(n T) bool                                           // true iff n is prime
+	Iterator(start T) IteratorOf[T]                             // allows for traversing the set
+	LargestNumber() T                                           // largest number in the set
+	LargestPrime() T                                            // largest prime number in the set
+	Set() Set                                                   // underlying set with the full API
+}
+
+// IteratorOf is a generic facade of Iterator for numbers of type T.
+type IteratorOf[T Unsigned] interface {
+	Next() (T, bool) // next prime number and status
+}
+
+// FactorizerOf is a generic facade of Factorizer for numbers of type T.
+type FactorizerOf[T Unsigned] interface {
+	Factorize(n T) ([]PrimePowerOf[T], bool) // prime factorization of a given number
+	LargestFactorOf(n T) (T, bool)           // largest prime factor of a given number
+	SmallestFactorOf(n T) (T, bool)          // smallest prime factor of a given number
+}
+
+// PrimePowerOf is a prime power p^e with a prime of type T.
+type PrimePowerOf[T Unsigned] struct {
+	Prime    T    // prime number p
+	Exponent uint // exponent e
+}
+
+// setOf is the internal implementation of SetOf.
+type setOf[T Unsigned] struct {
+	set *set // underlying set
+}
+
+// NewPrimeSetOf creates a new set of prime numbers of type T up to a given limit. Like NewPrimeSet, the set may reach
+// a bit beyond the limit, but its largest number is capped to the maximum of T.
+func NewPrimeSetOf[T Unsigned](limit T, opts ...Option) SetOf[T] {
+	return &setOf[T]{NewPrimeSet(uint64(limit), opts...).(*set)}
+}
+
+// Factorizer returns a new factorizer for numbers in the range up to max.
+func (s *setOf[T]) Factorizer(max T, opts ...FactorizerOption) FactorizerOf[T] {
+	return &factorizerOf[T]{s.set.Factorizer(uint64(max), opts...).(*factorizer)}
+}
+
+// IsPrime returns true iff n is a prime number.
+func (s *setOf[T]) IsPrime(n T) bool {
+	return s.set.IsPrime(uint64(n))
+}
+
+// Iterator returns an iterator over the set that returns all primes of type T from start on in ascending order.
+func (s *setOf[T]) Iterator(start T) IteratorOf[T] {
+	return &iteratorOf[T]{s.set.Iterator(uint64(start))}
+}
+
+// LargestNumber returns the largest number in the set that fits into T.
+func (s *setOf[T]) LargestNumber() T {
+	return T(min(s.set.largestNumber, uint64(^T(0))))
+}
+
+// LargestPrime returns the largest prime number in the set that fits into T.
+func (s *setOf[T]) LargestPrime() T {
+	if s.set.largestPrime <= uint64(^T(0)) {
+		return T(s.set.largestPrime)
+	}
+	// the largest prime of 32 bits
+	return T(uint64(4294967291))
+}
+
+// Set returns the underlying set.
+func (s *setOf[T]) Set() Set {
+	return s.set
+}
+
+// iteratorOf is the internal implementation of IteratorOf.
+type iteratorOf[T Unsigned] struct {
+	it Iterator // underlying iterator
+}
+
+// Next returns the next prime number in ascending order, ending with the largest prime that fits into T.
+func (i *iteratorOf[T]) Next() (T, bool) {
+	p, ok := i.it.Next()
+	if !ok || p > uint64(^T(0)) {
+		return 0, false
+	}
+	return T(p), true
+}
+
+// factorizerOf is the internal implementation of FactorizerOf.
+type factorizerOf[T Unsigned] struct {
+	f *factorizer // underlying factorizer
+}
+
+// Factorize returns the prime factorization of a given number in ascending order of the prime factors.
+// If the factorizer boundaries are exceeded without a fallback or n is 0, the second result is false.
+func (f *factorizerOf[T]) Factorize(n T) ([]PrimePowerOf[T], bool) {
+	factors, ok := f.f.Factorize(uint64(n))
+	if !ok {
+		return nil, false
+	}
+	result := make([]PrimePowerOf[T], len(factors))
+	for i, pp := range factors {
+		result[i] = PrimePowerOf[T]{T(pp.Prime), pp.Exponent}
+	}
+	return result, true
+}
+
+// LargestFactorOf returns the largest prime factor of a given number like Factorizer.LargestFactorOf.
+// If the set does not suffice to determine it beyond the table or n is 0, the second result is false.
+func (f *factorizerOf[T]) LargestFactorOf(n T) (T, bool) {
+	p, ok := f.f.LargestFactorOf(uint64(n))
+	return T(p), ok
+}
+
+// SmallestFactorOf returns the smallest prime factor of a given number.
+// If the set boundaries are exceeded during search, the second result is false.
+func (f *factorizerOf[T]) SmallestFactorOf(n T) (T, bool) {
+	p, ok := f.f.set.SmallestFactorOf(uint64(n))
+	return T(p), ok
+}
@@ -0,0 +1,32 @@
+package primes
+
+import "testing"
+
+func TestSetOf(t *testing.T) {
+	set := NewPrimeSetOf[uint32](1000)
+	if !set.IsPrime(997) || set.IsPrime(999) || set.LargestPrime() < 997 || set.LargestNumber() < 1000 {
+		t.Error("generic set is wrong")
+	}
+	var primes []uint32
+	it := set.Iterator(980)
+	for p, ok := it.Next(); ok && p < 1010; p, ok = it.Next() {
+		primes = append(primes, p)
+	}
+	if len(primes) != 4 || primes[0] != 983 || primes[3] != 1009 {
+		t.Errorf("Iterator(980) yields %v", primes)
+	}
+
+	f := set.Factorizer(1000)
+	if factors, ok := f.Factorize(360); !ok || len(factors) != 3 || factors[1] != (PrimePowerOf[uint32]{3, 2}) {
+		t.Errorf("Factorize(360) = %v, %v", factors, ok)
+	}
+	if p, ok := f.LargestFactorOf(360); !ok || p != 5 {
+		t.Errorf("LargestFactorOf(360) = %d, %v", p, ok)
+	}
+	if p, ok := f.SmallestFactorOf(999); !ok || p != 3 {
+		t.Errorf("SmallestFactorOf(999) = %d, %v", p, ok)
+	}
+	if set.Set().LargestNumber() != uint64(set.LargestNumber()) {
+		t.Error("underlying set differs")
+	}
+}
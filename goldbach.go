@@ -0,0 +1,52 @@
+package primes
+
+// PairIterator allows for traversing pairs of prime numbers.
+type PairIterator interface {
+	Next() (uint64, uint64, bool) // next pair of prime numbers and status
+}
+
+// goldbachIterator is the internal implementation of PairIterator for Goldbach partitions.
+type goldbachIterator struct {
+	set  *set     // prime set used for the primality checks
+	it   Iterator // iterator over the smaller primes p of each pair
+	n    uint64   // number to be partitioned
+	done bool     // true after the last partition has been returned
+}
+
+// GoldbachPartitions returns an iterator over all pairs (p, q) of primes with p <= q and p + q = n.
+// If n - 2 exceeds the set boundaries, the iterator returns no pairs; use GoldbachCount to detect this case.
+func (s *set) GoldbachPartitions(n uint64) PairIterator {
+	if n < 4 || n-2 > s.largestNumber {
+		return &goldbachIterator{s, nil, n, true}
+	}
+	return &goldbachIterator{s, s.Iterator(0), n, false}
+}
+
+// Next returns the next Goldbach partition in ascending order of its smaller prime.
+func (i *goldbachIterator) Next() (uint64, uint64, bool) {
+	if i.done {
+		return 0, 0, false
+	}
+	for p, ok := i.it.Next(); ok && p <= i.n/2; p, ok = i.it.Next() {
+		// q = n - p is looked up directly in the bit set
+		if q := i.n - p; i.set.isPrime(q) {
+			return p, q, true
+		}
+	}
+	i.done = true
+	return 0, 0, false
+}
+
+// GoldbachCount returns the number of Goldbach partitions of n, i.e. the number of pairs (p, q) of primes
+// with p <= q and p + q = n. If the set boundaries are exceeded, the second result is false.
+func (s *set) GoldbachCount(n uint64) (uint64, bool) {
+	if n >= 4 && n-2 > s.largestNumber {
+		return 0, false
+	}
+	count := uint64(0)
+	it := s.GoldbachPartitions(n)
+	for _, _, ok := it.Next(); ok; _, _, ok = it.Next() {
+		count++
+	}
+	return count, true
+}
@@ -0,0 +1,33 @@
+package primes
+
+import "testing"
+
+func TestGoldbachPartitions(t *testing.T) {
+	set := NewPrimeSet(1000)
+	expected := [][2]uint64{{3, 97}, {11, 89}, {17, 83}, {29, 71}, {41, 59}, {47, 53}}
+	it := set.GoldbachPartitions(100)
+	for _, e := range expected {
+		p, q, ok := it.Next()
+		if !ok || p != e[0] || q != e[1] {
+			t.Errorf("expected partition %d + %d of 100, got %d + %d", e[0], e[1], p, q)
+		}
+	}
+	if p, q, ok := it.Next(); ok {
+		t.Errorf("unexpected partition %d + %d of 100", p, q)
+	}
+	if p, q, ok := set.GoldbachPartitions(4).Next(); !ok || p != 2 || q != 2 {
+		t.Errorf("4 = 2 + 2 expected, got %d + %d", p, q)
+	}
+}
+
+func TestGoldbachCount(t *testing.T) {
+	set := NewPrimeSet(10000)
+	for n, expected := range map[uint64]uint64{0: 0, 3: 0, 4: 1, 11: 0, 13: 1, 100: 6, 1000: 28, 10000: 127} {
+		if c, ok := set.GoldbachCount(n); !ok || c != expected {
+			t.Errorf("GoldbachCount(%d) = %d instead of %d", n, c, expected)
+		}
+	}
+	if _, ok := set.GoldbachCount(set.LargestNumber() + 3); ok {
+		t.Error("GoldbachCount should fail beyond the set boundaries")
+	}
+}
@@ -0,0 +1,54 @@
+package primes
+
+import "sort"
+
+// HighlyCompositeNumbers returns an iterator over all highly composite numbers up to max in ascending order, i.e. the
+// numbers with more divisors than any smaller number: 1, 2, 4, 6, 12, 24, 36, 48, 60, 120, ... Instead of evaluating
+// τ(n) for every number, only the candidates 2^a·3^b·5^c·... with non-increasing exponents a >= b >= c >= ... are
+// generated, as every highly composite number has this form. The primes up to 47 required for 64 bit numbers are
+// contained in every set.
+func (s *set) HighlyCompositeNumbers(max uint64) Iterator {
+	if max == 0 {
+		return funcIterator(func() (uint64, bool) { return 0, false })
+	}
+	// the primes used by the candidates, i.e. as long as their product does not exceed max
+	var primes []uint64
+	product := uint64(1)
+	it := s.Iterator(0)
+	for p, ok := it.Next(); ok && product <= max/p; p, ok = it.Next() {
+		product *= p
+		primes = append(primes, p)
+	}
+
+	type candidate struct {
+		n   uint64 // candidate number
+		tau uint64 // number of divisors of n
+	}
+	candidates := []candidate{{1, 1}}
+	var generate func(i int, n, tau uint64, maxExponent uint64)
+	generate = func(i int, n, tau uint64, maxExponent uint64) {
+		if i == len(primes) {
+			return
+		}
+		p := primes[i]
+		for e := uint64(1); e <= maxExponent && n <= max/p; e++ {
+			n *= p
+			candidates = append(candidates, candidate{n, tau * (e + 1)})
+			generate(i+1, n, tau*(e+1), e)
+		}
+	}
+	generate(0, 1, 1, 64)
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].n < candidates[j].n })
+
+	i, record := 0, uint64(0)
+	return funcIterator(func() (uint64, bool) {
+		for ; i < len(candidates); i++ {
+			if c := candidates[i]; c.tau > record {
+				record = c.tau
+				i++
+				return c.n, true
+			}
+		}
+		return 0, false
+	})
+}
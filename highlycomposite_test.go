@@ -0,0 +1,43 @@
+package primes
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHighlyCompositeNumbers(t *testing.T) {
+	s := NewPrimeSet(1000)
+	testIterator(t, "HighlyCompositeNumbers(10080)", s.HighlyCompositeNumbers(10080), []uint64{1, 2, 4, 6, 12, 24, 36,
+		48, 60, 120, 180, 240, 360, 720, 840, 1260, 1680, 2520, 5040, 7560, 10080})
+	testIterator(t, "HighlyCompositeNumbers(0)", s.HighlyCompositeNumbers(0), nil)
+
+	// compare with the records of τ(n)
+	const max = 200000
+	it := s.HighlyCompositeNumbers(max)
+	record := uint64(0)
+	for n := uint64(1); n <= max; n++ {
+		tau := uint64(1)
+		for _, pp := range factorize(n) {
+			tau *= uint64(pp.Exponent) + 1
+		}
+		if tau > record {
+			record = tau
+			if h, ok := it.Next(); !ok || h != n {
+				t.Fatalf("next highly composite number is %d, %v instead of %d", h, ok, n)
+			}
+		}
+	}
+	if h, ok := it.Next(); ok {
+		t.Errorf("unexpected highly composite number %d", h)
+	}
+
+	// the largest highly composite number below 2^64 has 103680 divisors
+	var last uint64
+	for it, h, ok := s.HighlyCompositeNumbers(math.MaxUint64), uint64(0), true; ok; h, ok = it.Next() {
+		last = h
+	}
+	if last != 18401055938125660800 {
+		t.Errorf("largest highly composite number is %d", last)
+	}
+
+}
@@ -20,7 +20,7 @@ func (s *set) Iterator(start uint64) Iterator {
 	}
 	i := numberToIndex(start)
 	for {
-		n, found := nextSetBit(s.bits, i)
+		n, found := s.nextBit(i)
 		if !found {
 			// there is no next prime number in the set, so return an iterator that is already finished
 			return &iterator{s, 0, 0}
@@ -46,7 +46,7 @@ func (i *iterator) Next() (uint64, bool) {
 		return 2, true
 	}
 	r := i.nextPrime
-	n, found := nextSetBit(i.set.bits, i.nextIndex+1)
+	n, found := i.set.nextBit(i.nextIndex + 1)
 	i.nextIndex = n
 	if found {
 		i.nextPrime = indexToNumber(n)
@@ -55,3 +55,71 @@ func (i *iterator) Next() (uint64, bool) {
 	}
 	return r, true
 }
+
+// funcIterator adapts a function returning the next number and status to the Iterator interface.
+type funcIterator func() (uint64, bool)
+
+// Next returns the next number by calling the function.
+func (f funcIterator) Next() (uint64, bool) {
+	return f()
+}
+
+// Filter returns an iterator over all primes of the set from start on that fulfil the given predicate.
+func (s *set) Filter(start uint64, pred func(p uint64) bool) Iterator {
+	it := s.Iterator(start)
+	return funcIterator(func() (uint64, bool) {
+		for p, ok := it.Next(); ok; p, ok = it.Next() {
+			if pred(p) {
+				return p, true
+			}
+		}
+		return 0, false
+	})
+}
+
+// filterUpTo returns an iterator over all primes of the set in [start, max] that fulfil the given predicate. Filter
+// stops at the first prime beyond max instead of testing the rest of the set.
+func (s *set) filterUpTo(start, max uint64, pred func(p uint64) bool) Iterator {
+	return upTo(s.Filter(start, func(p uint64) bool { return p > max || pred(p) }), max)
+}
+
+// upTo returns an iterator over the numbers of an ascending iterator up to max.
+func upTo(it Iterator, max uint64) Iterator {
+	return funcIterator(func() (uint64, bool) {
+		if n, ok := it.Next(); ok && n <= max {
+			return n, true
+		}
+		return 0, false
+	})
+}
+
+// Composites returns an iterator over all composite numbers of the set from start on, i.e. all numbers from 4 up to
+// the largest number of the set that are not prime.
+func (s *set) Composites(start uint64) Iterator {
+	if start < 4 {
+		start = 4
+	}
+	n := start
+	return funcIterator(func() (uint64, bool) {
+		for ; n <= s.largestNumber && n >= start; n++ {
+			if !s.isPrime(n) {
+				n++
+				return n - 1, true
+			}
+		}
+		return 0, false
+	})
+}
+
+// Numbers returns an iterator over all numbers in [from, to], e.g. for exporting the factorizations of a range.
+func Numbers(from, to uint64) Iterator {
+	n, done := from, from > to
+	return funcIterator(func() (uint64, bool) {
+		if done {
+			return 0, false
+		}
+		done = n == to
+		n++
+		return n - 1, true
+	})
+}
@@ -0,0 +1,48 @@
+package primes
+
+import "testing"
+
+func TestFilter(t *testing.T) {
+	set := NewPrimeSet(100)
+	testIterator(t, "Filter(0, p%10 == 3)", set.Filter(0, func(p uint64) bool { return p%10 == 3 && p < 100 }),
+		[]uint64{3, 13, 23, 43, 53, 73, 83})
+	testIterator(t, "Filter(50, p%4 == 1)", set.Filter(50, func(p uint64) bool { return p%4 == 1 && p < 100 }),
+		[]uint64{53, 61, 73, 89, 97})
+	testIterator(t, "Filter(0, false)", set.Filter(0, func(uint64) bool { return false }), nil)
+}
+
+func TestComposites(t *testing.T) {
+	set := NewPrimeSet(100)
+	it := set.Composites(0)
+	for _, e := range []uint64{4, 6, 8, 9, 10, 12, 14, 15, 16, 18, 20, 21, 22, 24, 25} {
+		if n, ok := it.Next(); !ok || n != e {
+			t.Fatalf("Composites(0): expected %d, got %d", e, n)
+		}
+	}
+
+	// composites and primes from 90 on must cover the rest of the set exactly
+	expected := uint64(90)
+	it = set.Composites(90)
+	for n, ok := it.Next(); ok; n, ok = it.Next() {
+		for ; expected < n; expected++ {
+			if !set.IsPrime(expected) {
+				t.Fatalf("Composites(90) skipped %d", expected)
+			}
+		}
+		if set.IsPrime(n) {
+			t.Fatalf("Composites(90) returned the prime %d", n)
+		}
+		expected++
+	}
+	for ; expected <= set.LargestNumber(); expected++ {
+		if !set.IsPrime(expected) {
+			t.Fatalf("Composites(90) stopped before %d", expected)
+		}
+	}
+}
+
+func TestNumbers(t *testing.T) {
+	testIterator(t, "Numbers(3, 6)", Numbers(3, 6), []uint64{3, 4, 5, 6})
+	testIterator(t, "Numbers(6, 3)", Numbers(6, 3), nil)
+	testIterator(t, "Numbers(max-1, max)", Numbers(1<<64-2, 1<<64-1), []uint64{1<<64 - 2, 1<<64 - 1})
+}
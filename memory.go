@@ -0,0 +1,29 @@
+package primes
+
+// EstimateSetMemory returns the number of bytes NewPrimeSet will allocate for the prime bits of a set up to limit and,
+// with WithRankIndex, for its rank index. This is the same value as MemoryUsage of the resulting set.
+func EstimateSetMemory(limit uint64, opts ...Option) uint64 {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if limit < 5 {
+		limit = 5
+	}
+	words := uint64(setWords(limit))
+	if o.rankIndex {
+		words += (words+rankBlockWords-1)/rankBlockWords + 1
+	}
+	return words << 3
+}
+
+// EstimateFactorizerMemory returns the number of bytes a factorizer for numbers up to max will allocate for its
+// factor table, which uses 4 bytes per entry below 2^32 and 8 bytes otherwise. The prime set needed to build the
+// factorizer is not included.
+func EstimateFactorizerMemory(max uint64) uint64 {
+	entries := uint64(numberToIndex(max)) + 1
+	if max < 1<<32 {
+		return entries << 2
+	}
+	return entries << 3
+}
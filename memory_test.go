@@ -0,0 +1,28 @@
+package primes
+
+import "testing"
+
+func TestEstimateMemory(t *testing.T) {
+	for _, limit := range []uint64{5, 100, 191, 193, 1000, 1000000} {
+		set := NewPrimeSet(limit)
+		if m := EstimateSetMemory(limit); m != uint64(set.MemoryUsage()) {
+			t.Errorf("EstimateSetMemory(%d) = %d, but the set uses %d bytes", limit, m, set.MemoryUsage())
+		}
+		if m, u := EstimateSetMemory(limit, WithRankIndex()), NewPrimeSet(limit, WithRankIndex()).MemoryUsage(); m != uint64(u) {
+			t.Errorf("EstimateSetMemory(%d, WithRankIndex()) = %d, but the set uses %d bytes", limit, m, u)
+		}
+		if set.LargestNumber() < limit {
+			t.Errorf("set for limit %d only reaches up to %d", limit, set.LargestNumber())
+		}
+		f := set.Factorizer(limit).(*factorizer)
+		if m := EstimateFactorizerMemory(limit); m != uint64(f.factors.bytes()) {
+			t.Errorf("EstimateFactorizerMemory(%d) = %d, but the factorizer uses %d bytes", limit, m, f.factors.bytes())
+		}
+	}
+	if m := EstimateFactorizerMemory(1 << 32); m != (uint64(numberToIndex(1<<32))+1)<<3 {
+		t.Errorf("EstimateFactorizerMemory(2^32) = %d should use 8 bytes per entry", m)
+	}
+	if m := EstimateSetMemory(100000000); m < 4000000 || m > 4500000 {
+		t.Errorf("EstimateSetMemory(10^8) = %d is implausible", m)
+	}
+}
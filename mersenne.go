@@ -0,0 +1,43 @@
+package primes
+
+import "math/big"
+
+// IsMersennePrime returns true iff the Mersenne number 2^p - 1 is prime, using the Lucas-Lehmer test.
+// The test takes p - 2 squarings of p bit numbers, so it is only feasible for moderately sized exponents.
+func IsMersennePrime(p uint64) bool {
+	if p == 2 {
+		return true
+	}
+	if p < 2 || p&1 == 0 {
+		// 2^p - 1 is divisible by 3 for even p
+		return false
+	}
+	m := new(big.Int).Lsh(big.NewInt(1), uint(p))
+	m.Sub(m, big.NewInt(1))
+	s := big.NewInt(4)
+	hi := new(big.Int)
+	for i := uint64(0); i < p-2; i++ {
+		s.Mul(s, s)
+		s.Sub(s, big.NewInt(2))
+		if s.Sign() < 0 {
+			s.Add(s, m)
+		}
+		// reduce modulo 2^p - 1 by adding the upper p bits to the lower p bits
+		for s.Cmp(m) > 0 {
+			hi.Rsh(s, uint(p))
+			s.And(s, m)
+			s.Add(s, hi)
+		}
+		if s.Cmp(m) == 0 {
+			s.SetInt64(0)
+		}
+	}
+	return s.Sign() == 0
+}
+
+// MersenneExponents returns an iterator over all exponents p <= max for which 2^p - 1 is prime.
+// Since 2^p - 1 can only be prime for prime p, the candidates are taken from the set, so the iteration
+// also stops at the end of the set.
+func (s *set) MersenneExponents(max uint64) Iterator {
+	return s.filterUpTo(0, max, IsMersennePrime)
+}
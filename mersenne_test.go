@@ -0,0 +1,25 @@
+package primes
+
+import "testing"
+
+func TestIsMersennePrime(t *testing.T) {
+	for p, expected := range map[uint64]bool{0: false, 1: false, 2: true, 3: true, 4: false, 9: false, 11: false, 31: true, 521: true, 523: false} {
+		if IsMersennePrime(p) != expected {
+			t.Errorf("IsMersennePrime(%d) should be %t", p, expected)
+		}
+	}
+}
+
+func TestMersenneExponents(t *testing.T) {
+	set := NewPrimeSet(1000)
+	expected := []uint64{2, 3, 5, 7, 13, 17, 19, 31, 61, 89, 107, 127, 521, 607}
+	it := set.MersenneExponents(1000)
+	for _, e := range expected {
+		if p, ok := it.Next(); !ok || p != e {
+			t.Errorf("expected Mersenne exponent %d, got %d", e, p)
+		}
+	}
+	if p, ok := it.Next(); ok {
+		t.Errorf("unexpected Mersenne exponent %d", p)
+	}
+}
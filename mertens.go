@@ -0,0 +1,80 @@
+package primes
+
+import "math"
+
+// MobiusSieve returns the Möbius function μ(n) for all numbers n up to max, using a linear sieve. μ(0) is reported as 0.
+func MobiusSieve(max uint64) []int8 {
+	mu := make([]int8, max+1)
+	if max >= 1 {
+		mu[1] = 1
+	}
+	composite := make([]bool, max+1)
+	var primes []uint64
+	for n := uint64(2); n <= max; n++ {
+		if !composite[n] {
+			mu[n] = -1
+			primes = append(primes, n)
+		}
+		for _, p := range primes {
+			if p > max/n {
+				break
+			}
+			m := n * p
+			composite[m] = true
+			if n%p == 0 {
+				// m is divisible by p², so μ(m) stays 0
+				break
+			}
+			mu[m] = -mu[n]
+		}
+	}
+	return mu
+}
+
+// Mertens returns the Mertens function M(n), which is the sum of μ(k) for all k from 1 to n.
+// It sieves μ up to about n^(2/3) and derives the remaining values from the identity Σ M(n/d) = 1 over d = 1..n,
+// so that time and memory grow with n^(2/3) only.
+func Mertens(n uint64) int64 {
+	if n == 0 {
+		return 0
+	}
+	limit := uint64(math.Pow(float64(n), 2.0/3.0))
+	if limit < Sqrt(n) {
+		limit = Sqrt(n)
+	}
+	if limit > n {
+		limit = n
+	}
+
+	// partial sums up to limit, which fit into int32 since |M(x)| < sqrt(x) for all x in reach
+	mu := MobiusSieve(limit)
+	small := make([]int32, limit+1)
+	for x := uint64(1); x <= limit; x++ {
+		small[x] = small[x-1] + int32(mu[x])
+	}
+
+	// large[k] holds M(n/k) for all k with n/k > limit, computed from the largest k downward
+	kmax := n / (limit + 1)
+	large := make([]int64, kmax+1)
+	for k := kmax; k >= 1; k-- {
+		x := n / k
+		sum := int64(1)
+		for d := uint64(2); d <= x; {
+			q := x / d
+			last := x / q
+			var m int64
+			if q <= limit {
+				m = int64(small[q])
+			} else {
+				m = large[k*d] // n/(k*d) = q
+			}
+			sum -= int64(last-d+1) * m
+			d = last + 1
+		}
+		large[k] = sum
+	}
+	if kmax >= 1 {
+		return large[1]
+	}
+	return int64(small[n])
+}
@@ -0,0 +1,34 @@
+package primes
+
+import "testing"
+
+func TestMobiusSieve(t *testing.T) {
+	mu := MobiusSieve(10000)
+	for n := uint64(1); n <= 10000; n++ {
+		expected := int8(1)
+		for _, pp := range factorize(n) {
+			if pp.Exponent > 1 {
+				expected = 0
+				break
+			}
+			expected = -expected
+		}
+		if mu[n] != expected {
+			t.Errorf("μ(%d) = %d instead of %d", n, mu[n], expected)
+		}
+	}
+}
+
+func TestMertens(t *testing.T) {
+	for _, c := range []struct {
+		n uint64
+		m int64
+	}{
+		{0, 0}, {1, 1}, {2, 0}, {3, -1}, {10, -1}, {100, 1}, {1000, 2}, {10000, -23}, {100000, -48},
+		{1000000, 212}, {10000000, 1037}, {100000000, 1928}, {1000000000, -222},
+	} {
+		if m := Mertens(c.n); m != c.m {
+			t.Errorf("Mertens(%d) = %d instead of %d", c.n, m, c.m)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package primes
+
+import (
+	"expvar"
+	"time"
+)
+
+// Metrics receives usage and performance events from sets and factorizers. Implementations must be safe for
+// concurrent use, since a set may be shared by many goroutines.
+type Metrics interface {
+	IsPrimeCalled()                              // IsPrime was called on a set
+	Factorized()                                 // a number was factorized by a factorizer
+	CacheHit()                                   // a result was served from a cache
+	CacheMiss()                                  // a result was not found in a cache
+	SetBuilt(limit uint64, d time.Duration)      // a set was sieved up to limit in time d
+	FactorizerBuilt(max uint64, d time.Duration) // a factorizer was built up to max in time d
+}
+
+// Option configures the construction of a prime set.
+type Option func(*options)
+
+// options holds the configuration for the construction of a prime set.
+type options struct {
+	metrics            Metrics       // receiver for events of the set and its factorizers, or nil
+	checkpointPath     string        // file for checkpoints of the sieve, or empty
+	checkpointInterval time.Duration // minimum time between two checkpoints
+	rankIndex          bool          // true iff the set gets an index of cumulative popcounts
+}
+
+// WithMetrics reports the events of the set and its factorizers into m.
+func WithMetrics(m Metrics) Option {
+	return func(o *options) {
+		o.metrics = m
+	}
+}
+
+// expvarMetrics is the implementation of Metrics that publishes all events as expvar counters.
+type expvarMetrics struct {
+	isPrimeCalls, factorizations, cacheHits, cacheMisses *expvar.Int
+	setBuilds, setBuildNanos                             *expvar.Int
+	factorizerBuilds, factorizerBuildNanos               *expvar.Int
+}
+
+// NewExpvarMetrics returns Metrics that publish their counters in an expvar map with the given name, which is served
+// at /debug/vars by the expvar package. Like expvar.NewMap, it panics if the name is already in use.
+func NewExpvarMetrics(name string) Metrics {
+	m := expvar.NewMap(name)
+	counter := func(key string) *expvar.Int {
+		c := new(expvar.Int)
+		m.Set(key, c)
+		return c
+	}
+	return &expvarMetrics{
+		counter("isPrimeCalls"), counter("factorizations"), counter("cacheHits"), counter("cacheMisses"),
+		counter("setBuilds"), counter("setBuildNanos"),
+		counter("factorizerBuilds"), counter("factorizerBuildNanos"),
+	}
+}
+
+func (m *expvarMetrics) IsPrimeCalled() { m.isPrimeCalls.Add(1) }
+func (m *expvarMetrics) Factorized()    { m.factorizations.Add(1) }
+func (m *expvarMetrics) CacheHit()      { m.cacheHits.Add(1) }
+func (m *expvarMetrics) CacheMiss()     { m.cacheMisses.Add(1) }
+
+func (m *expvarMetrics) SetBuilt(limit uint64, d time.Duration) {
+	m.setBuilds.Add(1)
+	m.setBuildNanos.Add(int64(d))
+}
+
+func (m *expvarMetrics) FactorizerBuilt(max uint64, d time.Duration) {
+	m.factorizerBuilds.Add(1)
+	m.factorizerBuildNanos.Add(int64(d))
+}
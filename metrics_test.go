@@ -0,0 +1,46 @@
+package primes
+
+import (
+	"expvar"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingMetrics counts all events.
+type countingMetrics struct {
+	isPrimeCalls, factorizations, cacheHits, cacheMisses, setBuilds, factorizerBuilds int64
+}
+
+func (m *countingMetrics) IsPrimeCalled()                 { atomic.AddInt64(&m.isPrimeCalls, 1) }
+func (m *countingMetrics) Factorized()                    { atomic.AddInt64(&m.factorizations, 1) }
+func (m *countingMetrics) CacheHit()                      { atomic.AddInt64(&m.cacheHits, 1) }
+func (m *countingMetrics) CacheMiss()                     { atomic.AddInt64(&m.cacheMisses, 1) }
+func (m *countingMetrics) SetBuilt(uint64, time.Duration) { atomic.AddInt64(&m.setBuilds, 1) }
+func (m *countingMetrics) FactorizerBuilt(uint64, time.Duration) {
+	atomic.AddInt64(&m.factorizerBuilds, 1)
+}
+
+func TestMetrics(t *testing.T) {
+	m := new(countingMetrics)
+	set := NewPrimeSet(1000, WithMetrics(m))
+	set.IsPrime(7)
+	set.IsPrime(8)
+	f := set.Factorizer(1000)
+	f.Factorize(360)
+	if *m != (countingMetrics{isPrimeCalls: 2, factorizations: 1, setBuilds: 1, factorizerBuilds: 1}) {
+		t.Errorf("unexpected metrics %+v", *m)
+	}
+}
+
+func TestExpvarMetrics(t *testing.T) {
+	set := NewPrimeSet(1000, WithMetrics(NewExpvarMetrics("primes_test")))
+	set.IsPrime(7)
+	vars := expvar.Get("primes_test").(*expvar.Map)
+	if v := vars.Get("isPrimeCalls").String(); v != "1" {
+		t.Errorf("isPrimeCalls = %s instead of 1", v)
+	}
+	if v := vars.Get("setBuilds").String(); v != "1" {
+		t.Errorf("setBuilds = %s instead of 1", v)
+	}
+}
@@ -0,0 +1,105 @@
+package primes
+
+// millerRabinBases are the first twelve primes, which form a deterministic set of Miller-Rabin witnesses for all
+// numbers below 3.3 * 10^24 and thus for all uint64.
+var millerRabinBases = [...]uint64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37}
+
+// strongProbablePrime returns true iff the odd number m = d * 2^s + 1 is a strong probable prime to base a.
+// The base must already be converted to Montgomery form.
+func (mg montgomery) strongProbablePrime(a, d uint64, s uint) bool {
+	minusOne := mg.m - mg.one // -1 in Montgomery form
+	x := mg.pow(a, d)
+	if x == mg.one || x == minusOne {
+		return true
+	}
+	for i := uint(1); i < s; i++ {
+		x = mg.mul(x, x)
+		if x == minusOne {
+			return true
+		}
+		if x == mg.one {
+			return false
+		}
+	}
+	return false
+}
+
+// millerRabin determines deterministically whether n is prime. If n is composite, the second result is a base
+// that proves compositeness, i.e. a base that divides n or for which n is not a strong probable prime.
+// For n < 2, the second result is 0.
+func millerRabin(n uint64) (bool, uint64) {
+	if n < 2 {
+		return false, 0
+	}
+	for _, a := range millerRabinBases {
+		if n == a {
+			return true, 0
+		}
+		if n%a == 0 {
+			return false, a
+		}
+	}
+	s := numberOfTrailingZeroes(n - 1)
+	d := (n - 1) >> s
+	mg := newMontgomery(n)
+	for _, a := range millerRabinBases {
+		if !mg.strongProbablePrime(mg.to(a), d, s) {
+			return false, a
+		}
+	}
+	return true, 0
+}
+
+// IsPrimeUint32 returns true iff n is prime, without building a set. It uses trial division by the primes below 32,
+// a strong test to base 2 that rejects almost all remaining composites, and the bases 7 and 61, which together with 2
+// are deterministic for all n < 4759123141.
+func IsPrimeUint32(n uint32) bool {
+	if n < 32 {
+		return uint32(0xa08a28ac)>>n&1 != 0 // bit mask of the primes below 32
+	}
+	for _, p := range [...]uint32{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31} {
+		if n%p == 0 {
+			return false
+		}
+	}
+	if n < 37*37 {
+		return true
+	}
+	m := uint64(n)
+	s := numberOfTrailingZeroes(m - 1)
+	d := (m - 1) >> s
+	for _, a := range [...]uint64{2, 7, 61} {
+		if a >= m {
+			break
+		}
+		if !strongProbablePrime32(a, d, s, m) {
+			return false
+		}
+	}
+	return true
+}
+
+// strongProbablePrime32 returns true iff the odd number m = d * 2^s + 1 < 2^32 is a strong probable prime to base a.
+// Since m < 2^32, all products fit into an uint64.
+func strongProbablePrime32(a, d uint64, s uint, m uint64) bool {
+	x := uint64(1)
+	for ; d != 0; d >>= 1 {
+		if d&1 != 0 {
+			x = x * a % m
+		}
+		a = a * a % m
+	}
+	if x == 1 || x == m-1 {
+		return true
+	}
+	for i := uint(1); i < s; i++ {
+		x = x * x % m
+		if x == m-1 {
+			return true
+		}
+		if x == 1 {
+			return false
+		}
+	}
+	return false
+}
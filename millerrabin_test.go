@@ -0,0 +1,37 @@
+package primes
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestIsPrimeUint32(t *testing.T) {
+	set := NewPrimeSet(1000000)
+	for n := uint64(0); n <= 1000000; n++ {
+		if IsPrimeUint32(uint32(n)) != set.IsPrime(n) {
+			t.Fatalf("IsPrimeUint32(%d) = %t", n, !set.IsPrime(n))
+		}
+	}
+	rnd := rand.New(rand.NewSource(4))
+	for i := 0; i < 100000; i++ {
+		n := rnd.Uint32() | 1
+		if expected, _ := millerRabin(uint64(n)); IsPrimeUint32(n) != expected {
+			t.Fatalf("IsPrimeUint32(%d) = %t", n, !expected)
+		}
+	}
+	// strong pseudoprimes to several small bases and 2^32 - 1
+	for _, n := range []uint32{2047, 1373653, 25326001, 3215031751, 4294967295} {
+		if IsPrimeUint32(n) {
+			t.Errorf("IsPrimeUint32(%d) should be false", n)
+		}
+	}
+	if !IsPrimeUint32(4294967291) {
+		t.Error("IsPrimeUint32(4294967291) should be true")
+	}
+}
+
+func BenchmarkIsPrimeUint32(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		IsPrimeUint32(uint32(i) | 1)
+	}
+}
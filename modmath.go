@@ -0,0 +1,165 @@
+package primes
+
+import "math/bits"
+
+// montgomery holds the precalculated constants for Montgomery arithmetic modulo an odd number m, using R = 2^64.
+type montgomery struct {
+	m   uint64 // odd modulus
+	neg uint64 // -m^-1 mod 2^64
+	r2  uint64 // R^2 mod m, used to convert numbers into Montgomery form
+	one uint64 // R mod m, i.e. 1 in Montgomery form
+}
+
+// newMontgomery creates the Montgomery constants for the given odd modulus m.
+func newMontgomery(m uint64) montgomery {
+	// Newton iteration for m^-1 mod 2^64, doubling the number of correct bits in every step (m*m = 1 mod 8)
+	inv := m
+	for i := 0; i < 5; i++ {
+		inv *= 2 - m*inv
+	}
+	one := -m % m
+	return montgomery{m, -inv, MulMod(one, one, m), one}
+}
+
+// reduce returns x / R mod m for the 128 bit number x = hi * 2^64 + lo with hi < m.
+func (mg montgomery) reduce(hi, lo uint64) uint64 {
+	q := lo * mg.neg
+	qh, ql := bits.Mul64(q, mg.m)
+	_, carry := bits.Add64(lo, ql, 0)
+	t, carry := bits.Add64(hi, qh, carry)
+	if carry != 0 || t >= mg.m {
+		t -= mg.m
+	}
+	return t
+}
+
+// mul returns the Montgomery product a * b / R mod m of two numbers in Montgomery form.
+func (mg montgomery) mul(a, b uint64) uint64 {
+	return mg.reduce(bits.Mul64(a, b))
+}
+
+// to converts a into Montgomery form.
+func (mg montgomery) to(a uint64) uint64 {
+	return mg.mul(a%mg.m, mg.r2)
+}
+
+// from converts a from Montgomery form back into a normal number.
+func (mg montgomery) from(a uint64) uint64 {
+	return mg.reduce(0, a)
+}
+
+// pow returns a^e for a number a in Montgomery form; the result is in Montgomery form as well.
+func (mg montgomery) pow(a, e uint64) uint64 {
+	r := mg.one
+	for e != 0 {
+		if e&1 != 0 {
+			r = mg.mul(r, a)
+		}
+		a = mg.mul(a, a)
+		e >>= 1
+	}
+	return r
+}
+
+// MulMod returns a * b mod m using a 128 bit intermediate product. MulMod panics if m is 0.
+func MulMod(a, b, m uint64) uint64 {
+	if m == 0 {
+		panic("modulus must not be zero")
+	}
+	hi, lo := bits.Mul64(a%m, b%m)
+	_, r := bits.Div64(hi, lo, m)
+	return r
+}
+
+// PowMod returns a^e mod m. Odd moduli are handled with Montgomery multiplication, which avoids all divisions in the
+// exponentiation loop; even moduli fall back to 128 bit products reduced by division. PowMod panics if m is 0.
+func PowMod(a, e, m uint64) uint64 {
+	if m == 0 {
+		panic("modulus must not be zero")
+	}
+	if m == 1 {
+		return 0
+	}
+	if m&1 != 0 {
+		mg := newMontgomery(m)
+		return mg.from(mg.pow(mg.to(a), e))
+	}
+	r := uint64(1)
+	a %= m
+	for e != 0 {
+		if e&1 != 0 {
+			r = MulMod(r, a, m)
+		}
+		a = MulMod(a, a, m)
+		e >>= 1
+	}
+	return r
+}
+
+// addMod returns a + b mod n for a, b < n.
+func addMod(a, b, n uint64) uint64 {
+	s := a + b
+	if s < a || s >= n {
+		s -= n
+	}
+	return s
+}
+
+// subMod returns a - b mod n for a, b < n.
+func subMod(a, b, n uint64) uint64 {
+	if a >= b {
+		return a - b
+	}
+	return a - b + n
+}
+
+// ModInverse returns the inverse x of a modulo m with a * x = 1 mod m, using the extended Euclidean algorithm.
+// If a and m are not coprime, there is no inverse and the second result is false. ModInverse panics if m is 0.
+func ModInverse(a, m uint64) (uint64, bool) {
+	if m == 0 {
+		panic("modulus must not be zero")
+	}
+	// invariant: r0 = t0 * a and r1 = t1 * a modulo m
+	r0, r1 := m, a%m
+	t0, t1 := uint64(0), uint64(1)%m
+	for r1 != 0 {
+		q := r0 / r1
+		r0, r1 = r1, r0-q*r1
+		t0, t1 = t1, subMod(t0, MulMod(q, t1, m), m)
+	}
+	if r0 != 1 {
+		return 0, false
+	}
+	return t0, true
+}
+
+// CRT solves the system of congruences x = residues[i] mod moduli[i] with the Chinese remainder theorem. The moduli
+// need not be coprime. It returns the smallest solution x and the least common multiple of the moduli, modulo which
+// the solution is unique. If the system has no solution or the least common multiple exceeds an uint64, the third
+// result is false. CRT panics if the slices differ in length or a modulus is 0.
+func CRT(residues, moduli []uint64) (uint64, uint64, bool) {
+	if len(residues) != len(moduli) {
+		panic("number of residues and moduli must be equal")
+	}
+	x, lcm := uint64(0), uint64(1)
+	for i, m := range moduli {
+		if m == 0 {
+			panic("modulus must not be zero")
+		}
+		// find t with x + lcm * t = r mod m, i.e. lcm/g * t = (r - x)/g mod m/g
+		g := gcd(lcm, m)
+		diff := subMod(residues[i]%m, x%m, m)
+		if diff%g != 0 {
+			return 0, 0, false
+		}
+		mg := m / g
+		if lcm/g > ^uint64(0)/m {
+			return 0, 0, false
+		}
+		inv, _ := ModInverse(lcm/g, mg)
+		t := MulMod(diff/g, inv, mg)
+		x += lcm * t // x < lcm and t < m/g, so this does not exceed the new lcm
+		lcm = lcm / g * m
+	}
+	return x, lcm, true
+}
@@ -0,0 +1,83 @@
+package primes
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestPowMod(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	moduli := []uint64{1, 2, 3, 10, 1000000007, 1 << 63, 1<<63 + 1, 0xffffffffffffffff, 0xffffffffffffffc5}
+	for i := 0; i < 1000; i++ {
+		moduli = append(moduli, rnd.Uint64()|1, rnd.Uint64()&^1|2, rnd.Uint64()>>uint(rnd.Intn(63))|1)
+	}
+	for _, m := range moduli {
+		a, e := rnd.Uint64(), rnd.Uint64()
+		expected := new(big.Int).Exp(new(big.Int).SetUint64(a), new(big.Int).SetUint64(e), new(big.Int).SetUint64(m)).Uint64()
+		if r := PowMod(a, e, m); r != expected {
+			t.Errorf("PowMod(%d, %d, %d) = %d instead of %d", a, e, m, r, expected)
+		}
+	}
+	if r := PowMod(12345, 0, 7); r != 1 {
+		t.Errorf("PowMod(12345, 0, 7) = %d instead of 1", r)
+	}
+}
+
+func BenchmarkPowMod(b *testing.B) {
+	m := uint64(0xffffffffffffffc5)
+	for i := 0; i < b.N; i++ {
+		PowMod(uint64(i), m-1, m)
+	}
+}
+
+func TestMulMod(t *testing.T) {
+	rnd := rand.New(rand.NewSource(2))
+	for i := 0; i < 1000; i++ {
+		a, b, m := rnd.Uint64(), rnd.Uint64(), rnd.Uint64()>>uint(rnd.Intn(63))|1
+		expected := new(big.Int).Mul(new(big.Int).SetUint64(a), new(big.Int).SetUint64(b))
+		expected.Mod(expected, new(big.Int).SetUint64(m))
+		if r := MulMod(a, b, m); r != expected.Uint64() {
+			t.Errorf("MulMod(%d, %d, %d) = %d instead of %d", a, b, m, r, expected)
+		}
+	}
+}
+
+func TestModInverse(t *testing.T) {
+	rnd := rand.New(rand.NewSource(3))
+	for i := 0; i < 1000; i++ {
+		a, m := rnd.Uint64(), rnd.Uint64()>>uint(rnd.Intn(63))|1
+		expected := new(big.Int).ModInverse(new(big.Int).SetUint64(a), new(big.Int).SetUint64(m))
+		x, ok := ModInverse(a, m)
+		if ok != (expected != nil) || ok && x != expected.Uint64() {
+			t.Errorf("ModInverse(%d, %d) = %d, %t instead of %v", a, m, x, ok, expected)
+		}
+	}
+	if x, ok := ModInverse(5, 1); !ok || x != 0 {
+		t.Errorf("ModInverse(5, 1) = %d, %t", x, ok)
+	}
+	if _, ok := ModInverse(6, 9); ok {
+		t.Error("ModInverse(6, 9) should not exist")
+	}
+}
+
+func TestCRT(t *testing.T) {
+	for _, c := range []struct {
+		residues, moduli []uint64
+		x, lcm           uint64
+		ok               bool
+	}{
+		{nil, nil, 0, 1, true},
+		{[]uint64{2, 3, 2}, []uint64{3, 5, 7}, 23, 105, true},
+		{[]uint64{3, 5}, []uint64{4, 6}, 11, 12, true},
+		{[]uint64{3, 4}, []uint64{4, 6}, 0, 0, false},
+		{[]uint64{10, 20}, []uint64{7, 11}, 31, 77, true},
+		{[]uint64{1, 2}, []uint64{4294967291, 4294967279}, 1537228665292936541, 18446743979220271189, true},
+		{[]uint64{0, 0}, []uint64{1 << 33, 1<<32 + 15}, 0, 0, false},
+	} {
+		x, lcm, ok := CRT(c.residues, c.moduli)
+		if x != c.x || lcm != c.lcm || ok != c.ok {
+			t.Errorf("CRT(%v, %v) = %d, %d, %t instead of %d, %d, %t", c.residues, c.moduli, x, lcm, ok, c.x, c.lcm, c.ok)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package primes
+
+import "fmt"
+
+// maxSetWords is the maximum number of words of a prime set, which fills the 48 bit address space of current
+// 64 bit architectures.
+const maxSetWords uint64 = 1 << 45
+
+// TryNewPrimeSet creates a new set of prime numbers up to a given limit like NewPrimeSet, but returns an error
+// instead of panicking if the limit is too small or too large to be allocated.
+func TryNewPrimeSet(limit uint64, opts ...Option) (Set, error) {
+	if limit < 5 {
+		return nil, fmt.Errorf("%w: prime set limit %d is below the minimum of 5", ErrLimitTooSmall, limit)
+	}
+	if uint64(setWords(limit)) > maxSetWords {
+		return nil, fmt.Errorf("%w: prime set limit %d exceeds the addressable memory", ErrOutOfRange, limit)
+	}
+	return NewPrimeSet(limit, opts...), nil
+}
+
+// MustNewPrimeSet creates a new set of prime numbers up to a given limit like TryNewPrimeSet and panics with its error if
+// that fails, so the sentinel can still be recovered with errors.Is. It is intended for tests and examples.
+func MustNewPrimeSet(limit uint64, opts ...Option) Set {
+	s, err := TryNewPrimeSet(limit, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// TryFactorizer returns a new factorizer of the set for numbers up to max like Set.Factorizer, but returns an error
+// if the set does not reach up to max, since the factorizer table would be incomplete then.
+func TryFactorizer(s Set, max uint64, opts ...FactorizerOption) (Factorizer, error) {
+	if limit := s.LargestNumber(); max > limit {
+		return nil, fmt.Errorf("%w: factorizer maximum %d exceeds the prime set reaching up to %d", ErrOutOfRange, max, limit)
+	}
+	return s.Factorizer(max, opts...), nil
+}
+
+// MustFactorizer returns a new factorizer of the set for numbers up to max like TryFactorizer and panics with its
+// error if that fails. It is intended for tests and examples.
+func MustFactorizer(s Set, max uint64, opts ...FactorizerOption) Factorizer {
+	f, err := TryFactorizer(s, max, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
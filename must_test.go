@@ -0,0 +1,36 @@
+package primes
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTryConstructors(t *testing.T) {
+	if _, err := TryNewPrimeSet(4); !errors.Is(err, ErrLimitTooSmall) {
+		t.Error("TryNewPrimeSet(4) should fail")
+	}
+	if _, err := TryNewPrimeSet(1<<64 - 1); !errors.Is(err, ErrOutOfRange) {
+		t.Error("TryNewPrimeSet(2^64 - 1) should fail")
+	}
+	set, err := TryNewPrimeSet(1000)
+	if err != nil || set.LargestNumber() < 1000 {
+		t.Fatalf("TryNewPrimeSet(1000) = %v", err)
+	}
+	if _, err := TryFactorizer(set, 2000); !errors.Is(err, ErrOutOfRange) {
+		t.Error("TryFactorizer() beyond the set should fail")
+	}
+	if f, err := TryFactorizer(set, 1000); err != nil || f == nil {
+		t.Errorf("TryFactorizer(1000) = %v", err)
+	}
+}
+
+func TestMustConstructors(t *testing.T) {
+	set := MustNewPrimeSet(1000)
+	MustFactorizer(set, 1000)
+	defer func() {
+		if err, ok := recover().(error); !ok || !errors.Is(err, ErrLimitTooSmall) {
+			t.Errorf("MustNewPrimeSet(1) panics with %v", err)
+		}
+	}()
+	MustNewPrimeSet(1)
+}
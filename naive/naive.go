@@ -0,0 +1,64 @@
+/*
+Package naive provides straightforward reference implementations of the basic prime number functions. They use plain
+trial division and are far too slow for production use, but their correctness is obvious, so they are suitable as
+oracles in property-based tests of optimized code, e.g. of the prime sets and factorizers of package primes.
+
+Example usage:
+
+	set := primes.NewPrimeSet(100000)
+	for n := uint64(0); n <= 100000; n++ {
+		if set.IsPrime(n) != naive.IsPrime(n) {
+			t.Errorf("IsPrime(%d) is wrong", n)
+		}
+	}
+*/
+package naive
+
+import "github.com/docwalter/primes"
+
+// IsPrime returns true iff n is a prime number, testing all divisors up to sqrt(n).
+func IsPrime(n uint64) bool {
+	if n < 2 {
+		return false
+	}
+	for d := uint64(2); d <= n/d; d++ {
+		if n%d == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Factorize returns the prime factorization of n in ascending order of the prime factors, dividing by all numbers
+// from 2 on. For n = 0, the second result is false.
+func Factorize(n uint64) ([]primes.PrimePower, bool) {
+	if n == 0 {
+		return nil, false
+	}
+	var factors []primes.PrimePower
+	for d := uint64(2); d <= n/d; d++ {
+		e := uint(0)
+		for n%d == 0 {
+			n /= d
+			e++
+		}
+		if e > 0 {
+			factors = append(factors, primes.PrimePower{Prime: d, Exponent: e})
+		}
+	}
+	if n > 1 {
+		factors = append(factors, primes.PrimePower{Prime: n, Exponent: 1})
+	}
+	return factors, true
+}
+
+// Pi returns the number of primes up to n, testing all numbers with IsPrime.
+func Pi(n uint64) uint64 {
+	count := uint64(0)
+	for k := uint64(2); k <= n && k != 0; k++ {
+		if IsPrime(k) {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,53 @@
+package naive
+
+import (
+	"testing"
+
+	"github.com/docwalter/primes"
+)
+
+func TestAgainstSet(t *testing.T) {
+	const max = 20000
+	set := primes.NewPrimeSet(max)
+	f := set.Factorizer(max)
+	count := uint64(0)
+	for n := uint64(0); n <= max; n++ {
+		if set.IsPrime(n) != IsPrime(n) {
+			t.Fatalf("IsPrime(%d) differs", n)
+		}
+		if set.IsPrime(n) {
+			count++
+		}
+		expected, eok := f.Factorize(n)
+		actual, ok := Factorize(n)
+		if ok != eok || len(actual) != len(expected) {
+			t.Fatalf("Factorize(%d) = %v, %v instead of %v, %v", n, actual, ok, expected, eok)
+		}
+		for i := range actual {
+			if actual[i] != expected[i] {
+				t.Fatalf("Factorize(%d) = %v instead of %v", n, actual, expected)
+			}
+		}
+	}
+	if pi := Pi(max); pi != count {
+		t.Errorf("Pi(%d) = %d instead of %d", uint64(max), pi, count)
+	}
+}
+
+func TestSmallValues(t *testing.T) {
+	for _, c := range []struct {
+		n     uint64
+		prime bool
+		pi    uint64
+	}{{0, false, 0}, {1, false, 0}, {2, true, 1}, {3, true, 2}, {4, false, 2}, {100, false, 25}, {4294967291, true, 0}} {
+		if IsPrime(c.n) != c.prime || c.n < 1000 && Pi(c.n) != c.pi {
+			t.Errorf("IsPrime(%d) = %v, Pi(%d) = %d", c.n, IsPrime(c.n), c.n, Pi(c.n))
+		}
+	}
+	if factors, ok := Factorize(1); !ok || len(factors) != 0 {
+		t.Errorf("Factorize(1) = %v, %v", factors, ok)
+	}
+	if factors, ok := Factorize(1<<64 - 1); !ok || len(factors) != 7 {
+		t.Errorf("Factorize(2^64 - 1) = %v, %v", factors, ok)
+	}
+}
@@ -0,0 +1,45 @@
+package primes
+
+// DistinctFactorCount returns the number of distinct prime factors of a given number, also known as ω(n).
+// If the factorizer boundaries are exceeded or n is 0, the second result is false.
+func (f *factorizer) DistinctFactorCount(n uint64) (uint, bool) {
+	factors, ok := f.Factorize(n)
+	return uint(len(factors)), ok
+}
+
+// TotalFactorCount returns the number of prime factors of a given number counted with multiplicity, also known as Ω(n).
+// If the factorizer boundaries are exceeded or n is 0, the second result is false.
+func (f *factorizer) TotalFactorCount(n uint64) (uint, bool) {
+	factors, ok := f.Factorize(n)
+	count := uint(0)
+	for _, pp := range factors {
+		count += pp.Exponent
+	}
+	return count, ok
+}
+
+// DistinctFactorCounts returns ω(n) for all numbers n up to the largest number of the factorizer.
+// The count for 0 is reported as 0.
+func (f *factorizer) DistinctFactorCounts() []uint8 {
+	counts := make([]uint8, f.largestNumber+1)
+	for n := uint64(2); n <= f.largestNumber; n++ {
+		p, _ := f.LargestFactorOf(n)
+		m := n / p
+		counts[n] = counts[m]
+		if m%p != 0 {
+			counts[n]++
+		}
+	}
+	return counts
+}
+
+// TotalFactorCounts returns Ω(n) for all numbers n up to the largest number of the factorizer.
+// The count for 0 is reported as 0.
+func (f *factorizer) TotalFactorCounts() []uint8 {
+	counts := make([]uint8, f.largestNumber+1)
+	for n := uint64(2); n <= f.largestNumber; n++ {
+		p, _ := f.LargestFactorOf(n)
+		counts[n] = counts[n/p] + 1
+	}
+	return counts
+}
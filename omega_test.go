@@ -0,0 +1,28 @@
+package primes
+
+import "testing"
+
+func TestFactorCounts(t *testing.T) {
+	f := NewPrimeSet(10000).Factorizer(10000)
+	distinct := f.DistinctFactorCounts()
+	total := f.TotalFactorCounts()
+	if len(distinct) != 10001 || len(total) != 10001 {
+		t.Fatalf("unexpected lengths %d and %d", len(distinct), len(total))
+	}
+	for n := uint64(1); n <= 10000; n++ {
+		factors := factorize(n)
+		e := uint(0)
+		for _, pp := range factors {
+			e += pp.Exponent
+		}
+		if w, ok := f.DistinctFactorCount(n); !ok || w != uint(len(factors)) || distinct[n] != uint8(w) {
+			t.Errorf("ω(%d) = %d/%d instead of %d", n, w, distinct[n], len(factors))
+		}
+		if o, ok := f.TotalFactorCount(n); !ok || o != e || total[n] != uint8(o) {
+			t.Errorf("Ω(%d) = %d/%d instead of %d", n, o, total[n], e)
+		}
+	}
+	if _, ok := f.TotalFactorCount(0); ok {
+		t.Error("Ω(0) should fail")
+	}
+}
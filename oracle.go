@@ -0,0 +1,108 @@
+package primes
+
+// Oracle answers the basic primality questions. It is a minimal alternative to Set for code that only needs
+// primality, e.g. libraries that want to accept any source of primality information or tests that inject fakes.
+type Oracle interface {
+	IsPrime(n uint64) bool                // true iff n is prime
+	NextPrime(n uint64) (uint64, bool)    // smallest prime larger than n
+	Factor(n uint64) ([]PrimePower, bool) // prime factorization of n > 0 in ascending order
+}
+
+// setOracle is the implementation of Oracle backed by a Set.
+type setOracle struct {
+	set Set // underlying prime set
+}
+
+// NewSetOracle returns an Oracle that answers from the given set where possible and falls back to Miller-Rabin tests
+// and Pollard's rho method beyond its boundaries.
+func NewSetOracle(s Set) Oracle {
+	return &setOracle{s}
+}
+
+// IsPrime returns true iff n is a prime number.
+func (o *setOracle) IsPrime(n uint64) bool {
+	if n <= o.set.LargestNumber() {
+		return o.set.IsPrime(n)
+	}
+	prime, _ := millerRabin(n)
+	return prime
+}
+
+// NextPrime returns the smallest prime larger than n. If there is no such prime within uint64, the second result is false.
+func (o *setOracle) NextPrime(n uint64) (uint64, bool) {
+	if n < o.set.LargestPrime() {
+		return o.set.Iterator(n + 1).Next()
+	}
+	return nextPrimeMillerRabin(n)
+}
+
+// Factor returns the prime factorization of n in ascending order of the prime factors, using trial division by the
+// primes of the set first. For n = 0, the second result is false.
+func (o *setOracle) Factor(n uint64) ([]PrimePower, bool) {
+	if n == 0 {
+		return nil, false
+	}
+	var factors []PrimePower
+	it := o.set.Iterator(0)
+	p, ok := it.Next()
+	for ok && p <= n/p {
+		if n%p == 0 {
+			e := uint(0)
+			for n%p == 0 {
+				n /= p
+				e++
+			}
+			factors = append(factors, PrimePower{p, e})
+		}
+		p, ok = it.Next()
+	}
+	if ok || n <= o.set.LargestNumber() {
+		// all prime factors up to sqrt(n) are divided out, so the rest is 1 or prime
+		if n > 1 {
+			factors = append(factors, PrimePower{n, 1})
+		}
+		return factors, true
+	}
+	return appendLargeFactors(factors, n), true
+}
+
+// millerRabinOracle is the implementation of Oracle that needs no precalculated data at all.
+type millerRabinOracle struct{}
+
+// NewMillerRabinOracle returns an Oracle that uses deterministic Miller-Rabin tests and Pollard's rho method only.
+func NewMillerRabinOracle() Oracle {
+	return millerRabinOracle{}
+}
+
+// IsPrime returns true iff n is a prime number.
+func (millerRabinOracle) IsPrime(n uint64) bool {
+	prime, _ := millerRabin(n)
+	return prime
+}
+
+// NextPrime returns the smallest prime larger than n. If there is no such prime within uint64, the second result is false.
+func (millerRabinOracle) NextPrime(n uint64) (uint64, bool) {
+	return nextPrimeMillerRabin(n)
+}
+
+// Factor returns the prime factorization of n in ascending order of the prime factors. For n = 0, the second result is false.
+func (millerRabinOracle) Factor(n uint64) ([]PrimePower, bool) {
+	if n == 0 {
+		return nil, false
+	}
+	return factorize(n), true
+}
+
+// nextPrimeMillerRabin returns the smallest prime larger than n, testing the candidates with Miller-Rabin.
+// If there is no such prime within uint64, the second result is false.
+func nextPrimeMillerRabin(n uint64) (uint64, bool) {
+	if n < 2 {
+		return 2, true
+	}
+	for c := (n + 1) | 1; c > n; c += 2 {
+		if prime, _ := millerRabin(c); prime {
+			return c, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,51 @@
+package primes
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestOracles(t *testing.T) {
+	set := NewPrimeSet(10000)
+	oracles := []Oracle{NewSetOracle(set), NewMillerRabinOracle()}
+	rnd := rand.New(rand.NewSource(1))
+	numbers := []uint64{1, 2, 3, 4, 1 << 63, 999999999989 * 1000003, 4294967291 * 4294967279, 18446744073709551557}
+	for n := uint64(1); n < 2000; n++ {
+		numbers = append(numbers, n, set.LargestNumber()+n, rnd.Uint64()>>uint(rnd.Intn(64))+1)
+	}
+	for _, o := range oracles {
+		for _, n := range numbers {
+			factors, ok := o.Factor(n)
+			if !ok {
+				t.Fatalf("%T failed to factor %d", o, n)
+			}
+			product, last := uint64(1), uint64(0)
+			for _, f := range factors {
+				if f.Prime <= last || !o.IsPrime(f.Prime) || f.Exponent == 0 {
+					t.Fatalf("%T returned invalid factorization %v of %d", o, factors, n)
+				}
+				for i := uint(0); i < f.Exponent; i++ {
+					product *= f.Prime
+				}
+				last = f.Prime
+			}
+			if product != n {
+				t.Errorf("%T returned factorization %v of %d", o, factors, n)
+			}
+			if (len(factors) == 1 && factors[0].Exponent == 1) != o.IsPrime(n) {
+				t.Errorf("%T: IsPrime(%d) contradicts factorization %v", o, n, factors)
+			}
+		}
+		if _, ok := o.Factor(0); ok {
+			t.Errorf("%T should not factor 0", o)
+		}
+		for _, c := range [][2]uint64{{0, 2}, {2, 3}, {9973, 10007}, {set.LargestPrime() - 1, set.LargestPrime()}, {18446744073709551556, 18446744073709551557}} {
+			if p, ok := o.NextPrime(c[0]); !ok || p != c[1] {
+				t.Errorf("%T: NextPrime(%d) = %d instead of %d", o, c[0], p, c[1])
+			}
+		}
+		if p, ok := o.NextPrime(18446744073709551557); ok {
+			t.Errorf("%T: NextPrime returned %d beyond the largest uint64 prime", o, p)
+		}
+	}
+}
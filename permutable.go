@@ -0,0 +1,72 @@
+package primes
+
+import (
+	"slices"
+	"strconv"
+)
+
+// IsPermutablePrime returns true iff all permutations of the decimal digits of n are prime, e.g. 113, 131 and 311.
+// Permutations beyond the set are tested with Miller-Rabin, or with a probabilistic test if they exceed an uint64.
+func (s *set) IsPermutablePrime(n uint64) bool {
+	if !s.isPrimeExtended(n) {
+		return false
+	}
+	digits := []byte(strconv.FormatUint(n, 10))
+	if len(digits) == 1 {
+		return true
+	}
+	for _, d := range digits {
+		if d != '1' && d != '3' && d != '7' && d != '9' {
+			// some permutation ends with an even digit or 5
+			return false
+		}
+	}
+	slices.Sort(digits)
+	for {
+		if !s.isDecimalPrime(string(digits)) {
+			return false
+		}
+		if !nextPermutation(digits) {
+			return true
+		}
+	}
+}
+
+// PermutablePrimes returns an iterator over all permutable primes in the set up to max.
+func (s *set) PermutablePrimes(max uint64) Iterator {
+	return s.filterUpTo(0, max, s.IsPermutablePrime)
+}
+
+// PermutationClasses returns the primes of the set in [lo, hi] grouped by their decimal digits, so that each group
+// holds the primes that are permutations of each other, in ascending order. The keys are the sorted digits, e.g.
+// "1487" for 1487, 4817 and 8147. Since a permutation keeps the number of digits, 107 and 701 are in class "017",
+// but 17 and 71 are in class "17".
+func (s *set) PermutationClasses(lo, hi uint64) map[string][]uint64 {
+	classes := make(map[string][]uint64)
+	it := s.Iterator(lo)
+	for p, ok := it.Next(); ok && p <= hi; p, ok = it.Next() {
+		digits := []byte(strconv.FormatUint(p, 10))
+		slices.Sort(digits)
+		classes[string(digits)] = append(classes[string(digits)], p)
+	}
+	return classes
+}
+
+// nextPermutation rearranges digits into the lexicographically next permutation and returns true, or returns false if
+// digits is the last permutation already.
+func nextPermutation(digits []byte) bool {
+	i := len(digits) - 2
+	for i >= 0 && digits[i] >= digits[i+1] {
+		i--
+	}
+	if i < 0 {
+		return false
+	}
+	j := len(digits) - 1
+	for digits[j] <= digits[i] {
+		j--
+	}
+	digits[i], digits[j] = digits[j], digits[i]
+	slices.Reverse(digits[i+1:])
+	return true
+}
@@ -0,0 +1,35 @@
+package primes
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPermutablePrimes(t *testing.T) {
+	s := NewPrimeSet(1000000)
+	testIterator(t, "PermutablePrimes(1000000)", s.PermutablePrimes(1000000), []uint64{2, 3, 5, 7, 11, 13, 17, 31, 37,
+		71, 73, 79, 97, 113, 131, 199, 311, 337, 373, 733, 919, 991})
+	if !s.IsPermutablePrime(1111111111111111111) {
+		t.Error("repunit of length 19 is not permutable")
+	}
+	if s.IsPermutablePrime(1) || s.IsPermutablePrime(1117) {
+		t.Error("1 and 1117 are permutable")
+	}
+}
+
+func TestPermutationClasses(t *testing.T) {
+	classes := NewPrimeSet(10000).PermutationClasses(1000, 9999)
+	if c := classes["1478"]; !slices.Contains(c, 1487) || !slices.Contains(c, 4817) || !slices.Contains(c, 8147) {
+		t.Errorf("class 1478 is %v", c)
+	}
+	if c := classes["0179"]; !slices.Equal(c, []uint64{1097, 1709, 1907, 7019, 7109, 7901}) {
+		t.Errorf("class 0179 is %v", c)
+	}
+	count := 0
+	for _, c := range classes {
+		count += len(c)
+	}
+	if count != 1061 {
+		t.Errorf("%d primes with four digits", count)
+	}
+}
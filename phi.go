@@ -0,0 +1,76 @@
+package primes
+
+import "sort"
+
+// PhiSieve returns Euler's totient φ(n) for all numbers n up to max, using a linear sieve which finds the primes along
+// the way. φ(0) is reported as 0.
+func PhiSieve(max uint64) []uint64 {
+	phi := make([]uint64, max+1)
+	if max >= 1 {
+		phi[1] = 1
+	}
+	var primes []uint64
+	for n := uint64(2); n <= max; n++ {
+		if phi[n] == 0 {
+			// n has not been reached as a multiple of a smaller number, so it is prime
+			phi[n] = n - 1
+			primes = append(primes, n)
+		}
+		for _, p := range primes {
+			if p > max/n {
+				break
+			}
+			m := n * p
+			if n%p == 0 {
+				// p is the smallest prime factor of n, so m contains p once more than n
+				phi[m] = phi[n] * p
+				break
+			}
+			phi[m] = phi[n] * (p - 1)
+		}
+	}
+	return phi
+}
+
+// InversePhi returns all numbers n up to max with φ(n) = m in ascending order. Every prime factor p of such an n
+// satisfies p-1 | m, so n is built recursively from these primes and their powers, dividing m by φ(p^e) on the way.
+// The number of solutions is finite, and max allows for bounding them below 2^64. If m cannot be factorized, e.g. 0
+// or a number beyond the factorizer boundaries without a fallback, the second result is false.
+func (f *factorizer) InversePhi(m, max uint64) ([]uint64, bool) {
+	divisors, ok := f.Divisors(m)
+	if !ok {
+		return nil, false
+	}
+	var primes []uint64 // primes p with p-1 | m in ascending order
+	for _, d := range divisors {
+		if d+1 != 0 && f.set.isPrimeExtended(d+1) {
+			primes = append(primes, d+1)
+		}
+	}
+
+	var solutions []uint64
+	var build func(m, n uint64, first int)
+	build = func(m, n uint64, first int) {
+		if m == 1 {
+			solutions = append(solutions, n)
+		}
+		for i := first; i < len(primes) && primes[i]-1 <= m; i++ {
+			p := primes[i]
+			if m%(p-1) != 0 || n > max/p {
+				continue
+			}
+			// n·p^e for all e with p^(e-1) | m/(p-1)
+			for m, n := m/(p-1), n*p; ; m, n = m/p, n*p {
+				build(m, n, i+1)
+				if m%p != 0 || n > max/p {
+					break
+				}
+			}
+		}
+	}
+	if max >= 1 {
+		build(m, 1, 0)
+	}
+	sort.Slice(solutions, func(i, j int) bool { return solutions[i] < solutions[j] })
+	return solutions, true
+}
@@ -0,0 +1,60 @@
+package primes
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestPhiSieve(t *testing.T) {
+	const max = 10000
+	phi := PhiSieve(max)
+	if len(phi) != max+1 || phi[0] != 0 || phi[1] != 1 {
+		t.Fatalf("unexpected start of sieve %v", phi[:2])
+	}
+	for n := uint64(2); n <= max; n++ {
+		expected := n
+		for _, pp := range factorize(n) {
+			expected = expected / pp.Prime * (pp.Prime - 1)
+		}
+		if phi[n] != expected {
+			t.Errorf("φ(%d) = %d instead of %d", n, phi[n], expected)
+		}
+	}
+	if phi := PhiSieve(0); len(phi) != 1 || phi[0] != 0 {
+		t.Errorf("PhiSieve(0) = %v", phi)
+	}
+}
+
+func TestInversePhi(t *testing.T) {
+	const max = 20000
+	f := NewPrimeSet(max).Factorizer(max)
+	phi := PhiSieve(max)
+	for m := uint64(1); m <= 1000; m++ {
+		var expected []uint64
+		for n := uint64(1); n <= max; n++ {
+			if phi[n] == m {
+				expected = append(expected, n)
+			}
+		}
+		if solutions, ok := f.InversePhi(m, max); !ok || !slices.Equal(solutions, expected) {
+			t.Fatalf("InversePhi(%d) = %v, %v instead of %v", m, solutions, ok, expected)
+		}
+	}
+	expected := []uint64{73, 91, 95, 111, 117, 135, 146, 148}
+	if solutions, ok := f.InversePhi(72, 150); !ok || !slices.Equal(solutions, expected) {
+		t.Errorf("InversePhi(72) up to 150 = %v, %v", solutions, ok)
+	}
+	for _, m := range []uint64{0, 1<<40 + 1} {
+		if solutions, ok := f.InversePhi(m, max); ok {
+			t.Errorf("InversePhi(%d) = %v", m, solutions)
+		}
+	}
+
+	// the modulus of an RSA key with the known totient is among the solutions
+	f = NewPrimeSet(1000).Factorizer(1000, WithFallback())
+	solutions, ok := f.InversePhi(1000034000064, math.MaxUint64)
+	if !ok || !slices.Contains(solutions, 1000036000099) {
+		t.Errorf("InversePhi of an RSA totient = %v, %v", solutions, ok)
+	}
+}
@@ -0,0 +1,264 @@
+package primes
+
+import (
+	"math/big"
+	"math/bits"
+	"sort"
+)
+
+// PrimePower128 is a prime number of up to 128 bits raised to a positive exponent.
+type PrimePower128 struct {
+	Prime    Uint128 // prime factor
+	Exponent uint    // multiplicity of the prime factor
+}
+
+// millerRabin128Bound is the smallest strong pseudoprime to all of millerRabinBases, i.e. the bound below which
+// the Miller-Rabin test with these bases is deterministic.
+var millerRabin128Bound, _ = Uint128FromBig(fromDecimal("318665857834031151167461"))
+
+// IsPrime128 returns true iff n is a prime number. Below 3.18 * 10^23, it uses a deterministic Miller-Rabin test with
+// 128 bit Montgomery multiplication; beyond, it also runs the Baillie-PSW test of math/big, for which no
+// counterexample is known.
+func IsPrime128(n Uint128) bool {
+	if n.Hi == 0 {
+		prime, _ := millerRabin(n.Lo)
+		return prime
+	}
+	for _, p := range millerRabinBases {
+		if n.mod64(p) == 0 {
+			return false
+		}
+	}
+	if !millerRabin128(n) {
+		return false
+	}
+	return n.Cmp(millerRabin128Bound) < 0 || n.Big().ProbablyPrime(0)
+}
+
+// millerRabin128 returns true iff n > 2^64, which has no prime factors up to 37, is a strong probable prime to all
+// of millerRabinBases.
+func millerRabin128(n Uint128) bool {
+	mg := newMontgomery128(n)
+	nm1 := n.sub64(1)
+	s := nm1.trailingZeros()
+	d := nm1.rsh(s)
+	minusOne := n.sub(mg.one)
+	for _, a := range millerRabinBases {
+		x := mg.pow(mg.to(Uint128{0, a}), d)
+		if x == mg.one || x == minusOne {
+			continue
+		}
+		composite := true
+		for i := uint(1); i < s && composite; i++ {
+			x = mg.mul(x, x)
+			if x == minusOne {
+				composite = false
+			}
+		}
+		if composite {
+			return false
+		}
+	}
+	return true
+}
+
+// Factorize128 returns the prime factorization of n in ascending order of the prime factors. Small factors are
+// removed by trial division, the rest is split with Pollard-Brent rho using 128 bit Montgomery multiplication, which
+// takes time proportional to the square root of the second largest prime factor. So it is practical as long as that
+// factor has at most about 16 digits. For n = 0, the second result is false.
+func Factorize128(n Uint128) ([]PrimePower128, bool) {
+	if n == (Uint128{}) {
+		return nil, false
+	}
+	var primes []Uint128
+	for _, p := range millerRabinBases {
+		for n.mod64(p) == 0 {
+			n = n.div64(p)
+			primes = append(primes, Uint128{0, p})
+		}
+	}
+	var split func(m Uint128)
+	split = func(m Uint128) {
+		switch {
+		case m.Hi == 0:
+			for _, pp := range appendLargeFactors(nil, m.Lo) {
+				for e := uint(0); e < pp.Exponent; e++ {
+					primes = append(primes, Uint128{0, pp.Prime})
+				}
+			}
+		case IsPrime128(m):
+			primes = append(primes, m)
+		default:
+			d := findFactor128(m)
+			split(d)
+			q, _ := Uint128FromBig(new(big.Int).Quo(m.Big(), d.Big()))
+			split(q)
+		}
+	}
+	split(n)
+
+	sort.Slice(primes, func(i, j int) bool { return primes[i].Cmp(primes[j]) < 0 })
+	var factors []PrimePower128
+	for _, p := range primes {
+		if k := len(factors) - 1; k >= 0 && factors[k].Prime == p {
+			factors[k].Exponent++
+		} else {
+			factors = append(factors, PrimePower128{p, 1})
+		}
+	}
+	return factors, true
+}
+
+// findFactor128 returns a nontrivial factor of the odd composite number n > 2^64.
+func findFactor128(n Uint128) Uint128 {
+	if r := new(big.Int).Sqrt(n.Big()); new(big.Int).Mul(r, r).Cmp(n.Big()) == 0 {
+		root, _ := Uint128FromBig(r)
+		return root
+	}
+	for c := uint64(1); ; c++ {
+		if d := pollardBrent128(n, c); d != n {
+			return d
+		}
+	}
+}
+
+// pollardBrent128 tries to find a factor of the odd composite number n with Brent's variant of Pollard's rho method,
+// using the polynomial x^2 + c. If it fails, n is returned.
+func pollardBrent128(n Uint128, c uint64) Uint128 {
+	const batch = 128 // number of steps between two gcd calculations
+	mg := newMontgomery128(n)
+	mc := mg.to(Uint128{0, c})
+	f := func(x Uint128) Uint128 {
+		return mg.add(mg.mul(x, x), mc)
+	}
+	one := Uint128{0, 1}
+	y, x, ys := mg.to(Uint128{0, 2}), Uint128{}, Uint128{}
+	g, q := one, mg.one
+	for r := 1; g == one; r <<= 1 {
+		x = y
+		for i := 0; i < r; i++ {
+			y = f(y)
+		}
+		for k := 0; k < r && g == one; k += batch {
+			ys = y
+			for i := 0; i < batch && i < r-k; i++ {
+				y = f(y)
+				q = mg.mul(q, absDiff128(x, y))
+			}
+			g = gcd128(q, n)
+		}
+	}
+	if g == n {
+		// the batch overshot, so repeat its steps one at a time
+		for g = one; g == one; {
+			ys = f(ys)
+			g = gcd128(absDiff128(x, ys), n)
+		}
+	}
+	return g
+}
+
+// absDiff128 returns |a - b|.
+func absDiff128(a, b Uint128) Uint128 {
+	if a.Cmp(b) > 0 {
+		return a.sub(b)
+	}
+	return b.sub(a)
+}
+
+// gcd128 returns the greatest common divisor of a and b using the binary algorithm.
+func gcd128(a, b Uint128) Uint128 {
+	zero := Uint128{}
+	if a == zero {
+		return b
+	}
+	if b == zero {
+		return a
+	}
+	shift := min(a.trailingZeros(), b.trailingZeros())
+	a = a.rsh(a.trailingZeros())
+	for b != zero {
+		b = b.rsh(b.trailingZeros())
+		if a.Cmp(b) > 0 {
+			a, b = b, a
+		}
+		b = b.sub(a)
+	}
+	return a.lsh(shift)
+}
+
+// montgomery128 holds the constants for Montgomery multiplication modulo an odd 128 bit number with R = 2^128.
+type montgomery128 struct {
+	m   Uint128 // odd modulus
+	neg Uint128 // -m^-1 mod 2^128
+	r2  Uint128 // R^2 mod m, used to convert numbers into Montgomery form
+	one Uint128 // R mod m, i.e. 1 in Montgomery form
+}
+
+// newMontgomery128 creates the Montgomery constants for the given odd modulus m.
+func newMontgomery128(m Uint128) montgomery128 {
+	// Newton iteration for m^-1 mod 2^128, doubling the number of correct bits in every step (m*m = 1 mod 8)
+	inv := m
+	for i := 0; i < 6; i++ {
+		inv = inv.mul(Uint128{0, 2}.sub(m.mul(inv)))
+	}
+	mb := m.Big()
+	r := new(big.Int).Lsh(big.NewInt(1), 128)
+	one, _ := Uint128FromBig(new(big.Int).Mod(r, mb))
+	r2, _ := Uint128FromBig(new(big.Int).Mod(new(big.Int).Mul(r, r), mb))
+	return montgomery128{m, Uint128{}.sub(inv), r2, one}
+}
+
+// reduce returns t / R mod m for the 256 bit number t < m * R, given in four words starting with the least
+// significant one.
+func (mg montgomery128) reduce(t [4]uint64) Uint128 {
+	q := Uint128{t[1], t[0]}.mul(mg.neg)
+	qm := q.mulFull(mg.m)
+	_, c := bits.Add64(t[0], qm[0], 0)
+	_, c = bits.Add64(t[1], qm[1], c)
+	lo, c := bits.Add64(t[2], qm[2], c)
+	hi, c := bits.Add64(t[3], qm[3], c)
+	r := Uint128{hi, lo}
+	if c != 0 || r.Cmp(mg.m) >= 0 {
+		r = r.sub(mg.m)
+	}
+	return r
+}
+
+// mul returns the Montgomery product a * b / R mod m of two numbers in Montgomery form.
+func (mg montgomery128) mul(a, b Uint128) Uint128 {
+	return mg.reduce(a.mulFull(b))
+}
+
+// add returns a + b mod m for a, b < m.
+func (mg montgomery128) add(a, b Uint128) Uint128 {
+	s := a.add(b)
+	if s.Cmp(a) < 0 || s.Cmp(mg.m) >= 0 {
+		s = s.sub(mg.m)
+	}
+	return s
+}
+
+// to converts a < m into Montgomery form.
+func (mg montgomery128) to(a Uint128) Uint128 {
+	return mg.mul(a, mg.r2)
+}
+
+// pow returns a^e for a in Montgomery form.
+func (mg montgomery128) pow(a, e Uint128) Uint128 {
+	r := mg.one
+	for e != (Uint128{}) {
+		if e.Lo&1 != 0 {
+			r = mg.mul(r, a)
+		}
+		a = mg.mul(a, a)
+		e = e.rsh(1)
+	}
+	return r
+}
+
+// fromDecimal parses a decimal number, which must be valid.
+func fromDecimal(s string) *big.Int {
+	n, _ := new(big.Int).SetString(s, 10)
+	return n
+}
@@ -0,0 +1,122 @@
+package primes
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestIsPrime128(t *testing.T) {
+	for _, c := range []struct {
+		n     string
+		prime bool
+	}{
+		{"0", false},
+		{"2", true},
+		{"18446744073709551557", true},                     // largest 64 bit prime
+		{"18446744073709551629", true},                     // 2^64 + 13
+		{"18446744073709551631", false},                    // 2^64 + 15
+		{"340282366920938463463374607431768211455", false}, // 2^128 - 1
+		{"170141183460469231731687303715884105727", true},  // 2^127 - 1
+		{"318665857834031151167461", false},                // strong pseudoprime to the first twelve primes
+		{"3317044064679887385961981", false},               // strong pseudoprime to the first thirteen primes
+	} {
+		n, _ := Uint128FromBig(fromDecimal(c.n))
+		if IsPrime128(n) != c.prime {
+			t.Errorf("IsPrime128(%s) = %v", c.n, !c.prime)
+		}
+	}
+	// compare with math/big around 2^100
+	n, _ := Uint128FromBig(new(big.Int).Lsh(big.NewInt(1), 100))
+	for i := 0; i < 2000; i++ {
+		if IsPrime128(n) != n.Big().ProbablyPrime(20) {
+			t.Fatalf("IsPrime128(%v) is wrong", n)
+		}
+		n = n.add(Uint128{0, 1})
+	}
+}
+
+func TestFactorize128(t *testing.T) {
+	factors, ok := Factorize128(Uint128{^uint64(0), ^uint64(0)})
+	expected := []uint64{3, 5, 17, 257, 641, 65537, 274177, 6700417, 67280421310721}
+	if !ok || len(factors) != len(expected) {
+		t.Fatalf("Factorize128(2^128 - 1) = %v, %v", factors, ok)
+	}
+	for i, pp := range factors {
+		if pp.Prime != (Uint128{0, expected[i]}) || pp.Exponent != 1 {
+			t.Errorf("Factorize128(2^128 - 1) has factor %v^%d", pp.Prime, pp.Exponent)
+		}
+	}
+
+	for _, c := range []struct {
+		bits  []uint // sizes of the prime factors
+		power uint   // exponent of the first prime factor
+	}{
+		{[]uint{30, 33, 34}, 2}, // four factors, all of them below 64 bits
+		{[]uint{40, 80}, 1},     // a factor beyond 64 bits
+	} {
+		product := big.NewInt(1)
+		var primes []*big.Int
+		for i, b := range c.bits {
+			p := nextBigPrime(new(big.Int).Lsh(big.NewInt(1), b))
+			primes = append(primes, p)
+			product.Mul(product, p)
+			if i == 0 {
+				for e := uint(1); e < c.power; e++ {
+					product.Mul(product, p)
+				}
+			}
+		}
+		n, _ := Uint128FromBig(product)
+		factors, ok = Factorize128(n)
+		if !ok || len(factors) != len(primes) || factors[0].Exponent != c.power {
+			t.Errorf("Factorize128(%v) = %v, %v", n, factors, ok)
+			continue
+		}
+		for i, pp := range factors {
+			if pp.Prime.Big().Cmp(primes[i]) != 0 {
+				t.Errorf("Factorize128(%v) = %v", n, factors)
+			}
+		}
+	}
+
+	if factors, ok := Factorize128(Uint128{0, 1}); !ok || len(factors) != 0 {
+		t.Errorf("Factorize128(1) = %v, %v", factors, ok)
+	}
+	if _, ok := Factorize128(Uint128{}); ok {
+		t.Error("Factorize128(0) should fail")
+	}
+}
+
+func TestUint128(t *testing.T) {
+	x, ok := Uint128FromBig(fromDecimal("340282366920938463463374607431768211455"))
+	if !ok || x != (Uint128{^uint64(0), ^uint64(0)}) || x.String() != "340282366920938463463374607431768211455" {
+		t.Errorf("Uint128FromBig(2^128 - 1) = %v, %v", x, ok)
+	}
+	if _, ok := Uint128FromBig(new(big.Int).Lsh(big.NewInt(1), 128)); ok {
+		t.Error("Uint128FromBig(2^128) should fail")
+	}
+	if _, ok := Uint128FromBig(big.NewInt(-1)); ok {
+		t.Error("Uint128FromBig(-1) should fail")
+	}
+	if (Uint128{1, 0}).Cmp(Uint128{0, ^uint64(0)}) != 1 || (Uint128{0, 5}).Cmp(Uint128{0, 5}) != 0 {
+		t.Error("Cmp() is wrong")
+	}
+	a, b := Uint128{0x123456789abcdef0, 0xfedcba9876543210}, Uint128{0x0fedcba987654321, 0x1122334455667788}
+	full := a.mulFull(b)
+	product := new(big.Int).Mul(a.Big(), b.Big())
+	for i := 0; i < 4; i++ {
+		word := new(big.Int).Rsh(product, uint(64*i))
+		if word.And(word, new(big.Int).SetUint64(^uint64(0))).Uint64() != full[i] {
+			t.Errorf("mulFull() word %d is wrong", i)
+		}
+	}
+}
+
+// nextBigPrime returns the smallest prime larger than n.
+func nextBigPrime(n *big.Int) *big.Int {
+	p := new(big.Int).Add(n, big.NewInt(1))
+	for !p.ProbablyPrime(20) {
+		p.Add(p, big.NewInt(1))
+	}
+	return p
+}
@@ -0,0 +1,52 @@
+package primes
+
+// FindPrimeAP returns the first term a >= start and the difference d > 0 of an arithmetic progression of length
+// primes a, a+d, ..., a+(length-1)·d within the set, e.g. 7 and 30 for length 6. The progression with the smallest
+// first term is returned, and among these the one with the smallest difference. Each candidate is checked by striding
+// through the prime bits, and for a > length, d only runs through the multiples of the primes up to length, as
+// otherwise one of the terms would be divisible by such a prime. If there is no such progression within the set, the
+// last result is false. FindPrimeAP panics if length < 2.
+func (s *set) FindPrimeAP(length int, start uint64) (uint64, uint64, bool) {
+	return s.findPrimeAP(length, start, s.largestNumber)
+}
+
+// findPrimeAP returns the first arithmetic progression of length primes like FindPrimeAP, whose terms lie in
+// [start, end]. It panics if length < 2.
+func (s *set) findPrimeAP(length int, start, end uint64) (uint64, uint64, bool) {
+	if length < 2 {
+		panic("arithmetic progression must have at least two terms")
+	}
+	k := uint64(length)
+	step := uint64(2) // product of the primes up to length
+	for q := uint64(3); q <= k && step <= s.largestNumber; q += 2 {
+		if s.isPrime(q) {
+			step *= q
+		}
+	}
+
+	it := s.Iterator(start)
+	for a, ok := it.Next(); ok; a, ok = it.Next() {
+		if a > end || end-a < k-1 {
+			break
+		}
+		maxDiff := (end - a) / (k - 1)
+		d, stride := uint64(2), uint64(2)
+		switch {
+		case a == 2:
+			// all other terms are odd, so only 2, 3 is possible
+			d, stride = 1, maxDiff+1
+		case a > k:
+			d, stride = step, step
+		}
+		for ; d <= maxDiff; d += stride {
+			i := uint64(1)
+			for i < k && s.isPrime(a+i*d) {
+				i++
+			}
+			if i == k {
+				return a, d, true
+			}
+		}
+	}
+	return 0, 0, false
+}
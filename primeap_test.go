@@ -0,0 +1,28 @@
+package primes
+
+import "testing"
+
+func TestFindPrimeAP(t *testing.T) {
+	s := NewPrimeSet(200000)
+	for _, test := range []struct {
+		length int
+		start  uint64
+		a, d   uint64
+	}{
+		{2, 0, 2, 1}, {3, 0, 3, 2}, {4, 0, 5, 6}, {5, 0, 5, 6}, {6, 0, 7, 30}, {7, 0, 7, 150}, {8, 0, 17, 6930},
+		{9, 0, 17, 6930}, {6, 100, 101, 3990}, {3, 1000, 1009, 12},
+	} {
+		if a, d, ok := s.FindPrimeAP(test.length, test.start); !ok || a != test.a || d != test.d {
+			t.Errorf("FindPrimeAP(%d, %d) = %d, %d, %v instead of %d, %d", test.length, test.start, a, d, ok, test.a, test.d)
+		}
+	}
+	if a, d, ok := NewPrimeSet(1000).FindPrimeAP(9, 0); ok {
+		t.Errorf("FindPrimeAP(9, 0) within 1000 = %d, %d", a, d)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("FindPrimeAP(1, 0) does not panic")
+		}
+	}()
+	s.FindPrimeAP(1, 0)
+}
@@ -0,0 +1,115 @@
+package primes
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Format is a binary format of a list of primes, written by WritePrimes and read by ReadPrimes. All formats are raw,
+// i.e. without a header, so they can be exchanged with other tools.
+type Format int
+
+const (
+	FormatVarint Format = iota // first prime and then the differences between consecutive primes as unsigned varints
+	FormatUint32               // 4 bytes per prime in little endian byte order
+	FormatUint64               // 8 bytes per prime in little endian byte order, like the binary output of primesieve
+)
+
+// String returns the name of the format.
+func (f Format) String() string {
+	switch f {
+	case FormatVarint:
+		return "varint"
+	case FormatUint32:
+		return "uint32"
+	case FormatUint64:
+		return "uint64"
+	}
+	return fmt.Sprintf("Format(%d)", int(f))
+}
+
+// WritePrimes writes all primes of the iterator in the given format to w. The primes must be in ascending order, as
+// returned by all iterators of this package. FormatUint32 fails for primes beyond 32 bits. WritePrimes panics if
+// the format is unknown.
+func WritePrimes(w io.Writer, it Iterator, format Format) error {
+	if format < FormatVarint || format > FormatUint64 {
+		panic("unknown prime list format")
+	}
+	bw := bufio.NewWriter(w)
+	var buf [binary.MaxVarintLen64]byte
+	var last uint64
+	for p, ok := it.Next(); ok; p, ok = it.Next() {
+		if p <= last && last != 0 {
+			return fmt.Errorf("primes: %d is not in ascending order after %d", p, last)
+		}
+		var n int
+		switch format {
+		case FormatVarint:
+			n = binary.PutUvarint(buf[:], p-last)
+		case FormatUint32:
+			if p > math.MaxUint32 {
+				return fmt.Errorf("%w: %d does not fit into %v format", ErrOverflow, p, format)
+			}
+			binary.LittleEndian.PutUint32(buf[:], uint32(p))
+			n = 4
+		case FormatUint64:
+			binary.LittleEndian.PutUint64(buf[:], p)
+			n = 8
+		}
+		if _, err := bw.Write(buf[:n]); err != nil {
+			return err
+		}
+		last = p
+	}
+	return bw.Flush()
+}
+
+// ReadPrimes reads a list of primes in the given format from r until its end. Since the formats have no header, the
+// format must be known by the caller. The numbers must be in ascending order, otherwise an error wrapping ErrCorrupt
+// is returned, but they are not checked for primality.
+// ReadPrimes panics if the format is unknown.
+func ReadPrimes(r io.Reader, format Format) ([]uint64, error) {
+	if format < FormatVarint || format > FormatUint64 {
+		panic("unknown prime list format")
+	}
+	br := bufio.NewReader(r)
+	var primes []uint64
+	var buf [8]byte
+	var last uint64
+	for {
+		var p uint64
+		var err error
+		switch format {
+		case FormatVarint:
+			var d uint64
+			if d, err = binary.ReadUvarint(br); err == nil {
+				if d == 0 && last != 0 || d > math.MaxUint64-last {
+					return primes, fmt.Errorf("%w: invalid difference %d in prime list", ErrCorrupt, d)
+				}
+				p = last + d
+			}
+		case FormatUint32:
+			if _, err = io.ReadFull(br, buf[:4]); err == nil {
+				p = uint64(binary.LittleEndian.Uint32(buf[:]))
+			}
+		case FormatUint64:
+			if _, err = io.ReadFull(br, buf[:]); err == nil {
+				p = binary.LittleEndian.Uint64(buf[:])
+			}
+		}
+		if err == io.EOF {
+			return primes, nil
+		}
+		if err != nil {
+			return primes, err
+		}
+		if p <= last && last != 0 {
+			return primes, fmt.Errorf("%w: %d is not in ascending order after %d", ErrCorrupt, p, last)
+		}
+		primes = append(primes, p)
+		last = p
+	}
+}
@@ -0,0 +1,59 @@
+package primes
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+)
+
+func TestWriteReadPrimes(t *testing.T) {
+	set := NewPrimeSet(100000)
+	var expected []uint64
+	it := set.Iterator(0)
+	for p, ok := it.Next(); ok; p, ok = it.Next() {
+		expected = append(expected, p)
+	}
+	n := len(expected)
+	sizes := map[Format]int{FormatVarint: n, FormatUint32: 4 * n, FormatUint64: 8 * n} // all gaps are below 128
+	for format, size := range sizes {
+		var buf bytes.Buffer
+		if err := WritePrimes(&buf, set.Iterator(0), format); err != nil {
+			t.Fatalf("WritePrimes(%v) fails: %v", format, err)
+		}
+		if buf.Len() != size {
+			t.Errorf("WritePrimes(%v) writes %d bytes instead of %d", format, buf.Len(), size)
+		}
+		data := buf.Bytes()
+		primes, err := ReadPrimes(bytes.NewReader(data), format)
+		if err != nil || !slices.Equal(primes, expected) {
+			t.Errorf("ReadPrimes(%v) = %d primes, %v", format, len(primes), err)
+		}
+		if _, err := ReadPrimes(bytes.NewReader(data[:len(data)-1]), format); format != FormatVarint && err != io.ErrUnexpectedEOF {
+			t.Errorf("ReadPrimes(%v) of truncated data = %v", format, err)
+		}
+	}
+}
+
+func TestWritePrimesErrors(t *testing.T) {
+	big := funcIterator(func() (uint64, bool) { return 1<<32 + 15, true })
+	if err := WritePrimes(io.Discard, big, FormatUint32); !errors.Is(err, ErrOverflow) {
+		t.Error("WritePrimes() should reject primes beyond 32 bits")
+	}
+	descending := []uint64{7, 5}
+	it := funcIterator(func() (uint64, bool) {
+		if len(descending) == 0 {
+			return 0, false
+		}
+		p := descending[0]
+		descending = descending[1:]
+		return p, true
+	})
+	if err := WritePrimes(io.Discard, it, FormatUint64); err == nil {
+		t.Error("WritePrimes() should reject primes in descending order")
+	}
+	if _, err := ReadPrimes(bytes.NewReader([]byte{7, 0}), FormatVarint); !errors.Is(err, ErrCorrupt) {
+		t.Error("ReadPrimes() should reject duplicate primes")
+	}
+}
@@ -0,0 +1,55 @@
+package primes
+
+import "sort"
+
+// IsPrimePower returns the prime p and the exponent k >= 1 with p^k = n, if n is a prime power.
+// The base p is checked against the set, or with Miller-Rabin beyond it.
+func (s *set) IsPrimePower(n uint64) (uint64, uint, bool) {
+	if n < 2 {
+		return 0, 0, false
+	}
+	// the exponent is at most log2(n)
+	for k := 64 - numberOfLeadingZeroes(n) - 1; k >= 1; k-- {
+		r := Root(n, k)
+		if powExceeds(r, k, n-1) && s.isPrimeExtended(r) {
+			// r^k > n - 1 and r^k <= n, so r^k = n
+			return r, k, true
+		}
+	}
+	return 0, 0, false
+}
+
+// PrimePowers returns an iterator over all prime powers p^k with k >= 1 from start up to the largest number of the
+// set in ascending order. This includes the primes themselves.
+func (s *set) PrimePowers(start uint64) Iterator {
+	// the higher powers are rare, so they are collected in advance and merged with the primes
+	var powers []uint64
+	it := s.Iterator(0)
+	for p, ok := it.Next(); ok && p <= s.largestNumber/p; p, ok = it.Next() {
+		for q := p * p; ; q *= p {
+			if q >= start {
+				powers = append(powers, q)
+			}
+			if q > s.largestNumber/p {
+				break
+			}
+		}
+	}
+	sort.Slice(powers, func(i, j int) bool { return powers[i] < powers[j] })
+
+	primes := s.Iterator(start)
+	p, ok := primes.Next()
+	return funcIterator(func() (uint64, bool) {
+		if len(powers) > 0 && (!ok || powers[0] < p) {
+			q := powers[0]
+			powers = powers[1:]
+			return q, true
+		}
+		if !ok {
+			return 0, false
+		}
+		r := p
+		p, ok = primes.Next()
+		return r, true
+	})
+}
@@ -0,0 +1,51 @@
+package primes
+
+import "testing"
+
+func TestIsPrimePower(t *testing.T) {
+	set := NewPrimeSet(1000)
+	for _, c := range []struct {
+		n, p uint64
+		k    uint
+	}{
+		{2, 2, 1}, {4, 2, 2}, {997, 997, 1}, {1024, 2, 10}, {1 << 63, 2, 63}, {3486784401, 3, 20},
+		{18446744073709551557, 18446744073709551557, 1}, {4294967291 * 4294967291, 4294967291, 2},
+		{12157665459056928801, 3, 40},
+	} {
+		if p, k, ok := set.IsPrimePower(c.n); !ok || p != c.p || k != c.k {
+			t.Errorf("IsPrimePower(%d) = %d^%d, %t instead of %d^%d", c.n, p, k, ok, c.p, c.k)
+		}
+	}
+	for _, n := range []uint64{0, 1, 6, 36, 1000, 18446744073709551615} {
+		if p, k, ok := set.IsPrimePower(n); ok {
+			t.Errorf("IsPrimePower(%d) = %d^%d", n, p, k)
+		}
+	}
+}
+
+func TestPrimePowers(t *testing.T) {
+	set := NewPrimeSet(100)
+	it := set.PrimePowers(0)
+	for _, e := range []uint64{2, 3, 4, 5, 7, 8, 9, 11, 13, 16, 17, 19, 23, 25, 27, 29, 31, 32, 37} {
+		if n, ok := it.Next(); !ok || n != e {
+			t.Fatalf("PrimePowers(0): expected %d, got %d", e, n)
+		}
+	}
+	it = set.PrimePowers(50)
+	count := 0
+	for n, ok := it.Next(); ok; n, ok = it.Next() {
+		if _, _, pp := set.IsPrimePower(n); !pp || n < 50 || n > set.LargestNumber() {
+			t.Errorf("PrimePowers(50) returned %d", n)
+		}
+		count++
+	}
+	expected := 0
+	for n := uint64(50); n <= set.LargestNumber(); n++ {
+		if _, _, pp := set.IsPrimePower(n); pp {
+			expected++
+		}
+	}
+	if count != expected {
+		t.Errorf("PrimePowers(50) returned %d prime powers instead of %d", count, expected)
+	}
+}
@@ -22,48 +22,152 @@ Example usage:
 */
 package primes
 
-import "math"
+import (
+	"context"
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/docwalter/primes/bitset"
+)
 
 // Set is a set of prime numbers.
 type Set interface {
-	IsPrime(n uint64) bool                    // true iff n is prime
-	Iterator(start uint64) Iterator           // allows for traversing the set
-	Factorizer(max uint64) Factorizer         // allows for quick factorization of numbers
-	LargestNumber() uint64                    // largest number in the set
-	LargestPrime() uint64                     // largest prime number in the set
-	MemoryUsage() uint                        // number of bytes used for the prime bits
-	SmallestFactorOf(n uint64) (uint64, bool) // smallest prime factor of a given number
+	IsPrime(n uint64) bool                                                                           // true iff n is prime
+	Explain(n uint64) (Verdict, uint64)                                                              // primality of n with a factor or witness for composites
+	Iterator(start uint64) Iterator                                                                  // allows for traversing the set
+	Factorizer(max uint64, opts ...FactorizerOption) Factorizer                                      // allows for quick factorization of numbers
+	FactorizerContext(ctx context.Context, max uint64, opts ...FactorizerOption) (Factorizer, error) // like Factorizer, but cancellable
+	BinomialFactorization(n, k uint64) ([]PrimePower, bool)                                          // prime factorization of C(n, k)
+	BrunSum() float64                                                                                // partial sum of Brun's constant
+	Checksum() uint64                                                                                // checksum of the set for integrity checks
+	CircularPrimes(max uint64) Iterator                                                              // all circular primes up to max
+	Clone() Set                                                                                      // independent copy of the set
+	ClosestPrime(n uint64) (uint64, bool)                                                            // prime nearest to n
+	Compact() CompactSet                                                                             // compressed copy of the set for archival purposes
+	Composites(start uint64) Iterator                                                                // composite numbers from start on
+	CountRange(a, b uint64) uint64                                                                   // number of primes in [a, b]
+	Emirps(max uint64) Iterator                                                                      // all emirps up to max
+	FactorialFactorization(n uint64) ([]PrimePower, bool)                                            // prime factorization of n!
+	FactorizeBig(n *big.Int) ([]BigPrimePower, error)                                                // prime factorization of a big number
+	FactorizerRange(lo, hi uint64) RangeFactorizer                                                   // allows for factorization of a window of numbers
+	Filter(start uint64, pred func(p uint64) bool) Iterator                                          // primes from start on that fulfil a predicate
+	Find(start uint64, pred func(p uint64) bool) (uint64, bool)                                      // first prime from start on that fulfils a predicate
+	FindPrimeAP(length int, start uint64) (uint64, uint64, bool)                                     // arithmetic progression of primes from start on
+	FirstOccurrenceGaps(max uint64) map[uint64]uint64                                                // gap sizes mapped to the prime where they first occur
+	ForEach(start, end uint64, fn func(p uint64) bool)                                               // calls fn for all primes in [start, end]
+	ForEachParallel(start, end uint64, fn func(p uint64) bool, workers int)                          // calls fn concurrently for all primes in [start, end]
+	GoldbachCount(n uint64) (uint64, bool)                                                           // number of Goldbach partitions of n
+	GoldbachPartitions(n uint64) PairIterator                                                        // pairs of primes adding up to n
+	HighlyCompositeNumbers(max uint64) Iterator                                                      // numbers with more divisors than any smaller number
+	IsCircularPrime(n uint64) bool                                                                   // true iff all digit rotations of n are prime
+	IsEmirp(n uint64) bool                                                                           // true iff n and its digit reversal are distinct primes
+	IsPermutablePrime(n uint64) bool                                                                 // true iff all digit permutations of n are prime
+	IsPrimePower(n uint64) (uint64, uint, bool)                                                      // base and exponent of a prime power
+	LargestNumber() uint64                                                                           // largest number in the set
+	LargestPrime() uint64                                                                            // largest prime number in the set
+	MaximalGaps() []GapRecord                                                                        // all gaps larger than any previous gap
+	MemoryUsage() uint                                                                               // number of bytes used for the prime bits and their index
+	MersenneExponents(max uint64) Iterator                                                           // exponents p of Mersenne primes 2^p - 1
+	NthPrime(n uint64) (uint64, bool)                                                                // n-th prime number, starting with 2
+	PermutablePrimes(max uint64) Iterator                                                            // all permutable primes up to max
+	PermutationClasses(lo, hi uint64) map[string][]uint64                                            // primes in [lo, hi] grouped by their sorted digits
+	PrimePowers(start uint64) Iterator                                                               // prime powers from start on
+	Primorial(n uint64) (*big.Int, bool)                                                             // product of all primes up to n
+	Pseudoprimes(base, max uint64) Iterator                                                          // Fermat pseudoprimes to a given base
+	Psi(n uint64) (float64, bool)                                                                    // second Chebyshev function ψ(n)
+	Race(m, a, b uint64) RaceIterator                                                                // prime race between two residue classes modulo m
+	RamanujanPrimes(max uint64) Iterator                                                             // primes R_n with π(x) - π(x/2) >= n for all x >= R_n
+	RandomPrime(rnd io.Reader) (uint64, error)                                                       // uniformly distributed random prime of the set
+	ReadFactorizer(r io.Reader, opts ...FactorizerOption) (Factorizer, error)                        // reads a factorizer written by Factorizer.WriteTo
+	Render(w io.Writer, width int, opts ...RenderOption) error                                       // black and white image of the primes
+	RepunitExponents(base, max uint64) Iterator                                                      // lengths n of repunit primes in a given base
+	ResidueCounts(m, upTo uint64) map[uint64]uint64                                                  // number of primes per residue class modulo m
+	SmallestFactorOf(n uint64) (uint64, bool)                                                        // smallest prime factor of a given number
+	SqrtMod(a, p uint64) (uint64, bool)                                                              // square root of a modulo a prime p
+	Stats() SetStats                                                                                 // statistical summary of the set
+	StrongPseudoprimes(base, max uint64) Iterator                                                    // strong pseudoprimes to a given base
+	SubSet(lo, hi uint64) Set                                                                        // view of the primes in [lo, hi] sharing the prime bits
+	SumPrimes(n uint64) (uint64, bool)                                                               // sum of all primes up to n
+	SumPrimesExtended(n uint64) *big.Int                                                             // sum of all primes up to n, also beyond the set
+	Theta(n uint64) (float64, bool)                                                                  // first Chebyshev function θ(n)
+	Verify(samples int, rnd io.Reader) error                                                         // checks the prime bits for corruption
+	WieferichPrimes(lo, hi uint64) Iterator                                                          // primes p in [lo, hi] with 2^(p-1) = 1 mod p²
+	WilsonPrimes(lo, hi uint64) Iterator                                                             // primes p in [lo, hi] with (p-1)! = -1 mod p²
+	WriteTo(w io.Writer) (int64, error)                                                              // writes the set in a binary format
 }
 
 // set is the internal implementation of Set.
 type set struct {
-	bits          []uint64 // bits for prime number candidates that are not divisible by 2 and 3
-	largestNumber uint64   // largest number in the set
-	largestPrime  uint64   // largest prime number in the set
+	bits          bitset.BitSet // bits for prime number candidates that are not divisible by 2 and 3
+	store         Bits          // storage of these bits if they are not held in bits, or nil
+	rank          []uint64      // number of set bits before each block of rankBlockWords words, or nil
+	largestNumber uint64        // largest number in the set
+	largestPrime  uint64        // largest prime number in the set
+	metrics       Metrics       // receiver for usage events, or nil
 }
 
 // NewPrimeSet creates a new set of prime numbers up to a given limit.
-func NewPrimeSet(limit uint64) Set {
+func NewPrimeSet(limit uint64, opts ...Option) Set {
 	if limit < 5 {
 		panic("prime set must have at least a size of 5")
 	}
-	s := new(set)
-	i := numberToIndex(limit)
-	words := i >> 6
-	if i&63 != 0 {
-		words++
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	start := time.Now()
+	bits := make(bitset.BitSet, setWords(limit))
+	bits.SetAll()
+	sievePrimeBitSet(bits, 0, o.checkpointer(limit))
+	o.checkpointComplete(limit, bits)
+	s := newSet(bits, o.metrics)
+	if o.rankIndex {
+		s.buildRankIndex()
+	}
+	if s.metrics != nil {
+		s.metrics.SetBuilt(limit, time.Since(start))
 	}
-	s.bits = make([]uint64, words)
-	calculatePrimeBitSet(s.bits)
-	h, _ := highestSetBit(s.bits)
+	return s
+}
+
+// newSet creates a set from completely sieved prime bits.
+func newSet(bits bitset.BitSet, metrics Metrics) *set {
+	s := &set{bits: bits, metrics: metrics}
+	h, _ := s.bits.PrevSet(s.bits.Len())
 	s.largestPrime = indexToNumber(h)
 	s.largestNumber = indexToNumber(uint(len(s.bits)<<6 - 1))
 	return s
 }
 
+// NewPrimeSetForCount creates a new set of prime numbers which contains at least the first k primes.
+func NewPrimeSetForCount(k uint64, opts ...Option) Set {
+	limit := NthPrimeUpperBound(k)
+	if limit < 5 {
+		limit = 5
+	}
+	return NewPrimeSet(limit, opts...)
+}
+
+// setWords returns the number of uint64 words needed for the prime bits of a set reaching at least up to limit.
+func setWords(limit uint64) uint {
+	return numberToIndex(limit)>>6 + 1
+}
+
 // IsPrime returns true iff n is a prime number.
 func (s *set) IsPrime(n uint64) bool {
+	if s.metrics != nil {
+		s.metrics.IsPrimeCalled()
+	}
+	return s.isPrime(n)
+}
+
+// isPrime returns true iff n is a prime number, without reporting to the metrics.
+func (s *set) isPrime(n uint64) bool {
 	if n <= 63 {
+		if n&1 == 0 || n == 1 {
+			return n == 2
+		}
 		const quickcheck = uint64(0x816d129a64b4cb6f)
 		quickCheckMask := uint64(1) << ((n - 1) >> 1)
 		return quickcheck&quickCheckMask != 0
@@ -72,12 +176,25 @@ func (s *set) IsPrime(n uint64) bool {
 		return false
 	}
 	i := numberToIndex(n)
+	if i > 0 && s.store != nil {
+		return s.store.Get(i)
+	}
 	if i > 0 {
-		return getBit(s.bits, i)
+		return s.bits.Get(i) // not via bit, which is too large to inline in this hot path
 	}
 	return false
 }
 
+// isPrimeExtended returns true iff n is a prime number, using the set where possible and a deterministic
+// Miller-Rabin test beyond its boundaries.
+func (s *set) isPrimeExtended(n uint64) bool {
+	if n <= s.largestNumber {
+		return s.isPrime(n)
+	}
+	prime, _ := millerRabin(n)
+	return prime
+}
+
 // LargestPrime returns the largest prime number in the set, i.e. the upper limit for IsPrime() etc.
 func (s *set) LargestPrime() uint64 {
 	return s.largestPrime
@@ -89,7 +206,7 @@ func (s *set) LargestNumber() uint64 {
 }
 
 func (s *set) MemoryUsage() uint {
-	return uint(len(s.bits) << 3)
+	return uint(len(s.bits)+len(s.rank)) << 3
 }
 
 // SmallestFactorOf returns the smallest prime factor of a given number.
@@ -98,7 +215,7 @@ func (s *set) SmallestFactorOf(n uint64) (uint64, bool) {
 	if n == 0 {
 		return 0, false
 	}
-	limit := uint64(math.Ceil(math.Sqrt(float64(n))))
+	limit := Sqrt(n)
 	it := s.Iterator(0)
 	p, ok := it.Next()
 	for ok && p <= limit {
@@ -113,20 +230,29 @@ func (s *set) SmallestFactorOf(n uint64) (uint64, bool) {
 	return n, true
 }
 
-// calculatePrimeBitSet initializes the prime bit set using a simple prime sieve.
-func calculatePrimeBitSet(bits []uint64) {
-	setAllBits(bits)
+// sievePrimeBitSet completes the prime bit set using a simple prime sieve, starting with the prime at bit index from.
+// All bits must be set initially; if the sieve is resumed, all primes below the one at from must already be processed.
+// If checkpoint is not nil, it is called regularly with the bit index of the next prime to be processed.
+//
+// The multiples p*m of a prime p that remain in the bit set are those with m = 1 or 5 mod 6. Their bit indices form two
+// arithmetic progressions with the stride 2p, starting at the multiples p*p and p*(p+2) or p*(p+4), so they are
+// cleared without any divisions, and with precomputed masks for the small primes.
+func sievePrimeBitSet(bits bitset.BitSet, from uint, checkpoint func(bits []uint64, next uint)) {
 	highestbitindex := uint(len(bits)<<6 - 1)
 	limit := indexToNumber(highestbitindex)
-	for i, found := uint(0), true; found; i, found = nextSetBit(bits, i+1) {
+	count := 0
+	for i, found := max(from, 1), bits.Get(max(from, 1)); found; i, found = bits.NextSet(i + 1) {
+		if count++; checkpoint != nil && count&63 == 0 {
+			checkpoint(bits, i)
+		}
 		p := indexToNumber(i)
-		n := p * 5
-		d := p * 2
-		for n <= limit {
-			if n%3 != 0 {
-				clearBit(bits, numberToIndex(n))
-			}
-			n += d
+		if p > limit/p {
+			break
+		}
+		next := p + 4 - p%6/2 // the multiple of the other progression, i.e. p+2 for p = 5 mod 6 and p+4 otherwise
+		bits.ClearStride(numberToIndex(p*p), uint(2*p))
+		if p <= limit/next {
+			bits.ClearStride(numberToIndex(p*next), uint(2*p))
 		}
 	}
 }
@@ -14,6 +14,16 @@ func TestInitialization(t *testing.T) {
 	t.Logf("primes up to %d sieved in %s using %d kB", size, elapsed, p.MemoryUsage()>>10)
 }
 
+func TestIsPrime(t *testing.T) {
+	set := NewPrimeSet(1000)
+	expected := map[uint64]bool{0: false, 1: false, 2: true, 3: true, 4: false, 8: false, 9: false, 61: true, 62: false, 63: false, 997: true, 999: false}
+	for n, prime := range expected {
+		if set.IsPrime(n) != prime {
+			t.Errorf("IsPrime(%d) should be %t", n, prime)
+		}
+	}
+}
+
 func TestIterators(t *testing.T) {
 	set := NewPrimeSet(1000000)
 	it := set.Iterator(0)
@@ -0,0 +1,8 @@
+// Package primesgrpc provides a gRPC server for the Primes service of primes.proto, which answers with a prime set and
+// a factorizer of package primes. The generated code and the server are only built with the build tag grpc, so that
+// package primes has no external dependencies otherwise.
+package primesgrpc
+
+// The code is generated with protoc, protoc-gen-go and protoc-gen-go-grpc, and the build tag is added afterwards.
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative primes.proto
+//go:generate sed -i "1i //go:build grpc\n" primes.pb.go primes_grpc.pb.go
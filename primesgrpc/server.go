@@ -0,0 +1,82 @@
+//go:build grpc
+
+package primesgrpc
+
+import (
+	"context"
+
+	"github.com/docwalter/primes"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// rangeBatch is the maximum number of primes sent in a single message of Range.
+const rangeBatch = 1024
+
+// Server implements the Primes service with a prime set and a factorizer.
+type Server struct {
+	UnimplementedPrimesServer
+	set        primes.Set        // primes for IsPrime and Range
+	factorizer primes.Factorizer // factorizations for Factorize
+}
+
+// NewServer returns a server that answers with the given set and factorizer. Numbers beyond their boundaries are
+// rejected with the status code OutOfRange.
+func NewServer(set primes.Set, factorizer primes.Factorizer) *Server {
+	return &Server{set: set, factorizer: factorizer}
+}
+
+// IsPrime checks a single number of the set for primality.
+func (s *Server) IsPrime(ctx context.Context, req *IsPrimeRequest) (*IsPrimeResponse, error) {
+	if req.N > s.set.LargestNumber() {
+		return nil, status.Errorf(codes.OutOfRange, "%d exceeds the prime set reaching up to %d", req.N,
+			s.set.LargestNumber())
+	}
+	return &IsPrimeResponse{N: req.N, Prime: s.set.IsPrime(req.N)}, nil
+}
+
+// Factorize returns the prime factorization of a single number within the boundaries of the factorizer.
+func (s *Server) Factorize(ctx context.Context, req *FactorizeRequest) (*FactorizeResponse, error) {
+	if req.N == 0 {
+		return nil, status.Error(codes.InvalidArgument, "0 has no prime factorization")
+	}
+	factors, ok := s.factorizer.Factorize(req.N)
+	if !ok {
+		return nil, status.Errorf(codes.OutOfRange, "%d exceeds the factorizer boundaries", req.N)
+	}
+	resp := &FactorizeResponse{N: req.N, Factors: make([]*PrimePower, len(factors))}
+	for i, pp := range factors {
+		resp.Factors[i] = &PrimePower{Prime: pp.Prime, Exponent: uint32(pp.Exponent)}
+	}
+	return resp, nil
+}
+
+// Range streams all primes in [from, to] in ascending order in batches of up to rangeBatch primes. It stops early
+// if the stream's context is done.
+func (s *Server) Range(req *RangeRequest, stream grpc.ServerStreamingServer[RangeResponse]) error {
+	if req.From > req.To {
+		return status.Errorf(codes.InvalidArgument, "empty range [%d, %d]", req.From, req.To)
+	}
+	if req.To > s.set.LargestNumber() {
+		return status.Errorf(codes.OutOfRange, "range end %d exceeds the prime set reaching up to %d", req.To,
+			s.set.LargestNumber())
+	}
+	batch := make([]uint64, 0, rangeBatch)
+	it := s.set.Iterator(req.From)
+	for p, ok := it.Next(); ok && p <= req.To; p, ok = it.Next() {
+		if batch = append(batch, p); len(batch) == rangeBatch {
+			if err := stream.Context().Err(); err != nil {
+				return status.FromContextError(err).Err()
+			}
+			if err := stream.Send(&RangeResponse{Primes: batch}); err != nil {
+				return err
+			}
+			batch = make([]uint64, 0, rangeBatch)
+		}
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+	return stream.Send(&RangeResponse{Primes: batch})
+}
@@ -0,0 +1,93 @@
+//go:build grpc
+
+package primesgrpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/docwalter/primes"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestClient starts a server for the primes up to 100000 on an in-memory connection and returns a client for it.
+func newTestClient(t *testing.T) PrimesClient {
+	set := primes.NewPrimeSet(100000)
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	RegisterPrimesServer(srv, NewServer(set, set.Factorizer(100000)))
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return NewPrimesClient(conn)
+}
+
+func TestServer(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+	if resp, err := client.IsPrime(ctx, &IsPrimeRequest{N: 99991}); err != nil || !resp.Prime {
+		t.Errorf("IsPrime(99991) = %v, %v", resp, err)
+	}
+	if _, err := client.IsPrime(ctx, &IsPrimeRequest{N: 1 << 20}); status.Code(err) != codes.OutOfRange {
+		t.Errorf("IsPrime() beyond the set returns %v", err)
+	}
+
+	resp, err := client.Factorize(ctx, &FactorizeRequest{N: 720})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []*PrimePower{{Prime: 2, Exponent: 4}, {Prime: 3, Exponent: 2}, {Prime: 5, Exponent: 1}}
+	if len(resp.Factors) != len(expected) {
+		t.Fatalf("Factorize(720) = %v", resp.Factors)
+	}
+	for i, pp := range resp.Factors {
+		if pp.Prime != expected[i].Prime || pp.Exponent != expected[i].Exponent {
+			t.Errorf("Factorize(720) = %v", resp.Factors)
+		}
+	}
+	if _, err := client.Factorize(ctx, &FactorizeRequest{N: 0}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Factorize(0) returns %v", err)
+	}
+}
+
+func TestServerRange(t *testing.T) {
+	client := newTestClient(t)
+	stream, err := client.Range(context.Background(), &RangeRequest{From: 10, To: 100000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var received []uint64
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		received = append(received, resp.Primes...)
+	}
+	if len(received) != 9592-4 || received[0] != 11 || received[len(received)-1] != 99991 {
+		t.Errorf("Range() streamed %d primes from %d to %d", len(received), received[0], received[len(received)-1])
+	}
+
+	stream, err = client.Range(context.Background(), &RangeRequest{From: 0, To: 1 << 20})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.OutOfRange {
+		t.Errorf("Range() beyond the set returns %v", err)
+	}
+}
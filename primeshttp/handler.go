@@ -0,0 +1,172 @@
+/*
+Package primeshttp exposes a prime set as a JSON web service.
+
+Endpoints:
+
+	GET /isprime/{n}                     primality of n
+	GET /factors/{n}                     prime factorization of n
+	GET /primes?from=a&to=b&limit=c      primes in [a, b], at most c of them
+	GET /nth/{k}                         k-th prime number, starting with 2 for k = 1
+
+Example usage:
+
+	set := primes.NewPrimeSet(100000000, primes.WithRankIndex())
+	http.ListenAndServe(":8080", primeshttp.NewHandler(set))
+*/
+package primeshttp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/docwalter/primes"
+)
+
+// Limits for the number of primes returned by /primes.
+const (
+	DefaultLimit = 1000   // number of primes returned if no limit is given
+	MaxLimit     = 100000 // maximum number of primes returned by a single request
+)
+
+// handler is the http.Handler serving the prime endpoints.
+type handler struct {
+	set    primes.Set    // prime set used for all answers
+	oracle primes.Oracle // oracle for numbers beyond the set
+	mux    *http.ServeMux
+}
+
+// NewHandler returns an http.Handler that answers prime number queries from the given set.
+// Primality and factorization queries beyond the set boundaries are answered with Miller-Rabin tests and
+// Pollard's rho method. The k-th prime is looked up with Set.NthPrime, which is fastest for sets built with
+// WithRankIndex.
+func NewHandler(set primes.Set) http.Handler {
+	h := &handler{set: set, oracle: primes.NewSetOracle(set), mux: http.NewServeMux()}
+	h.mux.HandleFunc("/isprime/", h.isPrime)
+	h.mux.HandleFunc("/factors/", h.factors)
+	h.mux.HandleFunc("/primes", h.primes)
+	h.mux.HandleFunc("/nth/", h.nth)
+	return h
+}
+
+// ServeHTTP dispatches GET requests to the endpoints.
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "only GET requests are supported")
+		return
+	}
+	h.mux.ServeHTTP(w, r)
+}
+
+// pathValue returns the rest of the request path after the given endpoint prefix, e.g. n for /isprime/n.
+func pathValue(r *http.Request, prefix string) string {
+	return strings.TrimPrefix(r.URL.Path, prefix)
+}
+
+// isPrime answers /isprime/{n}.
+func (h *handler) isPrime(w http.ResponseWriter, r *http.Request) {
+	n, ok := parse(w, "n", pathValue(r, "/isprime/"))
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, struct {
+		N     uint64 `json:"n"`
+		Prime bool   `json:"prime"`
+	}{n, h.oracle.IsPrime(n)})
+}
+
+// factors answers /factors/{n}.
+func (h *handler) factors(w http.ResponseWriter, r *http.Request) {
+	n, ok := parse(w, "n", pathValue(r, "/factors/"))
+	if !ok {
+		return
+	}
+	factors, ok := h.oracle.Factor(n)
+	if !ok {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("%d has no prime factorization", n))
+		return
+	}
+	writeJSON(w, http.StatusOK, primes.Factorization{N: n, Factors: factors})
+}
+
+// primes answers /primes?from=&to=&limit=.
+func (h *handler) primes(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	from, to, limit := uint64(0), h.set.LargestNumber(), uint64(DefaultLimit)
+	ok := true
+	if s := query.Get("from"); s != "" {
+		from, ok = parse(w, "from", s)
+	}
+	if s := query.Get("to"); s != "" && ok {
+		to, ok = parse(w, "to", s)
+	}
+	if s := query.Get("limit"); s != "" && ok {
+		limit, ok = parse(w, "limit", s)
+	}
+	if !ok {
+		return
+	}
+	if to > h.set.LargestNumber() {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("to exceeds the largest number %d", h.set.LargestNumber()))
+		return
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	result := []uint64{}
+	it := h.set.Iterator(from)
+	for p, ok := it.Next(); ok && p <= to && uint64(len(result)) < limit; p, ok = it.Next() {
+		result = append(result, p)
+	}
+	writeJSON(w, http.StatusOK, struct {
+		Primes []uint64 `json:"primes"`
+	}{result})
+}
+
+// nth answers /nth/{k}.
+func (h *handler) nth(w http.ResponseWriter, r *http.Request) {
+	k, ok := parse(w, "k", pathValue(r, "/nth/"))
+	if !ok {
+		return
+	}
+	if k == 0 {
+		writeError(w, http.StatusBadRequest, "k must be at least 1")
+		return
+	}
+	p, ok := h.set.NthPrime(k)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("the %d-th prime exceeds the largest number %d", k, h.set.LargestNumber()))
+		return
+	}
+	writeJSON(w, http.StatusOK, struct {
+		K     uint64 `json:"k"`
+		Prime uint64 `json:"prime"`
+	}{k, p})
+}
+
+// parse parses a number parameter. If the parameter is invalid, an error response is written and the second result
+// is false.
+func parse(w http.ResponseWriter, name, s string) (uint64, bool) {
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid %s: %q", name, s))
+		return 0, false
+	}
+	return n, true
+}
+
+// writeError writes an error response.
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, struct {
+		Error string `json:"error"`
+	}{message})
+}
+
+// writeJSON writes a JSON response.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
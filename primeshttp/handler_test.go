@@ -0,0 +1,46 @@
+package primeshttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/docwalter/primes"
+)
+
+func TestHandler(t *testing.T) {
+	h := NewHandler(primes.NewPrimeSet(1000))
+	for _, c := range []struct {
+		url    string
+		status int
+		body   string
+	}{
+		{"/isprime/997", http.StatusOK, `{"n":997,"prime":true}`},
+		{"/isprime/1000001", http.StatusOK, `{"n":1000001,"prime":false}`},
+		{"/isprime/18446744073709551557", http.StatusOK, `{"n":18446744073709551557,"prime":true}`},
+		{"/isprime/x", http.StatusBadRequest, `{"error":"invalid n: \"x\""}`},
+		{"/factors/360", http.StatusOK, `{"n":360,"factors":[{"prime":2,"exponent":3},{"prime":3,"exponent":2},{"prime":5,"exponent":1}]}`},
+		{"/factors/1", http.StatusOK, `{"n":1,"factors":[]}`},
+		{"/factors/0", http.StatusBadRequest, `{"error":"0 has no prime factorization"}`},
+		{"/primes?from=10&to=30", http.StatusOK, `{"primes":[11,13,17,19,23,29]}`},
+		{"/primes?from=10&limit=3", http.StatusOK, `{"primes":[11,13,17]}`},
+		{"/primes?from=25&to=28", http.StatusOK, `{"primes":[]}`},
+		{"/primes?to=100000", http.StatusBadRequest, ""},
+		{"/nth/1", http.StatusOK, `{"k":1,"prime":2}`},
+		{"/nth/100", http.StatusOK, `{"k":100,"prime":541}`},
+		{"/nth/0", http.StatusBadRequest, `{"error":"k must be at least 1"}`},
+		{"/nth/100000", http.StatusNotFound, ""},
+		{"/unknown", http.StatusNotFound, ""},
+		{"/isprime/7/8", http.StatusBadRequest, ""},
+	} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("GET", c.url, nil))
+		if rec.Code != c.status {
+			t.Errorf("GET %s returned status %d instead of %d", c.url, rec.Code, c.status)
+		}
+		if body := strings.TrimSpace(rec.Body.String()); c.body != "" && body != c.body {
+			t.Errorf("GET %s returned %s instead of %s", c.url, body, c.body)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package primes
+
+import "math/big"
+
+// Primorial returns the product of all primes up to n. The primorial of 0 and 1 is 1.
+// If n exceeds the set, the second result is false.
+func (s *set) Primorial(n uint64) (*big.Int, bool) {
+	if n > s.largestNumber {
+		return nil, false
+	}
+	return primorial(s.Iterator(0), n), true
+}
+
+// primorial returns the product of all primes of an iterator up to n.
+func primorial(it Iterator, n uint64) *big.Int {
+	product := big.NewInt(1)
+	factor := new(big.Int)
+	chunk := uint64(1) // product of several primes that still fits into an uint64
+	for p, ok := it.Next(); ok && p <= n; p, ok = it.Next() {
+		if chunk > ^uint64(0)/p {
+			product.Mul(product, factor.SetUint64(chunk))
+			chunk = 1
+		}
+		chunk *= p
+	}
+	return product.Mul(product, factor.SetUint64(chunk))
+}
+
+// FactorialFactorization returns the prime factorization of n! in ascending order of the prime factors, using
+// Legendre's formula for the exponent of every prime p <= n. The factorization of 0! and 1! is empty.
+// If n exceeds the set, the second result is false.
+func (s *set) FactorialFactorization(n uint64) ([]PrimePower, bool) {
+	if n > s.largestNumber {
+		return nil, false
+	}
+	var factors []PrimePower
+	it := s.Iterator(0)
+	for p, ok := it.Next(); ok && p <= n; p, ok = it.Next() {
+		factors = append(factors, PrimePower{p, legendre(n, p)})
+	}
+	return factors, true
+}
+
+// legendre returns the exponent of the prime p in n!, which is the sum of n/p^i for all i >= 1.
+func legendre(n, p uint64) uint {
+	e := uint64(0)
+	for n >= p {
+		n /= p
+		e += n
+	}
+	return uint(e)
+}
@@ -0,0 +1,45 @@
+package primes
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestPrimorial(t *testing.T) {
+	set := NewPrimeSet(1000)
+	for _, c := range []struct {
+		n       uint64
+		product string
+	}{
+		{0, "1"}, {1, "1"}, {2, "2"}, {10, "210"}, {30, "6469693230"}, {60, "1922760350154212639070"},
+	} {
+		if product, ok := set.Primorial(c.n); !ok || product.String() != c.product {
+			t.Errorf("Primorial(%d) = %s instead of %s", c.n, product, c.product)
+		}
+	}
+	if _, ok := set.Primorial(set.LargestNumber() + 1); ok {
+		t.Error("Primorial beyond the set should fail")
+	}
+}
+
+func TestFactorialFactorization(t *testing.T) {
+	set := NewPrimeSet(1000)
+	factorial := big.NewInt(1)
+	for n := uint64(0); n <= 200; n++ {
+		if n > 1 {
+			factorial.Mul(factorial, new(big.Int).SetUint64(n))
+		}
+		factors, ok := set.FactorialFactorization(n)
+		if !ok {
+			t.Fatalf("FactorialFactorization(%d) failed", n)
+		}
+		product := big.NewInt(1)
+		for _, pp := range factors {
+			power := new(big.Int).Exp(new(big.Int).SetUint64(pp.Prime), big.NewInt(int64(pp.Exponent)), nil)
+			product.Mul(product, power)
+		}
+		if product.Cmp(factorial) != 0 {
+			t.Fatalf("FactorialFactorization(%d) = %v does not multiply to %s", n, factors, factorial)
+		}
+	}
+}
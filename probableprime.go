@@ -0,0 +1,156 @@
+package primes
+
+import (
+	"crypto/rand"
+	"io"
+	"math/big"
+	"sync"
+)
+
+// trialPrimeLimit is the upper bound of the primes used for trial division of big integers.
+const trialPrimeLimit = 4096
+
+var (
+	trialPrimesOnce sync.Once // guards the initialization of trialPrimes and trialProducts
+	trialPrimes     []uint64  // odd primes up to trialPrimeLimit
+	trialProducts   []uint64  // products of consecutive runs of trialPrimes, each fitting into an uint64
+	trialRuns       []int     // number of primes that make up each of trialProducts
+)
+
+// initTrialPrimes sieves the primes for trial division and groups them into products, so that a big integer needs
+// only one big division per product.
+func initTrialPrimes() {
+	trialPrimesOnce.Do(func() {
+		it := NewPrimeSet(trialPrimeLimit).Iterator(3)
+		product, run := uint64(1), 0
+		for p, ok := it.Next(); ok && p <= trialPrimeLimit; p, ok = it.Next() {
+			if product > ^uint64(0)/p {
+				trialProducts = append(trialProducts, product)
+				trialRuns = append(trialRuns, run)
+				product, run = 1, 0
+			}
+			trialPrimes = append(trialPrimes, p)
+			product *= p
+			run++
+		}
+		trialProducts = append(trialProducts, product)
+		trialRuns = append(trialRuns, run)
+	})
+}
+
+// smallFactorOf returns the smallest prime factor of the odd number n up to trialPrimeLimit, or 0 if there is none.
+func smallFactorOf(n *big.Int) uint64 {
+	initTrialPrimes()
+	r := new(big.Int)
+	m := new(big.Int)
+	i := 0
+	for j, product := range trialProducts {
+		rem := r.Mod(n, m.SetUint64(product)).Uint64()
+		for _, p := range trialPrimes[i : i+trialRuns[j]] {
+			if rem%p == 0 {
+				return p
+			}
+		}
+		i += trialRuns[j]
+	}
+	return 0
+}
+
+// IsProbablePrime returns true iff n is probably prime. Numbers that fit into an uint64 are tested deterministically.
+// Larger numbers are first divided by the primes up to 4096 and then subjected to the given number of Miller-Rabin
+// rounds with random bases read from rnd, so that a composite passes with a probability of at most 4^-rounds.
+// If rnd is nil, crypto/rand.Reader is used. An error is only returned if reading from rnd fails.
+func IsProbablePrime(n *big.Int, rounds int, rnd io.Reader) (bool, error) {
+	if n.Sign() <= 0 {
+		return false, nil
+	}
+	if n.IsUint64() {
+		prime, _ := millerRabin(n.Uint64())
+		return prime, nil
+	}
+	if n.Bit(0) == 0 || smallFactorOf(n) != 0 {
+		return false, nil
+	}
+	if rnd == nil {
+		rnd = rand.Reader
+	}
+
+	one := big.NewInt(1)
+	nm1 := new(big.Int).Sub(n, one)
+	s := nm1.TrailingZeroBits()
+	d := new(big.Int).Rsh(nm1, s)
+	limit := new(big.Int).Sub(n, big.NewInt(3)) // bases are drawn from [2, n-2]
+	x := new(big.Int)
+	for i := 0; i < rounds; i++ {
+		a, err := rand.Int(rnd, limit)
+		if err != nil {
+			return false, err
+		}
+		a.Add(a, big.NewInt(2))
+		x.Exp(a, d, n)
+		if x.Cmp(one) == 0 || x.Cmp(nm1) == 0 {
+			continue
+		}
+		composite := true
+		for j := uint(1); j < s && composite; j++ {
+			x.Mul(x, x).Mod(x, n)
+			if x.Cmp(nm1) == 0 {
+				composite = false
+			} else if x.Cmp(one) == 0 {
+				break
+			}
+		}
+		if composite {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// nextPrimeWindow is the number of odd candidates that NextProbablePrime sieves at once.
+const nextPrimeWindow = 4096
+
+// NextProbablePrime returns the smallest probable prime larger than n. Results within uint64 are exact. Beyond that,
+// candidates are taken from a window of odd numbers that is sieved with the primes up to 4096, and only the survivors
+// are tested with big.Int.ProbablyPrime(20), which combines Miller-Rabin with a Baillie-PSW test.
+func NextProbablePrime(n *big.Int) *big.Int {
+	if n.Sign() < 0 || n.IsUint64() {
+		var m uint64
+		if n.Sign() > 0 {
+			m = n.Uint64()
+		}
+		if p, ok := nextPrimeMillerRabin(m); ok {
+			return new(big.Int).SetUint64(p)
+		}
+	}
+
+	initTrialPrimes()
+	start := new(big.Int).Add(n, big.NewInt(1))
+	start.SetBit(start, 0, 1) // first odd candidate
+	composite := make([]bool, nextPrimeWindow)
+	m := new(big.Int)
+	c := new(big.Int)
+	for {
+		// mark the candidates start + 2i that are divisible by a small prime
+		for i := range composite {
+			composite[i] = false
+		}
+		for _, p := range trialPrimes {
+			r := m.Mod(start, m.SetUint64(p)).Uint64()
+			// start + 2i = 0 mod p for i = (p - r) / 2 mod p, where the division by 2 is done modulo p
+			i := p - r
+			if i&1 != 0 {
+				i += p
+			}
+			for i >>= 1; i < nextPrimeWindow; i += p {
+				composite[i] = true
+			}
+		}
+		for i, comp := range composite {
+			if !comp && c.Add(start, big.NewInt(int64(2*i))).ProbablyPrime(20) {
+				return c
+			}
+		}
+		start.Add(start, big.NewInt(2*nextPrimeWindow))
+	}
+}
@@ -0,0 +1,89 @@
+package primes
+
+import (
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestIsProbablePrime(t *testing.T) {
+	rnd := rand.New(rand.NewSource(5))
+	mersenne := func(p uint) *big.Int {
+		m := new(big.Int).Lsh(big.NewInt(1), p)
+		return m.Sub(m, big.NewInt(1))
+	}
+	for _, c := range []struct {
+		n     *big.Int
+		prime bool
+	}{
+		{big.NewInt(0), false},
+		{big.NewInt(-7), false},
+		{big.NewInt(2), true},
+		{big.NewInt(3825123056546413051), false},
+		{new(big.Int).SetUint64(18446744073709551557), true},
+		{mersenne(127), true},
+		{mersenne(521), true},
+		{mersenne(523), false},
+		{new(big.Int).Mul(mersenne(89), mersenne(107)), false},
+		{new(big.Int).Mul(mersenne(61), big.NewInt(4093)), false},
+		// Arnault's strong pseudoprime to all bases below 307
+		{fromString("2887148238050771212671429597130393991977609459279722700926516024197432303799152733116328983144639225941977803110929349655578418949441740933805615113979999421542416933972905423711002751042080134966731755152859226962916775325475044445856101949404200039904432116776619949629539250452698719329070373564032273701278453899126120309244841494728976885406024976768122077071687938121709811322297802059565867"), false},
+	} {
+		if prime, err := IsProbablePrime(c.n, 20, rnd); err != nil || prime != c.prime {
+			t.Errorf("IsProbablePrime(%s) = %t, %v instead of %t", c.n, prime, err, c.prime)
+		}
+	}
+	if _, err := IsProbablePrime(mersenne(127), 1, failingReader{}); err == nil {
+		t.Error("IsProbablePrime should report read errors")
+	}
+}
+
+// fromString parses a decimal big integer.
+func fromString(s string) *big.Int {
+	n, _ := new(big.Int).SetString(s, 10)
+	return n
+}
+
+// failingReader is an io.Reader that always fails.
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("no randomness")
+}
+
+func TestNextProbablePrime(t *testing.T) {
+	for _, c := range []struct {
+		n, next string
+	}{
+		{"-5", "2"},
+		{"0", "2"},
+		{"2", "3"},
+		{"1000000", "1000003"},
+		{"18446744073709551557", "18446744073709551629"},
+		{"18446744073709551615", "18446744073709551629"},
+		{"340282366920938463463374607431768211455", "340282366920938463463374607431768211507"},
+	} {
+		if next := NextProbablePrime(fromString(c.n)); next.String() != c.next {
+			t.Errorf("NextProbablePrime(%s) = %s instead of %s", c.n, next, c.next)
+		}
+	}
+
+	// compare with naive incrementing beyond 2^64
+	n := fromString("123456789012345678901234567890")
+	naive := new(big.Int).Add(n, big.NewInt(1))
+	for !naive.ProbablyPrime(20) {
+		naive.Add(naive, big.NewInt(1))
+	}
+	for i := 0; i < 20; i++ {
+		next := NextProbablePrime(n)
+		if next.Cmp(naive) != 0 {
+			t.Fatalf("NextProbablePrime(%s) = %s instead of %s", n, next, naive)
+		}
+		n = next
+		naive = new(big.Int).Add(n, big.NewInt(1))
+		for !naive.ProbablyPrime(20) {
+			naive.Add(naive, big.NewInt(1))
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package primes
+
+import (
+	"math"
+	"math/big"
+)
+
+// ProthTest returns true iff the Proth number N = k·2^n + 1 with odd k < 2^n is prime. By Proth's theorem, N is
+// prime iff a^((N-1)/2) = -1 mod N for a quadratic non-residue a, which is searched among the small primes with the
+// Jacobi symbol, so the result is a proof. Proth numbers that fit into an uint64 are tested with Miller-Rabin.
+// ProthTest panics if k is even or not less than 2^n.
+func ProthTest(k, n uint64) bool {
+	if k&1 == 0 || n < 64 && k >= 1<<n {
+		panic("Proth number requires an odd k < 2^n")
+	}
+	proth := new(big.Int).Lsh(new(big.Int).SetUint64(k), uint(n))
+	proth.Add(proth, big.NewInt(1))
+	if proth.IsUint64() {
+		prime, _ := millerRabin(proth.Uint64())
+		return prime
+	}
+	initTrialPrimes()
+	e := new(big.Int).Rsh(proth, 1) // (N-1)/2
+	nm1 := new(big.Int).Sub(proth, big.NewInt(1))
+	a := new(big.Int)
+	for _, p := range trialPrimes {
+		switch big.Jacobi(a.SetUint64(p), proth) {
+		case 0:
+			return false
+		case -1:
+			return a.Exp(a, e, proth).Cmp(nm1) == 0
+		}
+	}
+	// all small primes are residues, which happens for squares, so settle for a probabilistic test
+	return proth.ProbablyPrime(20)
+}
+
+// IsFermatNumberPrime returns true iff the Fermat number F_m = 2^(2^m) + 1 is prime, using Pépin's test
+// 3^((F_m-1)/2) = -1 mod F_m for m > 0. It takes 2^m - 1 squarings of 2^m bit numbers, so it is only feasible for
+// small m; F_0 to F_4 are the only known Fermat primes. IsFermatNumberPrime panics if 2^m does not fit into
+// an uint, i.e. if m >= 64 or m >= 32 on 32-bit platforms.
+func IsFermatNumberPrime(m uint) bool {
+	if m >= 64 || uint64(1)<<m > math.MaxUint {
+		panic("Fermat number exceeds the address space")
+	}
+	if m == 0 {
+		return true
+	}
+	f := new(big.Int).Lsh(big.NewInt(1), uint(uint64(1)<<m))
+	e := new(big.Int).Rsh(f, 1) // (F_m-1)/2 = 2^(2^m-1)
+	f.Add(f, big.NewInt(1))
+	x := new(big.Int).Exp(big.NewInt(3), e, f)
+	return x.Add(x, big.NewInt(1)).Cmp(f) == 0
+}
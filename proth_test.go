@@ -0,0 +1,41 @@
+package primes
+
+import "testing"
+
+func TestProthTest(t *testing.T) {
+	// 3·2^n + 1 is prime for these n (OEIS A002253)
+	expected := map[uint64]bool{2: true, 5: true, 6: true, 8: true, 12: true, 18: true, 30: true, 36: true, 41: true,
+		66: true, 189: true, 201: true, 209: true, 276: true, 353: true, 408: true, 438: true, 534: true}
+	for n := uint64(2); n <= 600; n++ {
+		if ProthTest(3, n) != expected[n] {
+			t.Errorf("ProthTest(3, %d) != %v", n, expected[n])
+		}
+	}
+	for n := uint64(1); n <= 200; n++ {
+		fermat := n == 1 || n == 2 || n == 4 || n == 8 || n == 16
+		if ProthTest(1, n) != fermat {
+			t.Errorf("ProthTest(1, %d) != %v", n, fermat)
+		}
+	}
+	if ProthTest(13, 4) || !ProthTest(15, 4) {
+		t.Error("13·2^4 + 1 = 209 = 11·19 and 15·2^4 + 1 = 241 are misclassified")
+	}
+	for _, test := range [][2]uint64{{2, 5}, {3, 1}, {1, 0}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("ProthTest(%d, %d) does not panic", test[0], test[1])
+				}
+			}()
+			ProthTest(test[0], test[1])
+		}()
+	}
+}
+
+func TestIsFermatNumberPrime(t *testing.T) {
+	for m := uint(0); m <= 12; m++ {
+		if IsFermatNumberPrime(m) != (m <= 4) {
+			t.Errorf("IsFermatNumberPrime(%d) != %v", m, m <= 4)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package primes
+
+// IsCarmichael returns true iff n is a Carmichael number, i.e. a composite number n with a^(n-1) = 1 mod n for all
+// a coprime to n. It uses Korselt's criterion: n is odd, squarefree, and p - 1 divides n - 1 for all prime factors p.
+// If the factorizer boundaries are exceeded, the second result is false.
+func (f *factorizer) IsCarmichael(n uint64) (bool, bool) {
+	if n < 2 || n&1 == 0 {
+		return false, true
+	}
+	factors, ok := f.Factorize(n)
+	if !ok {
+		return false, false
+	}
+	if len(factors) < 2 {
+		return false, true
+	}
+	for _, pp := range factors {
+		if pp.Exponent > 1 || (n-1)%(pp.Prime-1) != 0 {
+			return false, true
+		}
+	}
+	return true, true
+}
+
+// Pseudoprimes returns an iterator over all Fermat pseudoprimes to the given base up to max, i.e. all composite
+// numbers n with base^(n-1) = 1 mod n. The iteration stops at the end of the set.
+func (s *set) Pseudoprimes(base, max uint64) Iterator {
+	return s.composites(0, max, isFermatPseudoprime(base))
+}
+
+// StrongPseudoprimes returns an iterator over all strong pseudoprimes to the given base up to max, i.e. all odd
+// composite numbers n that pass the Miller-Rabin test for this base. The iteration stops at the end of the set.
+func (s *set) StrongPseudoprimes(base, max uint64) Iterator {
+	return s.composites(0, max, isStrongPseudoprime(base))
+}
+
+// isFermatPseudoprime returns the predicate of Pseudoprimes for composite numbers.
+func isFermatPseudoprime(base uint64) func(n uint64) bool {
+	return func(n uint64) bool {
+		return PowMod(base, n-1, n) == 1
+	}
+}
+
+// isStrongPseudoprime returns the predicate of StrongPseudoprimes for composite numbers.
+func isStrongPseudoprime(base uint64) func(n uint64) bool {
+	return func(n uint64) bool {
+		if n&1 == 0 {
+			return false
+		}
+		mg := newMontgomery(n)
+		a := mg.to(base)
+		if a == 0 {
+			return false
+		}
+		e := numberOfTrailingZeroes(n - 1)
+		return mg.strongProbablePrime(a, (n-1)>>e, e)
+	}
+}
+
+// composites returns an iterator over all composite numbers in [from, max] within the set that fulfil the given
+// predicate.
+func (s *set) composites(from, max uint64, pred func(n uint64) bool) Iterator {
+	if max > s.largestNumber {
+		max = s.largestNumber
+	}
+	n := uint64(3)
+	if from > 4 {
+		n = from - 1
+	}
+	return funcIterator(func() (uint64, bool) {
+		for n < max {
+			n++
+			if !s.isPrime(n) && pred(n) {
+				return n, true
+			}
+		}
+		return 0, false
+	})
+}
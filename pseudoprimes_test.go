@@ -0,0 +1,57 @@
+package primes
+
+import "testing"
+
+func TestIsCarmichael(t *testing.T) {
+	set := NewPrimeSet(100000)
+	f := set.Factorizer(100000)
+	carmichaels := map[uint64]bool{561: true, 1105: true, 1729: true, 2465: true, 2821: true, 6601: true, 8911: true}
+	for n := uint64(0); n < 10000; n++ {
+		if c, ok := f.IsCarmichael(n); !ok || c != carmichaels[n] {
+			t.Errorf("IsCarmichael(%d) should be %t", n, carmichaels[n])
+		}
+	}
+	if _, ok := f.IsCarmichael(1000001); ok {
+		t.Error("IsCarmichael should fail beyond the factorizer boundaries")
+	}
+}
+
+func TestFactorize(t *testing.T) {
+	set := NewPrimeSet(100000)
+	f := set.Factorizer(100000)
+	o := NewMillerRabinOracle()
+	for n := uint64(1); n <= 100000; n++ {
+		factors, ok := f.Factorize(n)
+		expected, _ := o.Factor(n)
+		if !ok || len(factors) != len(expected) {
+			t.Fatalf("Factorize(%d) = %v instead of %v", n, factors, expected)
+		}
+		for i := range factors {
+			if factors[i] != expected[i] {
+				t.Fatalf("Factorize(%d) = %v instead of %v", n, factors, expected)
+			}
+		}
+	}
+	if _, ok := f.Factorize(0); ok {
+		t.Error("0 should not have a factorization")
+	}
+}
+
+func TestPseudoprimes(t *testing.T) {
+	set := NewPrimeSet(10000)
+	testIterator(t, "Pseudoprimes(2)", set.Pseudoprimes(2, 3000), []uint64{341, 561, 645, 1105, 1387, 1729, 1905, 2047, 2465, 2701, 2821})
+	testIterator(t, "StrongPseudoprimes(2)", set.StrongPseudoprimes(2, 10000), []uint64{2047, 3277, 4033, 4681, 8321})
+}
+
+// testIterator checks that an iterator returns exactly the expected numbers.
+func testIterator(t *testing.T, name string, it Iterator, expected []uint64) {
+	for _, e := range expected {
+		if n, ok := it.Next(); !ok || n != e {
+			t.Errorf("%s: expected %d, got %d", name, e, n)
+			return
+		}
+	}
+	if n, ok := it.Next(); ok {
+		t.Errorf("%s: unexpected %d", name, n)
+	}
+}
@@ -0,0 +1,65 @@
+package primes
+
+// RaceIterator allows for traversing a prime race between two residue classes.
+type RaceIterator interface {
+	Next() (uint64, int64, bool) // next prime in one of the classes, current lead of the first class, and status
+}
+
+// Internal implementation of RaceIterator.
+type raceIterator struct {
+	it   Iterator // iterator over all primes of the set
+	m    uint64   // modulus of the residue classes
+	a, b uint64   // competing residue classes
+	lead int64    // number of primes in class a minus number of primes in class b so far
+}
+
+// ResidueCounts returns the number of primes up to upTo in each residue class modulo m. Classes without primes are
+// omitted. If upTo exceeds the set, only the primes in the set are counted. ResidueCounts panics if m is 0.
+func (s *set) ResidueCounts(m, upTo uint64) map[uint64]uint64 {
+	return residueCounts(s.Iterator(0), m, upTo)
+}
+
+// residueCounts returns the number of primes of an iterator up to upTo in each residue class modulo m. It panics if
+// m is 0.
+func residueCounts(it Iterator, m, upTo uint64) map[uint64]uint64 {
+	if m == 0 {
+		panic("modulus must not be 0")
+	}
+	counts := make(map[uint64]uint64)
+	for p, ok := it.Next(); ok && p <= upTo; p, ok = it.Next() {
+		counts[p%m]++
+	}
+	return counts
+}
+
+// Race returns an iterator over the prime race between the residue classes a and b modulo m, e.g. π(x;4,3) versus
+// π(x;4,1). For every prime of the set that falls into one of the two classes, the iterator returns the prime and
+// π(p;m,a) - π(p;m,b). Race panics if m is 0.
+func (s *set) Race(m, a, b uint64) RaceIterator {
+	return newRaceIterator(s.Iterator(0), m, a, b)
+}
+
+// newRaceIterator returns an iterator over the prime race between the residue classes a and b modulo m among the
+// primes of the given iterator. It panics if m is 0.
+func newRaceIterator(it Iterator, m, a, b uint64) *raceIterator {
+	if m == 0 {
+		panic("modulus must not be 0")
+	}
+	return &raceIterator{it, m, a % m, b % m, 0}
+}
+
+// Next returns the next prime of one of the competing classes and the current lead of the first class.
+func (r *raceIterator) Next() (uint64, int64, bool) {
+	for p, ok := r.it.Next(); ok; p, ok = r.it.Next() {
+		switch p % r.m {
+		case r.a:
+			r.lead++
+		case r.b:
+			r.lead--
+		default:
+			continue
+		}
+		return p, r.lead, true
+	}
+	return 0, 0, false
+}
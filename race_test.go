@@ -0,0 +1,40 @@
+package primes
+
+import "testing"
+
+func TestResidueCounts(t *testing.T) {
+	set := NewPrimeSet(1000)
+	counts := set.ResidueCounts(4, 100)
+	if len(counts) != 3 || counts[1] != 11 || counts[2] != 1 || counts[3] != 13 {
+		t.Errorf("ResidueCounts(4, 100) = %v", counts)
+	}
+	counts = set.ResidueCounts(10, 100)
+	if len(counts) != 6 || counts[1] != 5 || counts[3] != 7 || counts[7] != 6 || counts[9] != 5 {
+		t.Errorf("ResidueCounts(10, 100) = %v", counts)
+	}
+}
+
+func TestRace(t *testing.T) {
+	set := NewPrimeSet(30000)
+	it := set.Race(4, 1, 3)
+	var lastLead int64
+	firstLead := uint64(0)
+	count := 0
+	for p, lead, ok := it.Next(); ok; p, lead, ok = it.Next() {
+		if lead > 0 && firstLead == 0 {
+			firstLead = p
+		}
+		if lead-lastLead != 1 && lastLead-lead != 1 {
+			t.Fatalf("lead jumped from %d to %d at %d", lastLead, lead, p)
+		}
+		lastLead = lead
+		count++
+	}
+	if firstLead != 26861 {
+		t.Errorf("class 1 mod 4 first takes the lead at %d instead of 26861", firstLead)
+	}
+	counts := set.ResidueCounts(4, set.LargestNumber())
+	if uint64(count) != counts[1]+counts[3] || lastLead != int64(counts[1])-int64(counts[3]) {
+		t.Errorf("race ended with %d primes and lead %d, but counts are %v", count, lastLead, counts)
+	}
+}
@@ -0,0 +1,30 @@
+package primes
+
+// IsSquareFree returns true iff a given number is not divisible by the square of any prime.
+// If the factorizer boundaries are exceeded or n is 0, the second result is false.
+func (f *factorizer) IsSquareFree(n uint64) (bool, bool) {
+	factors, ok := f.Factorize(n)
+	if !ok {
+		return false, false
+	}
+	for _, pp := range factors {
+		if pp.Exponent > 1 {
+			return false, true
+		}
+	}
+	return true, true
+}
+
+// Radical returns the product of the distinct prime factors of a given number. The radical of 1 is 1.
+// If the factorizer boundaries are exceeded or n is 0, the second result is false.
+func (f *factorizer) Radical(n uint64) (uint64, bool) {
+	factors, ok := f.Factorize(n)
+	if !ok {
+		return 0, false
+	}
+	rad := uint64(1)
+	for _, pp := range factors {
+		rad *= pp.Prime
+	}
+	return rad, true
+}
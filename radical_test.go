@@ -0,0 +1,26 @@
+package primes
+
+import "testing"
+
+func TestRadical(t *testing.T) {
+	f := NewPrimeSet(1000).Factorizer(1000)
+	for _, c := range []struct {
+		n, rad     uint64
+		squareFree bool
+	}{
+		{1, 1, true}, {2, 2, true}, {4, 2, false}, {30, 30, true}, {72, 6, false}, {997, 997, true}, {1000, 10, false},
+	} {
+		if rad, ok := f.Radical(c.n); !ok || rad != c.rad {
+			t.Errorf("Radical(%d) = %d instead of %d", c.n, rad, c.rad)
+		}
+		if sf, ok := f.IsSquareFree(c.n); !ok || sf != c.squareFree {
+			t.Errorf("IsSquareFree(%d) = %t instead of %t", c.n, sf, c.squareFree)
+		}
+	}
+	if _, ok := f.Radical(0); ok {
+		t.Error("Radical(0) should fail")
+	}
+	if _, ok := f.IsSquareFree(1001); ok {
+		t.Error("IsSquareFree(1001) should fail beyond the factorizer")
+	}
+}
@@ -0,0 +1,53 @@
+package primes
+
+// RamanujanPrimes returns an iterator over the Ramanujan primes up to max in ascending order (OEIS A104272). The n-th
+// Ramanujan prime R_n is the smallest number such that π(x) - π(x/2) >= n for all x >= R_n, e.g. R_1 = 2, R_2 = 11
+// and R_3 = 17. The counting function is swept along the primes p, where it increases, and the doubled primes 2p,
+// where it decreases. Since R_n <= p_3n, the sweep ends at the 3k-th prime for the k primes up to max. If the set does
+// not reach that far, the iteration ends with the Ramanujan primes that are certain from the primes of the set.
+func (s *set) RamanujanPrimes(max uint64) Iterator {
+	k := uint64(0) // number of primes up to max, which bounds the number of Ramanujan primes up to max
+	it := s.Iterator(0)
+	for p, ok := it.Next(); ok && p <= max; p, ok = it.Next() {
+		k++
+	}
+
+	// last[c] is the largest x with π(x) - π(x/2) = c, so R_n is one more than the largest of last[0], ..., last[n-1]
+	last := make([]uint64, k)
+	primes, halves := s.Iterator(0), s.Iterator(0)
+	p, ok := primes.Next()
+	q, _ := halves.Next()
+	count, c := uint64(0), uint64(0) // π(x) and π(x) - π(x/2) for x just below the next event
+	for ok && count < 3*k {
+		if p < 2*q {
+			if c < k {
+				last[c] = p - 1
+			}
+			c++
+			count++
+			p, ok = primes.Next()
+		} else {
+			if c < k {
+				last[c] = 2*q - 1
+			}
+			c--
+			q, _ = halves.Next()
+		}
+	}
+
+	n, known, r := uint64(0), count/3, uint64(0)
+	return funcIterator(func() (uint64, bool) {
+		if n >= known {
+			return 0, false
+		}
+		if last[n] >= r {
+			r = last[n] + 1
+		}
+		if r > max {
+			n = known
+			return 0, false
+		}
+		n++
+		return r, true
+	})
+}
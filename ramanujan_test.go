@@ -0,0 +1,50 @@
+package primes
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRamanujanPrimes(t *testing.T) {
+	s := NewPrimeSet(300000)
+	expected := []uint64{2, 11, 17, 29, 41, 47, 59, 67, 71, 97, 101, 107, 127, 149, 151, 167, 179, 181, 227, 229, 233,
+		239, 241, 263, 269, 281, 307, 311, 347, 349, 367, 373, 401, 409, 419, 431, 433, 439, 461, 487, 491}
+	testIterator(t, "RamanujanPrimes(500)", s.RamanujanPrimes(500), expected)
+	testIterator(t, "RamanujanPrimes(1)", s.RamanujanPrimes(1), nil)
+
+	// compare with the counting function evaluated for every number
+	const max = 50000
+	pi := make([]uint64, s.LargestNumber()+1)
+	for x := uint64(2); x < uint64(len(pi)); x++ {
+		pi[x] = pi[x-1]
+		if s.IsPrime(x) {
+			pi[x]++
+		}
+	}
+	expected = nil
+	for n := uint64(1); ; n++ {
+		r := uint64(0)
+		for x := uint64(len(pi)) - 1; x > 0; x-- {
+			if pi[x]-pi[x/2] < n {
+				r = x + 1
+				break
+			}
+		}
+		if r > max {
+			break
+		}
+		expected = append(expected, r)
+	}
+	testIterator(t, "RamanujanPrimes(50000)", s.RamanujanPrimes(max), expected)
+
+	// a small set only yields the first Ramanujan primes
+	var small []uint64
+	for it, r, ok := NewPrimeSet(5).RamanujanPrimes(500), uint64(0), true; ok; r, ok = it.Next() {
+		if r != 0 {
+			small = append(small, r)
+		}
+	}
+	if len(small) == 0 || len(small) >= len(expected) || !slices.Equal(small, expected[:len(small)]) {
+		t.Errorf("RamanujanPrimes of a small set = %v", small)
+	}
+}
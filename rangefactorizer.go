@@ -0,0 +1,121 @@
+package primes
+
+// RangeFactorizer holds precalculated smallest and largest prime factors for a window [lo, hi] of numbers, which
+// may be located far from zero.
+type RangeFactorizer interface {
+	Factorize(n uint64) ([]PrimePower, bool)  // prime factorization of a number in the window
+	LargestFactorOf(n uint64) (uint64, bool)  // largest prime factor of a number in the window
+	SmallestFactorOf(n uint64) (uint64, bool) // smallest prime factor of a number in the window
+}
+
+// Internal implementation of RangeFactorizer.
+type rangeFactorizer struct {
+	set      *set     // underlying prime set, reaching at least up to sqrt(hi)
+	lo, hi   uint64   // boundaries of the window
+	largest  []uint64 // largest prime factors of all numbers in the window
+	smallest []uint32 // smallest prime factors up to sqrt(hi), or 0 for primes
+}
+
+// FactorizerRange returns a factorizer for the numbers in [lo, hi], which sieves with the primes up to sqrt(hi) only.
+// It needs 16 bytes per number of the window while sieving and keeps 12 of them afterwards. FactorizerRange panics
+// if lo > hi or if the set does not reach up to sqrt(hi).
+func (s *set) FactorizerRange(lo, hi uint64) RangeFactorizer {
+	if lo > hi {
+		panic("factorizer range must not be empty")
+	}
+	root := Sqrt(hi)
+	if root > s.largestNumber {
+		panic("prime set must reach up to the square root of the factorizer range")
+	}
+	size := hi - lo + 1
+	largest := make([]uint64, size)  // remaining cofactors during sieving, largest factors afterwards
+	smallest := make([]uint32, size) // smallest prime factors
+	top := make([]uint32, size)      // largest prime factors up to sqrt(hi)
+	for i := range largest {
+		largest[i] = lo + uint64(i)
+	}
+
+	it := s.Iterator(0)
+	for p, ok := it.Next(); ok && p <= root; p, ok = it.Next() {
+		m := lo + (p-lo%p)%p // first multiple of p in the window
+		for i := m - lo; i < size; i += p {
+			if smallest[i] == 0 {
+				smallest[i] = uint32(p)
+			}
+			top[i] = uint32(p)
+			for largest[i] != 0 && largest[i]%p == 0 {
+				largest[i] /= p
+			}
+		}
+	}
+
+	for i := range largest {
+		if largest[i] == 1 {
+			// all prime factors are at most sqrt(hi)
+			largest[i] = uint64(top[i])
+		}
+		// otherwise the cofactor is a single prime larger than sqrt(hi)
+	}
+	return &rangeFactorizer{s, lo, hi, largest, smallest}
+}
+
+// LargestFactorOf returns the largest prime factor of a given number.
+// If n is outside of the window or has no prime factors, the second result is false.
+func (f *rangeFactorizer) LargestFactorOf(n uint64) (uint64, bool) {
+	if n < 2 || n < f.lo || n > f.hi {
+		return 0, false
+	}
+	return f.largest[n-f.lo], true
+}
+
+// SmallestFactorOf returns the smallest prime factor of a given number.
+// If n is outside of the window or has no prime factors, the second result is false.
+func (f *rangeFactorizer) SmallestFactorOf(n uint64) (uint64, bool) {
+	if n < 2 || n < f.lo || n > f.hi {
+		return 0, false
+	}
+	if p := f.smallest[n-f.lo]; p != 0 {
+		return uint64(p), true
+	}
+	return n, true
+}
+
+// Factorize returns the prime factorization of a given number in ascending order of the prime factors.
+// The factors between the smallest and the largest one are found by trial division with the primes of the set.
+// If n is outside of the window or is 0, the second result is false.
+func (f *rangeFactorizer) Factorize(n uint64) ([]PrimePower, bool) {
+	if n == 0 || n < f.lo || n > f.hi {
+		return nil, false
+	}
+	if n == 1 {
+		return nil, true
+	}
+	smallest, _ := f.SmallestFactorOf(n)
+	largest, _ := f.LargestFactorOf(n)
+	var factors []PrimePower
+	divide := func(p uint64) {
+		e := uint(0)
+		for n%p == 0 {
+			n /= p
+			e++
+		}
+		factors = append(factors, PrimePower{p, e})
+	}
+	divide(smallest)
+	if largest != smallest {
+		// remove the largest factor first, so that trial division can stop early
+		e := uint(0)
+		for n%largest == 0 {
+			n /= largest
+			e++
+		}
+		it := f.set.Iterator(smallest + 1)
+		for p, ok := it.Next(); ok && n > 1; p, ok = it.Next() {
+			if n%p == 0 {
+				divide(p)
+			}
+		}
+		factors = append(factors, PrimePower{largest, e})
+	}
+	return factors, true
+}
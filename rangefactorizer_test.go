@@ -0,0 +1,46 @@
+package primes
+
+import "testing"
+
+func TestFactorizerRange(t *testing.T) {
+	set := NewPrimeSet(1100000)
+	for _, window := range [][2]uint64{{0, 1000}, {1000000000000, 1000000010000}, {1200000000000, 1200000000000}} {
+		lo, hi := window[0], window[1]
+		f := set.FactorizerRange(lo, hi)
+		if _, ok := f.Factorize(0); ok && lo == 0 {
+			t.Error("Factorize(0) should fail")
+		}
+		for n := max(lo, 1); n <= hi; n++ {
+			expected := factorize(n)
+			factors, fok := f.Factorize(n)
+			if !fok || len(factors) != len(expected) {
+				t.Fatalf("Factorize(%d) = %v instead of %v", n, factors, expected)
+			}
+			for i := range factors {
+				if factors[i] != expected[i] {
+					t.Fatalf("Factorize(%d) = %v instead of %v", n, factors, expected)
+				}
+			}
+			if len(expected) > 0 {
+				if p, _ := f.SmallestFactorOf(n); p != expected[0].Prime {
+					t.Fatalf("SmallestFactorOf(%d) = %d instead of %d", n, p, expected[0].Prime)
+				}
+				if p, _ := f.LargestFactorOf(n); p != expected[len(expected)-1].Prime {
+					t.Fatalf("LargestFactorOf(%d) = %d instead of %d", n, p, expected[len(expected)-1].Prime)
+				}
+			}
+		}
+		if _, ok := f.LargestFactorOf(hi + 1); ok {
+			t.Errorf("LargestFactorOf(%d) should fail beyond the window", hi+1)
+		}
+	}
+}
+
+func TestFactorizerRangeTooLarge(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("FactorizerRange beyond the square of the set should panic")
+		}
+	}()
+	NewPrimeSet(1000).FactorizerRange(2000000, 2000000)
+}
@@ -0,0 +1,113 @@
+package primes
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"math/bits"
+	"sort"
+)
+
+// rankBlockWords is the number of words of prime bits per entry of the rank index, which thus needs about 3% of the
+// memory of the prime bits.
+const rankBlockWords = 32
+
+// WithRankIndex lets the set build an index of cumulative popcounts per block of prime bits during construction.
+// With the index, CountRange takes constant time, and NthPrime and RandomPrime need only a binary search over the
+// index, at the cost of about 3% more memory.
+func WithRankIndex() Option {
+	return func(o *options) {
+		o.rankIndex = true
+	}
+}
+
+// buildRankIndex builds the rank index of the set, whose last entry is the total number of set bits. Other storages
+// than an array in memory are asked for the ranks of the block boundaries.
+func (s *set) buildRankIndex() {
+	blocks := (int(s.wordCount()) + rankBlockWords - 1) / rankBlockWords
+	s.rank = make([]uint64, blocks+1)
+	for b := 0; b < blocks; b++ {
+		from := uint(b * rankBlockWords << 6)
+		if s.store != nil {
+			s.rank[b+1] = uint64(s.store.Rank(min(from+rankBlockWords<<6, s.store.Len())))
+		} else {
+			s.rank[b+1] = s.rank[b] + uint64(s.bits.Count(from, from+rankBlockWords<<6))
+		}
+	}
+}
+
+// rankOf returns the number of set bits with an index below i using the rank index.
+func (s *set) rankOf(i uint) uint {
+	i = min(i, s.bits.Len())
+	b := i / (rankBlockWords << 6)
+	return uint(s.rank[b]) + s.bits.Count(b*rankBlockWords<<6, i)
+}
+
+// NthPrime returns the n-th prime number, starting with NthPrime(1) = 2.
+// If the set contains less than n primes, the second result is false.
+func (s *set) NthPrime(n uint64) (uint64, bool) {
+	if n <= 2 {
+		return [...]uint64{0, 2, 3}[n], n > 0
+	}
+	if i, ok := s.selectBit(n - 2); ok {
+		return indexToNumber(i), true
+	}
+	return 0, false
+}
+
+// selectBit returns the index of the set bit with k set bits before it.
+// If there are not more than k bits set, the second result is false.
+func (s *set) selectBit(k uint64) (uint, bool) {
+	word, words := uint(0), s.wordCount()
+	if s.rank != nil {
+		// last block whose preceding bits do not exceed k
+		b := sort.Search(len(s.rank), func(b int) bool { return s.rank[b] > k }) - 1
+		if b == len(s.rank)-1 {
+			return 0, false
+		}
+		word, k = uint(b*rankBlockWords), k-s.rank[b]
+	}
+	for ; word < words; word++ {
+		w := s.word(word)
+		if c := uint64(bits.OnesCount64(w)); k >= c {
+			k -= c
+			continue
+		}
+		for ; k > 0; k-- {
+			w &= w - 1
+		}
+		return word<<6 + uint(bits.TrailingZeros64(w)), true
+	}
+	return 0, false
+}
+
+// RandomPrime returns a uniformly distributed random prime of the set. Randomness is read from rnd, or from
+// crypto/rand.Reader if rnd is nil. The error is the error of reading from rnd.
+func (s *set) RandomPrime(rnd io.Reader) (uint64, error) {
+	r, err := randomBelow(rnd, s.CountRange(0, s.largestNumber))
+	if err != nil {
+		return 0, err
+	}
+	p, _ := s.NthPrime(r + 1)
+	return p, nil
+}
+
+// randomBelow returns a uniformly distributed random number in [0, n) for n > 0. Randomness is read from rnd, or from
+// crypto/rand.Reader if rnd is nil, 8 bytes at a time, where values that would favour the smaller numbers are
+// rejected.
+func randomBelow(rnd io.Reader, n uint64) (uint64, error) {
+	if rnd == nil {
+		rnd = rand.Reader
+	}
+	// the values from threshold = 2^64 mod n on are a multiple of n
+	threshold := -n % n
+	var buf [8]byte
+	for {
+		if _, err := io.ReadFull(rnd, buf[:]); err != nil {
+			return 0, err
+		}
+		if r := binary.LittleEndian.Uint64(buf[:]); r >= threshold {
+			return r % n, nil
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package primes
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/rand"
+	"testing"
+)
+
+func TestRankIndex(t *testing.T) {
+	plain, indexed := NewPrimeSet(1000000), NewPrimeSet(1000000, WithRankIndex())
+	if m, n := plain.MemoryUsage(), indexed.MemoryUsage(); n <= m || n > m+m/25 {
+		t.Errorf("indexed set uses %d bytes instead of %d", n, m)
+	}
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		a, b := uint64(rnd.Int63n(1000000)), uint64(rnd.Int63n(1000000))
+		if m, n := plain.CountRange(a, b), indexed.CountRange(a, b); m != n {
+			t.Errorf("CountRange(%d, %d) = %d instead of %d", a, b, n, m)
+		}
+	}
+	if n := indexed.CountRange(0, indexed.LargestNumber()); n != 78506 {
+		t.Errorf("indexed set contains %d primes", n)
+	}
+
+	it := plain.Iterator(0)
+	for n := uint64(1); ; n++ {
+		p, ok := it.Next()
+		for _, s := range []Set{plain, indexed} {
+			if q, qok := s.NthPrime(n); q != p || qok != ok {
+				t.Fatalf("NthPrime(%d) = %d, %v instead of %d, %v", n, q, qok, p, ok)
+			}
+		}
+		if !ok {
+			break
+		}
+	}
+	if p, ok := indexed.NthPrime(0); ok {
+		t.Errorf("NthPrime(0) = %d", p)
+	}
+
+	var buf bytes.Buffer
+	indexed.WriteTo(&buf)
+	read, err := ReadPrimeSet(&buf, WithRankIndex())
+	if err != nil || read.MemoryUsage() != indexed.MemoryUsage() || read.CountRange(10, 999999) != 78494 {
+		t.Errorf("read set lost the index: %v", err)
+	}
+}
+
+func TestRandomPrime(t *testing.T) {
+	set := NewPrimeSet(1000, WithRankIndex())
+	rnd := rand.New(rand.NewSource(1))
+	seen := map[uint64]bool{}
+	for i := 0; i < 5000; i++ {
+		p, err := set.RandomPrime(rnd)
+		if err != nil || !set.IsPrime(p) {
+			t.Fatalf("RandomPrime() = %d, %v", p, err)
+		}
+		seen[p] = true
+	}
+	if n := set.CountRange(0, set.LargestNumber()); uint64(len(seen)) != n {
+		t.Errorf("RandomPrime() yields %d of %d primes", len(seen), n)
+	}
+	if _, err := set.RandomPrime(failingReader{}); err == nil {
+		t.Error("RandomPrime() should return the error of the reader")
+	}
+
+	// 0 is below 2^64 mod count and must be rejected in favour of the next value
+	count := set.CountRange(0, set.LargestNumber())
+	data := binary.LittleEndian.AppendUint64(make([]byte, 8), 1<<63)
+	r := bytes.NewReader(data)
+	expected, _ := set.NthPrime(1<<63%count + 1)
+	if p, err := set.RandomPrime(r); -count%count == 0 || err != nil || p != expected || r.Len() != 0 {
+		t.Errorf("RandomPrime() = %d, %v with %d bytes left instead of %d", p, err, r.Len(), expected)
+	}
+}
@@ -0,0 +1,147 @@
+package primes
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+)
+
+// RenderOption configures the image written by Set.Render.
+type RenderOption func(*renderOptions)
+
+// renderOptions holds the configuration of a rendered image.
+type renderOptions struct {
+	lo, hi uint64 // range of numbers shown row by row
+	ranged bool   // true iff the range was given explicitly
+	ulam   bool   // true iff the numbers are arranged in an Ulam spiral starting at lo
+	pbm    bool   // true iff the image is written as PBM instead of PNG
+}
+
+// WithRange renders the numbers in [lo, hi] row by row instead of the width² numbers from 0 on.
+func WithRange(lo, hi uint64) RenderOption {
+	return func(o *renderOptions) {
+		o.lo, o.hi, o.ranged, o.ulam = lo, hi, true, false
+	}
+}
+
+// WithUlamSpiral renders width² numbers as an Ulam spiral, which starts with the given number in the center and winds
+// counterclockwise outwards, so that the primes line up along diagonals.
+func WithUlamSpiral(start uint64) RenderOption {
+	return func(o *renderOptions) {
+		o.lo, o.ranged, o.ulam = start, false, true
+	}
+}
+
+// WithPBM writes the image in the binary portable bitmap format (P4) instead of PNG.
+func WithPBM() RenderOption {
+	return func(o *renderOptions) {
+		o.pbm = true
+	}
+}
+
+// Render writes an image of the given width with a black pixel for every prime and a white one for every other
+// number. By default, it shows the numbers from 0 on row by row in a square, clipped at the end of the set, which
+// can be changed with WithRange or WithUlamSpiral. The pixels are taken from the prime bits directly. If the numbers
+// exceed the set, an error wrapping ErrOutOfRange is returned. Render panics if width < 1.
+func (s *set) Render(w io.Writer, width int, opts ...RenderOption) error {
+	return render(w, width, opts, s.largestNumber, s.Iterator, s.isPrime)
+}
+
+// render writes the image of Render for a set reaching up to largestNumber, whose primes are given by an iterator
+// and a primality test.
+func render(w io.Writer, width int, opts []RenderOption, largestNumber uint64, iterator func(start uint64) Iterator,
+	isPrime func(n uint64) bool) error {
+	if width < 1 {
+		panic("image width must be positive")
+	}
+	var o renderOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	size := uint64(width) * uint64(width)
+	if !o.ranged && !o.ulam {
+		o.hi = min(size-1, largestNumber)
+	}
+	if o.ulam {
+		o.hi = o.lo + size - 1
+	}
+	if o.hi < o.lo || o.hi > largestNumber {
+		return fmt.Errorf("%w: image of [%d, %d] exceeds the set reaching up to %d", ErrOutOfRange, o.lo, o.hi,
+			largestNumber)
+	}
+
+	var img *image.Gray
+	if o.ulam {
+		img = renderUlamSpiral(width, o.lo, isPrime)
+	} else {
+		img = renderRows(width, o.lo, o.hi, iterator(o.lo))
+	}
+	if o.pbm {
+		return writePBM(w, img)
+	}
+	return png.Encode(w, img)
+}
+
+// renderRows returns an image of the numbers in [lo, hi] row by row, painting the primes of an iterator from lo on.
+func renderRows(width int, lo, hi uint64, it Iterator) *image.Gray {
+	height := int((hi-lo)/uint64(width)) + 1
+	img := newWhiteImage(width, height)
+	for p, ok := it.Next(); ok && p <= hi; p, ok = it.Next() {
+		i := p - lo
+		img.Pix[int(i/uint64(width))*img.Stride+int(i%uint64(width))] = 0
+	}
+	return img
+}
+
+// renderUlamSpiral returns an image of width² numbers from start on arranged in an Ulam spiral, painting the numbers
+// isPrime holds. The spiral starts in the center, goes right, up, left twice, down twice, right three times and so on.
+func renderUlamSpiral(width int, start uint64, isPrime func(n uint64) bool) *image.Gray {
+	img := newWhiteImage(width, width)
+	x, y := (width-1)/2, width/2
+	dx, dy := 1, 0
+	n, end := start, start+uint64(width)*uint64(width)
+	for steps := 1; n < end; steps++ {
+		// every step length is used for two directions
+		for turn := 0; turn < 2 && n < end; turn++ {
+			for i := 0; i < steps && n < end; i++ {
+				if isPrime(n) {
+					img.Pix[y*img.Stride+x] = 0
+				}
+				n++
+				x, y = x+dx, y+dy
+			}
+			dx, dy = dy, -dx // counterclockwise with y pointing downwards
+		}
+	}
+	return img
+}
+
+// newWhiteImage returns a gray image of the given size with all pixels white.
+func newWhiteImage(width, height int) *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	return img
+}
+
+// writePBM writes a black and white image in the binary portable bitmap format, where a set bit stands for a black
+// pixel and every row is padded to whole bytes.
+func writePBM(w io.Writer, img *image.Gray) error {
+	bw := bufio.NewWriter(w)
+	width, height := img.Rect.Dx(), img.Rect.Dy()
+	fmt.Fprintf(bw, "P4\n%d %d\n", width, height)
+	row := make([]byte, (width+7)/8)
+	for y := 0; y < height; y++ {
+		clear(row)
+		for x, v := range img.Pix[y*img.Stride : y*img.Stride+width] {
+			if v == 0 {
+				row[x/8] |= 0x80 >> (x % 8)
+			}
+		}
+		bw.Write(row)
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,58 @@
+package primes
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	s := NewPrimeSet(100000)
+	var buf bytes.Buffer
+	if err := s.Render(&buf, 100, WithRange(1000, 30999)); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Bounds() != image.Rect(0, 0, 100, 300) {
+		t.Fatalf("image has bounds %v", img.Bounds())
+	}
+	for n := uint64(1000); n <= 30999; n++ {
+		i := int(n - 1000)
+		if r, _, _, _ := img.At(i%100, i/100).RGBA(); (r == 0) != s.IsPrime(n) {
+			t.Fatalf("pixel of %d is wrong", n)
+		}
+	}
+
+	// the default is a square of the numbers from 0 on
+	buf.Reset()
+	if err := s.Render(&buf, 10, WithPBM()); err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte("P4\n10 10\n" +
+		"\x35\x00\x51\x40\x10\x40\x41\x00\x51\x00\x10\x40\x41\x00\x50\x40\x10\x40\x01\x00")
+	if !bytes.Equal(buf.Bytes(), expected) {
+		t.Errorf("PBM image of 0 to 99 is %q", buf.Bytes())
+	}
+
+	// 5 4 3
+	// 6 1 2
+	// 7 8 9
+	buf.Reset()
+	if err := s.Render(&buf, 3, WithUlamSpiral(1), WithPBM()); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "P4\n3 3\n\xa0\x20\x80"; buf.String() != expected {
+		t.Errorf("Ulam spiral is %q instead of %q", buf.String(), expected)
+	}
+
+	for _, opt := range []RenderOption{WithRange(0, 1<<20), WithRange(5, 4), WithUlamSpiral(1 << 20)} {
+		if err := s.Render(&buf, 3, opt); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("Render beyond the set returns error %v", err)
+		}
+	}
+}
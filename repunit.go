@@ -0,0 +1,46 @@
+package primes
+
+import "math/big"
+
+// IsRepunitPrime returns true iff the repunit (base^n - 1) / (base - 1), i.e. the number consisting of n ones in the
+// given base, is prime. Repunits that fit into an uint64 are tested deterministically; larger ones are probable primes
+// according to big.Int.ProbablyPrime. IsRepunitPrime panics if base < 2.
+func IsRepunitPrime(base uint64, n uint) bool {
+	if base < 2 {
+		panic("repunit base must be at least 2")
+	}
+	if prime, _ := millerRabin(uint64(n)); !prime {
+		// for n = ab, the repunit of length n is divisible by the repunit of length a
+		return false
+	}
+	if base == 2 {
+		return IsMersennePrime(uint64(n))
+	}
+	b := new(big.Int).SetUint64(base)
+	r := new(big.Int).Exp(b, big.NewInt(int64(n)), nil)
+	r.Sub(r, big.NewInt(1))
+	r.Quo(r, b.Sub(b, big.NewInt(1)))
+	if r.IsUint64() {
+		prime, _ := millerRabin(r.Uint64())
+		return prime
+	}
+	return r.ProbablyPrime(20)
+}
+
+// RepunitExponents returns an iterator over all exponents n <= max for which the repunit of length n in the given
+// base is prime. Since only prime lengths are candidates, they are taken from the set, so the iteration also stops at
+// the end of the set. RepunitExponents panics if base < 2.
+func (s *set) RepunitExponents(base, max uint64) Iterator {
+	return s.repunitExponents(base, 0, max)
+}
+
+// repunitExponents returns an iterator over all exponents in [from, max] for which the repunit of that length in the
+// given base is prime. It panics if base < 2.
+func (s *set) repunitExponents(base, from, max uint64) Iterator {
+	if base < 2 {
+		panic("repunit base must be at least 2")
+	}
+	return s.filterUpTo(from, max, func(p uint64) bool {
+		return IsRepunitPrime(base, uint(p))
+	})
+}
@@ -0,0 +1,13 @@
+package primes
+
+import "testing"
+
+func TestRepunitExponents(t *testing.T) {
+	set := NewPrimeSet(1000)
+	testIterator(t, "RepunitExponents(10, 400)", set.RepunitExponents(10, 400), []uint64{2, 19, 23, 317})
+	testIterator(t, "RepunitExponents(2, 130)", set.RepunitExponents(2, 130), []uint64{2, 3, 5, 7, 13, 17, 19, 31, 61, 89, 107, 127})
+	testIterator(t, "RepunitExponents(3, 100)", set.RepunitExponents(3, 100), []uint64{3, 7, 13, 71})
+	if IsRepunitPrime(10, 1) || IsRepunitPrime(10, 4) {
+		t.Error("1 and 1111 should not be repunit primes")
+	}
+}
@@ -0,0 +1,178 @@
+package primes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+// Factorization is the prime factorization of a number.
+type Factorization struct {
+	N       uint64       // factorized number
+	Factors []PrimePower // prime factors in ascending order
+}
+
+// GapReport describes the gap between two consecutive primes.
+type GapReport struct {
+	Start uint64 // prime before the gap
+	End   uint64 // prime after the gap
+}
+
+// Gap returns the size of the gap, i.e. the difference between the two primes.
+func (g GapReport) Gap() uint64 {
+	return g.End - g.Start
+}
+
+// SetStats is a statistical summary of a prime set.
+type SetStats struct {
+	LargestNumber  uint64    // largest number in the set
+	LargestPrime   uint64    // largest prime number in the set
+	PrimeCount     uint64    // number of primes in the set
+	LargestGap     GapReport // first occurrence of the largest gap between consecutive primes
+	AverageGap     float64   // average gap between consecutive primes
+	MemoryUsage    uint      // number of bytes used for the prime bits
+	ResidueCounts4 [4]uint64 // number of primes per residue class modulo 4
+	ResidueCounts6 [6]uint64 // number of primes per residue class modulo 6
+}
+
+// jsonPrimePower is the JSON representation of a PrimePower.
+type jsonPrimePower struct {
+	Prime    uint64 `json:"prime"`
+	Exponent uint   `json:"exponent"`
+}
+
+// MarshalJSON encodes the prime power as an object with the fields prime and exponent.
+func (pp PrimePower) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonPrimePower(pp))
+}
+
+// UnmarshalJSON decodes a prime power, rejecting primes below 2 and zero exponents.
+func (pp *PrimePower) UnmarshalJSON(data []byte) error {
+	var j jsonPrimePower
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	if j.Prime < 2 || j.Exponent == 0 {
+		return fmt.Errorf("primes: invalid prime power %d^%d", j.Prime, j.Exponent)
+	}
+	*pp = PrimePower(j)
+	return nil
+}
+
+// jsonFactorization is the JSON representation of a Factorization.
+type jsonFactorization struct {
+	N       uint64       `json:"n"`
+	Factors []PrimePower `json:"factors"`
+}
+
+// MarshalJSON encodes the factorization as an object with the fields n and factors.
+// A number without prime factors is encoded with an empty array.
+func (f Factorization) MarshalJSON() ([]byte, error) {
+	factors := f.Factors
+	if factors == nil {
+		factors = []PrimePower{}
+	}
+	return json.Marshal(jsonFactorization{f.N, factors})
+}
+
+// UnmarshalJSON decodes a factorization, checking that the factors are in ascending order and multiply to n.
+func (f *Factorization) UnmarshalJSON(data []byte) error {
+	var j jsonFactorization
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	if err := Factorization(j).validate(); err != nil {
+		return err
+	}
+	*f = Factorization(j)
+	return nil
+}
+
+// validate checks that the factors are in ascending order and multiply to N. The primality of the factors is not
+// checked.
+func (f Factorization) validate() error {
+	product, last := uint64(1), uint64(0)
+	for _, pp := range f.Factors {
+		if pp.Prime < 2 || pp.Exponent == 0 {
+			return fmt.Errorf("primes: invalid prime power %d^%d", pp.Prime, pp.Exponent)
+		}
+		if pp.Prime <= last {
+			return errors.New("primes: factors not in ascending order")
+		}
+		last = pp.Prime
+		for i := uint(0); i < pp.Exponent; i++ {
+			hi, lo := bits.Mul64(product, pp.Prime)
+			if hi != 0 {
+				return fmt.Errorf("primes: factors of %d overflow", f.N)
+			}
+			product = lo
+		}
+	}
+	if product != f.N {
+		return fmt.Errorf("primes: factors do not multiply to %d", f.N)
+	}
+	return nil
+}
+
+// jsonGapReport is the JSON representation of a GapReport.
+type jsonGapReport struct {
+	Start uint64 `json:"start"`
+	End   uint64 `json:"end"`
+	Gap   uint64 `json:"gap"`
+}
+
+// MarshalJSON encodes the gap as an object with the fields start, end and gap.
+func (g GapReport) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonGapReport{g.Start, g.End, g.Gap()})
+}
+
+// UnmarshalJSON decodes a gap, checking that it is consistent with its primes.
+func (g *GapReport) UnmarshalJSON(data []byte) error {
+	var j jsonGapReport
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	if j.End < j.Start || j.End-j.Start != j.Gap {
+		return fmt.Errorf("primes: invalid gap %d between %d and %d", j.Gap, j.Start, j.End)
+	}
+	*g = GapReport{j.Start, j.End}
+	return nil
+}
+
+// jsonSetStats is the JSON representation of SetStats.
+type jsonSetStats struct {
+	LargestNumber  uint64    `json:"largestNumber"`
+	LargestPrime   uint64    `json:"largestPrime"`
+	PrimeCount     uint64    `json:"primeCount"`
+	LargestGap     GapReport `json:"largestGap"`
+	AverageGap     float64   `json:"averageGap"`
+	MemoryUsage    uint      `json:"memoryUsage"`
+	ResidueCounts4 [4]uint64 `json:"residueCountsMod4"`
+	ResidueCounts6 [6]uint64 `json:"residueCountsMod6"`
+}
+
+// MarshalJSON encodes the statistics as an object with camel case field names.
+func (s SetStats) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonSetStats(s))
+}
+
+// UnmarshalJSON decodes the statistics, checking that the residue counts add up to the prime count.
+func (s *SetStats) UnmarshalJSON(data []byte) error {
+	var j jsonSetStats
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	var sum4, sum6 uint64
+	for _, c := range j.ResidueCounts4 {
+		sum4 += c
+	}
+	for _, c := range j.ResidueCounts6 {
+		sum6 += c
+	}
+	if sum4 != j.PrimeCount || sum6 != j.PrimeCount {
+		return fmt.Errorf("primes: residue counts do not add up to %d primes", j.PrimeCount)
+	}
+	*s = SetStats(j)
+	return nil
+}
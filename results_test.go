@@ -0,0 +1,63 @@
+package primes
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFactorizationJSON(t *testing.T) {
+	f := Factorization{360, []PrimePower{{2, 3}, {3, 2}, {5, 1}}}
+	data, err := json.Marshal(f)
+	if err != nil || string(data) != `{"n":360,"factors":[{"prime":2,"exponent":3},{"prime":3,"exponent":2},{"prime":5,"exponent":1}]}` {
+		t.Errorf("unexpected JSON %s, %v", data, err)
+	}
+	var g Factorization
+	if err := json.Unmarshal(data, &g); err != nil || g.N != 360 || len(g.Factors) != 3 || g.Factors[1] != (PrimePower{3, 2}) {
+		t.Errorf("unexpected factorization %v, %v", g, err)
+	}
+	if data, _ := json.Marshal(Factorization{N: 1}); string(data) != `{"n":1,"factors":[]}` {
+		t.Errorf("unexpected JSON %s for 1", data)
+	}
+	for _, invalid := range []string{
+		`{"n":12,"factors":[{"prime":2,"exponent":1},{"prime":3,"exponent":1}]}`,
+		`{"n":6,"factors":[{"prime":3,"exponent":1},{"prime":2,"exponent":1}]}`,
+		`{"n":6,"factors":[{"prime":6,"exponent":0}]}`,
+		`{"n":0,"factors":[{"prime":4294967296,"exponent":2}]}`,
+	} {
+		if err := json.Unmarshal([]byte(invalid), &g); err == nil {
+			t.Errorf("invalid factorization %s accepted", invalid)
+		}
+	}
+}
+
+func TestGapReportJSON(t *testing.T) {
+	data, err := json.Marshal(GapReport{113, 127})
+	if err != nil || string(data) != `{"start":113,"end":127,"gap":14}` {
+		t.Errorf("unexpected JSON %s, %v", data, err)
+	}
+	var g GapReport
+	if err := json.Unmarshal(data, &g); err != nil || g != (GapReport{113, 127}) {
+		t.Errorf("unexpected gap %v, %v", g, err)
+	}
+	if err := json.Unmarshal([]byte(`{"start":113,"end":127,"gap":12}`), &g); err == nil {
+		t.Error("inconsistent gap accepted")
+	}
+}
+
+func TestSetStatsJSON(t *testing.T) {
+	s := SetStats{LargestNumber: 11, LargestPrime: 11, PrimeCount: 5, LargestGap: GapReport{7, 11}, AverageGap: 2.25, MemoryUsage: 8,
+		ResidueCounts4: [4]uint64{0, 2, 1, 2}, ResidueCounts6: [6]uint64{0, 1, 1, 1, 0, 2}}
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var u SetStats
+	if err := json.Unmarshal(data, &u); err != nil || u != s {
+		t.Errorf("unexpected statistics %v, %v from %s", u, err, data)
+	}
+	s.PrimeCount = 6
+	data, _ = json.Marshal(s)
+	if err := json.Unmarshal(data, &u); err == nil {
+		t.Error("inconsistent residue counts accepted")
+	}
+}
@@ -0,0 +1,58 @@
+//go:build roaring
+
+package primes
+
+import (
+	"fmt"
+
+	"github.com/RoaringBitmap/roaring/roaring64"
+	"github.com/docwalter/primes/bitset"
+)
+
+// This file is only built with the build tag roaring, so the package does not depend on the roaring bitmap library
+// otherwise.
+
+// ToRoaring returns a roaring bitmap which contains all primes of the set, so they can be combined with other bitmap
+// data.
+func ToRoaring(s Set) *roaring64.Bitmap {
+	b := roaring64.New()
+	batch := make([]uint64, 0, 4096)
+	it := s.Iterator(0)
+	for p, ok := it.Next(); ok; p, ok = it.Next() {
+		if batch = append(batch, p); len(batch) == cap(batch) {
+			b.AddMany(batch)
+			batch = batch[:0]
+		}
+	}
+	b.AddMany(batch)
+	return b
+}
+
+// FromRoaring creates a prime set from a roaring bitmap which contains exactly the primes up to its maximum, e.g.
+// a bitmap created by ToRoaring. Apart from a quick plausibility check, this is not verified, so use NewPrimeSet for
+// untrusted data. Like the sets of NewPrimeSet, the set may reach a bit beyond the maximum.
+func FromRoaring(b *roaring64.Bitmap) (Set, error) {
+	if b.IsEmpty() || !b.Contains(2) || !b.Contains(3) {
+		return nil, fmt.Errorf("primes: bitmap does not start with the primes 2 and 3")
+	}
+	limit := max(b.Maximum(), 5)
+	bits := make(bitset.BitSet, setWords(limit))
+	it := b.Iterator()
+	for it.HasNext() {
+		n := it.Next()
+		if n == 2 {
+			continue
+		}
+		if n < 2 || n&1 == 0 || n%3 == 0 && n != 3 {
+			return nil, fmt.Errorf("primes: bitmap contains the composite number %d", n)
+		}
+		bits.Set(numberToIndex(n))
+	}
+	// complete the numbers beyond the maximum of the bitmap
+	for i := numberToIndex(limit) + 1; i < bits.Len(); i++ {
+		if prime, _ := millerRabin(indexToNumber(i)); prime {
+			bits.Set(i)
+		}
+	}
+	return newSet(bits, nil), nil
+}
@@ -0,0 +1,38 @@
+//go:build roaring
+
+package primes
+
+import (
+	"testing"
+
+	"github.com/RoaringBitmap/roaring/roaring64"
+)
+
+func TestRoaring(t *testing.T) {
+	set := NewPrimeSet(100000)
+	b := ToRoaring(set)
+	if b.GetCardinality() != 9594 || b.Maximum() != set.LargestPrime() {
+		t.Errorf("ToRoaring() has %d primes up to %d", b.GetCardinality(), b.Maximum())
+	}
+
+	b.RemoveRange(50000, 1<<64-1)
+	s, err := FromRoaring(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.LargestNumber() < 49999 {
+		t.Errorf("FromRoaring() reaches up to %d only", s.LargestNumber())
+	}
+	for n := uint64(0); n <= s.LargestNumber(); n++ {
+		if s.IsPrime(n) != set.IsPrime(n) {
+			t.Fatalf("FromRoaring() differs at %d", n)
+		}
+	}
+
+	if _, err := FromRoaring(roaring64.BitmapOf(2, 3, 5, 9)); err == nil {
+		t.Error("FromRoaring() should reject 9")
+	}
+	if _, err := FromRoaring(roaring64.BitmapOf(5, 7)); err == nil {
+		t.Error("FromRoaring() should reject a bitmap without 2 and 3")
+	}
+}
@@ -0,0 +1,59 @@
+package primes
+
+import "math"
+
+// Sqrt returns the integer square root of n, i.e. the largest integer r with r^2 <= n. Unlike math.Sqrt, it is exact for
+// all uint64 values.
+func Sqrt(n uint64) uint64 {
+	if n == 0 {
+		return 0
+	}
+	r := uint64(1) << ((64 - numberOfLeadingZeroes(n) + 1) >> 1)
+	for {
+		// Newton iteration from above
+		s := (r + n/r) >> 1
+		if s >= r {
+			break
+		}
+		r = s
+	}
+	for r*r > n {
+		r--
+	}
+	return r
+}
+
+// Root returns the integer k-th root of n, i.e. the largest integer r with r^k <= n. Root panics if k is 0.
+func Root(n uint64, k uint) uint64 {
+	switch {
+	case k == 0:
+		panic("root exponent must not be 0")
+	case k == 1 || n < 2:
+		return n
+	case k == 2:
+		return Sqrt(n)
+	case k >= 64:
+		return 1
+	}
+	// start from the floating point estimate and correct it by at most a few steps
+	r := uint64(math.Pow(float64(n), 1/float64(k)))
+	for r > 0 && powExceeds(r, k, n) {
+		r--
+	}
+	for !powExceeds(r+1, k, n) {
+		r++
+	}
+	return r
+}
+
+// powExceeds returns true iff r^k > n, without overflowing.
+func powExceeds(r uint64, k uint, n uint64) bool {
+	p := uint64(1)
+	for i := uint(0); i < k; i++ {
+		if r != 0 && p > n/r {
+			return true
+		}
+		p *= r
+	}
+	return p > n
+}
@@ -0,0 +1,33 @@
+package primes
+
+import "testing"
+
+func TestSqrt(t *testing.T) {
+	for _, c := range []struct {
+		n, root uint64
+	}{
+		{0, 0}, {1, 1}, {3, 1}, {4, 2}, {99, 9}, {100, 10},
+		{9223372030926249001, 3037000499}, {9223372030926249000, 3037000498},
+		{18446744065119617025, 4294967295}, {18446744073709551615, 4294967295},
+	} {
+		if root := Sqrt(c.n); root != c.root {
+			t.Errorf("Sqrt(%d) = %d instead of %d", c.n, root, c.root)
+		}
+	}
+}
+
+func TestRoot(t *testing.T) {
+	for _, c := range []struct {
+		n    uint64
+		k    uint
+		root uint64
+	}{
+		{0, 3, 0}, {1, 5, 1}, {26, 3, 2}, {27, 3, 3}, {18446744073709551615, 2, 4294967295},
+		{18446744073709551615, 3, 2642245}, {18446744073709551615, 63, 2}, {18446744073709551615, 64, 1},
+		{12157665459056928800, 40, 2}, {12157665459056928801, 40, 3},
+	} {
+		if root := Root(c.n, c.k); root != c.root {
+			t.Errorf("Root(%d, %d) = %d instead of %d", c.n, c.k, root, c.root)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package primes
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// DHParams are the group parameters for a Diffie-Hellman key exchange.
+type DHParams struct {
+	P *big.Int // safe prime modulus p = 2q + 1 with a prime q
+	G *big.Int // generator of the subgroup of prime order q
+}
+
+// IsSafePrime returns true iff p and (p - 1) / 2 are both probable primes, using IsProbablePrime with 20 rounds.
+// An error is only returned if reading from rnd fails.
+func IsSafePrime(p *big.Int, rnd io.Reader) (bool, error) {
+	if p.Cmp(big.NewInt(5)) < 0 {
+		return false, nil
+	}
+	q := new(big.Int).Rsh(p, 1)
+	if prime, err := IsProbablePrime(q, 20, rnd); !prime || err != nil {
+		return false, err
+	}
+	return IsProbablePrime(p, 20, rnd)
+}
+
+// GenerateDHParams returns Diffie-Hellman parameters with a random safe prime p of the given number of bits and the
+// generator 2. Since p = 23 mod 24, 2 is a quadratic residue and therefore generates the subgroup of prime order
+// (p - 1) / 2, so that no information about a private exponent leaks through the small subgroups.
+// Randomness is read from rnd, or from crypto/rand.Reader if rnd is nil. Fewer than 16 bits are rejected with an error
+// wrapping ErrLimitTooSmall.
+func GenerateDHParams(bits int, rnd io.Reader) (DHParams, error) {
+	if bits < 16 {
+		return DHParams{}, fmt.Errorf("%w: Diffie-Hellman parameters need at least 16 bits, not %d", ErrLimitTooSmall,
+			bits)
+	}
+	if rnd == nil {
+		rnd = rand.Reader
+	}
+	p, err := randomSafePrime(bits, rnd)
+	if err != nil {
+		return DHParams{}, err
+	}
+	params := DHParams{p, big.NewInt(2)}
+	if err := params.Validate(rnd); err != nil {
+		return DHParams{}, err
+	}
+	return params, nil
+}
+
+// Validate checks that P is a safe prime and that G generates the subgroup of prime order (P - 1) / 2.
+// Randomness for the primality tests is read from rnd, or from crypto/rand.Reader if rnd is nil. A generator outside
+// of [2, P - 2] is reported with an error wrapping ErrOutOfRange, other invalid parameters with ErrCorrupt.
+func (d DHParams) Validate(rnd io.Reader) error {
+	if rnd == nil {
+		rnd = rand.Reader
+	}
+	safe, err := IsSafePrime(d.P, rnd)
+	if err != nil {
+		return err
+	}
+	if !safe {
+		return fmt.Errorf("%w: %s is not a safe prime", ErrCorrupt, d.P)
+	}
+	one := big.NewInt(1)
+	if d.G.Cmp(one) <= 0 || d.G.Cmp(new(big.Int).Sub(d.P, one)) >= 0 {
+		return fmt.Errorf("%w: generator %s", ErrOutOfRange, d.G)
+	}
+	q := new(big.Int).Rsh(d.P, 1)
+	if new(big.Int).Exp(d.G, q, d.P).Cmp(one) != 0 {
+		return fmt.Errorf("%w: %s does not generate the subgroup of order %s", ErrCorrupt, d.G, q)
+	}
+	return nil
+}
+
+// randomSafePrime returns a random safe prime p = 2q + 1 = 23 mod 24 with the given number of bits. The candidates
+// for q are walked in steps of 12 and sieved so that neither q nor p has a factor up to 4096 before any
+// Miller-Rabin test is done.
+func randomSafePrime(bits int, rnd io.Reader) (*big.Int, error) {
+	initTrialPrimes()
+	residues := make([]uint64, len(trialPrimes))
+	m := new(big.Int)
+	for {
+		q, err := rand.Int(rnd, new(big.Int).Lsh(big.NewInt(1), uint(bits-2)))
+		if err != nil {
+			return nil, err
+		}
+		q.SetBit(q, bits-2, 1)
+		q.Sub(q, m.Mod(q, big.NewInt(12))).Add(q, big.NewInt(11)) // q = 11 mod 12
+		for i, p := range trialPrimes {
+			residues[i] = m.Mod(q, m.SetUint64(p)).Uint64()
+		}
+		for delta := uint64(0); delta < 1<<20; delta += 12 {
+			candidate := true
+			for i, p := range trialPrimes {
+				r := (residues[i] + delta) % p
+				if r == 0 || (2*r+1)%p == 0 {
+					candidate = false
+					break
+				}
+			}
+			if !candidate {
+				continue
+			}
+			c := new(big.Int).Add(q, m.SetUint64(delta))
+			p := new(big.Int).Lsh(c, 1)
+			p.SetBit(p, 0, 1)
+			if p.BitLen() != bits {
+				break
+			}
+			safe, err := IsSafePrime(p, rnd)
+			if err != nil {
+				return nil, err
+			}
+			if safe {
+				return p, nil
+			}
+		}
+	}
+}
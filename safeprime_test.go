@@ -0,0 +1,44 @@
+package primes
+
+import (
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestIsSafePrime(t *testing.T) {
+	rnd := rand.New(rand.NewSource(7))
+	for _, c := range []struct {
+		p    int64
+		safe bool
+	}{
+		{2, false}, {5, true}, {7, true}, {11, true}, {13, false}, {23, true}, {29, false}, {1019, true}, {1021, false},
+	} {
+		if safe, err := IsSafePrime(big.NewInt(c.p), rnd); err != nil || safe != c.safe {
+			t.Errorf("IsSafePrime(%d) = %t, %v instead of %t", c.p, safe, err, c.safe)
+		}
+	}
+}
+
+func TestGenerateDHParams(t *testing.T) {
+	rnd := rand.New(rand.NewSource(8))
+	for _, bits := range []int{16, 64, 256} {
+		params, err := GenerateDHParams(bits, rnd)
+		if err != nil {
+			t.Fatalf("GenerateDHParams(%d) failed: %v", bits, err)
+		}
+		if params.P.BitLen() != bits || params.G.Int64() != 2 {
+			t.Errorf("GenerateDHParams(%d) = %s, %s", bits, params.P, params.G)
+		}
+	}
+	if err := (DHParams{big.NewInt(23), big.NewInt(5)}).Validate(rnd); !errors.Is(err, ErrCorrupt) {
+		t.Error("5 generates the full group modulo 23 and should be rejected")
+	}
+	if err := (DHParams{big.NewInt(29), big.NewInt(4)}).Validate(rnd); err == nil {
+		t.Error("29 is not a safe prime and should be rejected")
+	}
+	if _, err := GenerateDHParams(8, rnd); !errors.Is(err, ErrLimitTooSmall) {
+		t.Error("GenerateDHParams(8) should fail")
+	}
+}
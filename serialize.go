@@ -0,0 +1,120 @@
+package primes
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+// Binary format of a serialized factorizer, all numbers in little endian byte order:
+//
+//	magic        4 bytes  "PFAC"
+//	version      uint32   1
+//	set limit    uint64   largest number of the owning prime set
+//	max          uint64   largest number of the factorizer
+//	width        uint32   4 or 8, size of a table entry in bytes
+//	entries      uint64   number of table entries
+//	table        entries * width bytes
+//	checksum     uint32   CRC-32 (IEEE) of all preceding bytes
+const (
+	factorizerMagic   = "PFAC"
+	factorizerVersion = 1
+)
+
+// errInvalidFactorizer is returned for data that is not a valid serialized factorizer.
+var errInvalidFactorizer = fmt.Errorf("%w: invalid factorizer data", ErrCorrupt)
+
+// factorizerHeader is the fixed-size header of a serialized factorizer.
+type factorizerHeader struct {
+	Magic    [4]byte
+	Version  uint32
+	SetLimit uint64
+	Max      uint64
+	Width    uint32
+	Entries  uint64
+}
+
+// WriteTo writes the factorizer in a binary format to w, which can be read again with ReadFactorizer of the same set.
+func (f *factorizer) WriteTo(w io.Writer) (int64, error) {
+	crc := crc32.NewIEEE()
+	bw := bufio.NewWriter(io.MultiWriter(w, crc))
+	width := uint32(8)
+	entries := uint64(len(f.factors.wide))
+	if f.factors.narrow != nil {
+		width, entries = 4, uint64(len(f.factors.narrow))
+	}
+	h := factorizerHeader{Version: factorizerVersion, SetLimit: f.set.largestNumber, Max: f.largestNumber, Width: width, Entries: entries}
+	copy(h.Magic[:], factorizerMagic)
+	if err := binary.Write(bw, binary.LittleEndian, &h); err != nil {
+		return 0, err
+	}
+	var buf [8]byte
+	for i := uint(0); i < uint(entries); i++ {
+		binary.LittleEndian.PutUint64(buf[:], f.factors.get(i))
+		if _, err := bw.Write(buf[:width]); err != nil {
+			return 0, err
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return 0, err
+	}
+	if err := binary.Write(w, binary.LittleEndian, crc.Sum32()); err != nil {
+		return 0, err
+	}
+	return int64(binary.Size(h)) + int64(entries)*int64(width) + 4, nil
+}
+
+// ReadFactorizer reads a factorizer written by WriteTo. The factorizer must have been built from a set with the same
+// limit, and its checksum must be correct, otherwise an error wrapping ErrCorrupt is returned. The options are
+// applied as for Set.Factorizer.
+func (s *set) ReadFactorizer(r io.Reader, opts ...FactorizerOption) (Factorizer, error) {
+	crc := crc32.NewIEEE()
+	br := io.TeeReader(bufio.NewReader(r), crc)
+	var h factorizerHeader
+	if err := binary.Read(br, binary.LittleEndian, &h); err != nil {
+		return nil, err
+	}
+	if string(h.Magic[:]) != factorizerMagic || h.Version != factorizerVersion {
+		return nil, errInvalidFactorizer
+	}
+	if h.SetLimit != s.largestNumber {
+		return nil, fmt.Errorf("%w: factorizer belongs to a set up to %d, not %d", ErrCorrupt, h.SetLimit, s.largestNumber)
+	}
+	if h.Max > s.largestNumber {
+		// checked before the table is allocated, since the header may be forged
+		return nil, fmt.Errorf("%w: factorizer maximum %d exceeds the prime set reaching up to %d", ErrOutOfRange, h.Max,
+			s.largestNumber)
+	}
+	expectedWidth := uint32(8)
+	if h.Max < 1<<32 {
+		expectedWidth = 4
+	}
+	if h.Width != expectedWidth || h.Entries != uint64(numberToIndex(h.Max))+1 {
+		return nil, errInvalidFactorizer
+	}
+	factors := newFactorTable(uint(h.Entries), h.Max)
+	var err error
+	if factors.narrow != nil {
+		err = binary.Read(br, binary.LittleEndian, factors.narrow)
+	} else {
+		err = binary.Read(br, binary.LittleEndian, factors.wide)
+	}
+	if err != nil {
+		return nil, err
+	}
+	sum := crc.Sum32()
+	var checksum uint32
+	if err := binary.Read(br, binary.LittleEndian, &checksum); err != nil {
+		return nil, err
+	}
+	if checksum != sum {
+		return nil, fmt.Errorf("%w: factorizer checksum %#x instead of %#x", ErrCorrupt, checksum, sum)
+	}
+	f := &factorizer{set: s, factors: factors, largestNumber: h.Max}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f, nil
+}
@@ -0,0 +1,51 @@
+package primes
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+func TestFactorizerSerialization(t *testing.T) {
+	set := NewPrimeSet(100000)
+	f := set.Factorizer(100000)
+	var buf bytes.Buffer
+	n, err := f.WriteTo(&buf)
+	if err != nil || n != int64(buf.Len()) {
+		t.Fatalf("WriteTo returned %d, %v for %d bytes", n, err, buf.Len())
+	}
+	data := buf.Bytes()
+	g, err := set.ReadFactorizer(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := uint64(0); i <= 100000; i++ {
+		f1, ok1 := f.LargestFactorOf(i)
+		f2, ok2 := g.LargestFactorOf(i)
+		if f1 != f2 || ok1 != ok2 {
+			t.Fatalf("LargestFactorOf(%d) = %d after reading instead of %d", i, f2, f1)
+		}
+	}
+
+	corrupted := append([]byte(nil), data...)
+	corrupted[1000] ^= 1
+	if _, err := set.ReadFactorizer(bytes.NewReader(corrupted)); !errors.Is(err, ErrCorrupt) {
+		t.Error("corrupted factorizer accepted")
+	}
+	if _, err := set.ReadFactorizer(bytes.NewReader(data[:len(data)-1])); err == nil {
+		t.Error("truncated factorizer accepted")
+	}
+	if _, err := NewPrimeSet(1000).ReadFactorizer(bytes.NewReader(data)); !errors.Is(err, ErrCorrupt) {
+		t.Error("factorizer of another set accepted")
+	}
+
+	// a consistent header of a huge factorizer must be rejected before the table is allocated
+	forged := append([]byte(nil), data...)
+	binary.LittleEndian.PutUint64(forged[16:], 1<<40)
+	binary.LittleEndian.PutUint32(forged[24:], 8)
+	binary.LittleEndian.PutUint64(forged[28:], uint64(numberToIndex(1<<40))+1)
+	if _, err := set.ReadFactorizer(bytes.NewReader(forged)); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("huge factorizer read with %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package primes
+
+import "github.com/docwalter/primes/bitset"
+
+// sieveSegmentWords is the size of a segment of the streaming sieve in words, chosen to fit into the L2 cache.
+const sieveSegmentWords = 4096
+
+// SievePrimes calls fn for all primes up to limit in ascending order, until fn returns false. It runs a segmented
+// sieve over the odd numbers and discards each segment after the primes in it are passed on, so apart from the
+// sieving primes up to sqrt(limit), it needs only constant memory. Use it instead of a Set if the primes are needed
+// only once, e.g. to write them to a file.
+func SievePrimes(limit uint64, fn func(p uint64) bool) {
+	SievePrimesRange(0, limit, fn)
+}
+
+// SievePrimesRange calls fn for all primes in [lo, hi] in ascending order like SievePrimes, until fn returns false.
+// Only the segments from lo on are sieved, so a range far from zero costs about as much as its length.
+func SievePrimesRange(lo, hi uint64, fn func(p uint64) bool) {
+	if hi < 2 || lo > hi || lo <= 2 && !fn(2) {
+		return
+	}
+	var sieving []uint32 // odd sieving primes up to sqrt(hi)
+	if root := Sqrt(hi); root >= 3 {
+		it := NewPrimeSet(max(root, 5)).Iterator(3)
+		for p, ok := it.Next(); ok && p <= root; p, ok = it.Next() {
+			sieving = append(sieving, uint32(p))
+		}
+	}
+
+	bits := make(bitset.BitSet, sieveSegmentWords)
+	const span = sieveSegmentWords << 7 // numbers covered by a segment, bit i stands for start+2i
+	start := max(lo|1, 3)               // smallest odd number of the segment
+	if start > hi {
+		return
+	}
+	for ; ; start += span {
+		end := hi // largest number in the segment
+		if hi-start >= span {
+			end = start + span - 1
+		}
+		bits.SetAll()
+		for _, q := range sieving {
+			p := uint64(q)
+			if p*p > end {
+				break
+			}
+			m := p * p // first odd multiple of p in the segment, which is not p itself
+			if m < start {
+				off := (p - start%p) % p
+				if off&1 != 0 {
+					// start is odd, so an odd offset leads to an even multiple
+					off += p
+				}
+				if off > end-start {
+					continue
+				}
+				m = start + off
+			}
+			for ; m <= end; m += 2 * p {
+				bits.Clear(uint((m - start) >> 1))
+				if end-m < 2*p {
+					break
+				}
+			}
+		}
+		count := uint((end-start)>>1) + 1
+		for i, found := bits.NextSet(0); found && i < count; i, found = bits.NextSet(i + 1) {
+			if !fn(start + uint64(i)<<1) {
+				return
+			}
+		}
+		if end == hi {
+			return
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package primes
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSievePrimes(t *testing.T) {
+	const limit = 3000000 // several segments
+	set := NewPrimeSet(limit)
+	it := set.Iterator(0)
+	count := 0
+	SievePrimes(limit, func(p uint64) bool {
+		if q, _ := it.Next(); p != q {
+			t.Fatalf("SievePrimes() yields %d instead of %d", p, q)
+		}
+		count++
+		return true
+	})
+	if q, ok := it.Next(); ok && q <= limit {
+		t.Errorf("SievePrimes() stops before %d", q)
+	}
+	if count != 216816 {
+		t.Errorf("SievePrimes() yields %d primes instead of 216816", count)
+	}
+
+	small := []struct {
+		limit    uint64
+		expected []uint64
+	}{
+		{0, nil},
+		{1, nil},
+		{2, []uint64{2}},
+		{3, []uint64{2, 3}},
+		{30, []uint64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29}},
+		{31, []uint64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31}},
+	}
+	for _, tt := range small {
+		var actual []uint64
+		SievePrimes(tt.limit, func(p uint64) bool {
+			actual = append(actual, p)
+			return true
+		})
+		if !slices.Equal(actual, tt.expected) {
+			t.Errorf("SievePrimes(%d) yields %v, expected %v", tt.limit, actual, tt.expected)
+		}
+	}
+}
+
+func TestSievePrimesStop(t *testing.T) {
+	var actual []uint64
+	SievePrimes(1000, func(p uint64) bool {
+		actual = append(actual, p)
+		return p < 7
+	})
+	if expected := []uint64{2, 3, 5, 7}; !slices.Equal(actual, expected) {
+		t.Errorf("SievePrimes() yields %v, expected %v", actual, expected)
+	}
+}
+
+func TestSievePrimesRange(t *testing.T) {
+	set := NewPrimeSet(2000000)
+	span := uint64(sieveSegmentWords << 7)
+	for _, r := range [][2]uint64{{0, 100}, {2, 2}, {4, 4}, {8, 10}, {24, 30}, {999, 1000000}, {span, 2 * span},
+		{span + 2, span + 2}, {1500000, 2000000}} {
+		var actual, expected []uint64
+		SievePrimesRange(r[0], r[1], func(p uint64) bool {
+			actual = append(actual, p)
+			return true
+		})
+		set.ForEach(r[0], r[1], func(p uint64) bool {
+			expected = append(expected, p)
+			return true
+		})
+		if !slices.Equal(actual, expected) {
+			t.Errorf("SievePrimesRange(%d, %d) yields %d primes, expected %d", r[0], r[1], len(actual), len(expected))
+		}
+	}
+
+	// far from zero, the primes are checked with Miller-Rabin
+	lo, hi := uint64(1)<<50, uint64(1)<<50+100000
+	count := 0
+	SievePrimesRange(lo, hi, func(p uint64) bool {
+		if !IsPrime(p) {
+			t.Fatalf("SievePrimesRange() yields the composite %d", p)
+		}
+		count++
+		return true
+	})
+	for n := lo; n <= hi; n++ {
+		if IsPrime(n) {
+			count--
+		}
+	}
+	if count != 0 {
+		t.Errorf("SievePrimesRange() misses or repeats %d primes", -count)
+	}
+}
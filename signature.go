@@ -0,0 +1,47 @@
+package primes
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Signature returns the prime signature of n, i.e. the exponents of its prime factorization in descending order,
+// e.g. [2 1] for 12 = 2^2·3 and 18 = 2·3^2. The signature of 1 is empty.
+// If the factorizer boundaries are exceeded without a fallback or n is 0, the second result is false.
+func (f *factorizer) Signature(n uint64) ([]uint, bool) {
+	factors, ok := f.Factorize(n)
+	if !ok {
+		return nil, false
+	}
+	signature := make([]uint, len(factors))
+	for i, pp := range factors {
+		signature[i] = pp.Exponent
+	}
+	sort.Slice(signature, func(i, j int) bool { return signature[i] > signature[j] })
+	return signature, true
+}
+
+// GroupBySignature returns the numbers in [lo, hi] grouped by their prime signatures, each group in ascending order.
+// The keys are the exponents of the signatures joined by commas, e.g. "2,1" for 12 and 18, or "" for 1.
+// If a number in the range cannot be factorized, e.g. 0 or a number beyond the boundaries without a fallback, the
+// second result is false.
+func (f *factorizer) GroupBySignature(lo, hi uint64) (map[string][]uint64, bool) {
+	groups := make(map[string][]uint64)
+	var key strings.Builder
+	for n := lo; n <= hi && n >= lo; n++ {
+		signature, ok := f.Signature(n)
+		if !ok {
+			return nil, false
+		}
+		key.Reset()
+		for i, e := range signature {
+			if i > 0 {
+				key.WriteByte(',')
+			}
+			key.WriteString(strconv.FormatUint(uint64(e), 10))
+		}
+		groups[key.String()] = append(groups[key.String()], n)
+	}
+	return groups, true
+}
@@ -0,0 +1,44 @@
+package primes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSignature(t *testing.T) {
+	f := NewPrimeSet(1000).Factorizer(1000)
+	for n, expected := range map[uint64][]uint{1: {}, 2: {1}, 12: {2, 1}, 18: {2, 1}, 360: {3, 2, 1}, 1000: {3, 3},
+		997: {1}, 750: {3, 1, 1}} {
+		if signature, ok := f.Signature(n); !ok || !reflect.DeepEqual(signature, expected) {
+			t.Errorf("Signature(%d) = %v, %v instead of %v", n, signature, ok, expected)
+		}
+	}
+	for _, n := range []uint64{0, 1001} {
+		if signature, ok := f.Signature(n); ok {
+			t.Errorf("Signature(%d) = %v", n, signature)
+		}
+	}
+}
+
+func TestGroupBySignature(t *testing.T) {
+	f := NewPrimeSet(1000).Factorizer(1000)
+	groups, ok := f.GroupBySignature(1, 20)
+	expected := map[string][]uint64{
+		"":    {1},
+		"1":   {2, 3, 5, 7, 11, 13, 17, 19},
+		"2":   {4, 9},
+		"3":   {8},
+		"4":   {16},
+		"1,1": {6, 10, 14, 15},
+		"2,1": {12, 18, 20},
+	}
+	if !ok || !reflect.DeepEqual(groups, expected) {
+		t.Errorf("GroupBySignature(1, 20) = %v, %v", groups, ok)
+	}
+	if groups, ok := f.GroupBySignature(990, 1010); ok {
+		t.Errorf("GroupBySignature beyond the factorizer = %v", groups)
+	}
+	if groups, ok := f.GroupBySignature(0, 10); ok {
+		t.Errorf("GroupBySignature including 0 = %v", groups)
+	}
+}
@@ -0,0 +1,32 @@
+package primes
+
+// IsSmooth returns true iff a given number has no prime factor larger than b. The number 1 is smooth for every b.
+// If LargestFactorOf cannot determine the largest prime factor of n or n is 0, the second result is false.
+func (f *factorizer) IsSmooth(n, b uint64) (bool, bool) {
+	if n == 1 {
+		return true, true
+	}
+	p, ok := f.LargestFactorOf(n)
+	if !ok {
+		return false, false
+	}
+	return p <= b, true
+}
+
+// SmoothNumbers returns an iterator over all b-smooth numbers up to max in ascending order, starting with 1.
+// The iteration stops at the largest number of the factorizer if max exceeds it.
+func (f *factorizer) SmoothNumbers(b, max uint64) Iterator {
+	if max > f.largestNumber {
+		max = f.largestNumber
+	}
+	n := uint64(0)
+	return funcIterator(func() (uint64, bool) {
+		for n < max {
+			n++
+			if smooth, _ := f.IsSmooth(n, b); smooth {
+				return n, true
+			}
+		}
+		return 0, false
+	})
+}
@@ -0,0 +1,33 @@
+package primes
+
+import "testing"
+
+func TestIsSmooth(t *testing.T) {
+	f := NewPrimeSet(1000).Factorizer(1000)
+	for _, c := range []struct {
+		n, b   uint64
+		smooth bool
+	}{
+		{1, 0, true}, {2, 2, true}, {2, 1, false}, {3, 2, false}, {96, 3, true}, {97, 89, false}, {1000, 5, true},
+	} {
+		if smooth, ok := f.IsSmooth(c.n, c.b); !ok || smooth != c.smooth {
+			t.Errorf("IsSmooth(%d, %d) = %t instead of %t", c.n, c.b, smooth, c.smooth)
+		}
+	}
+	if _, ok := f.IsSmooth(0, 5); ok {
+		t.Error("IsSmooth(0, 5) should fail")
+	}
+}
+
+func TestSmoothNumbers(t *testing.T) {
+	f := NewPrimeSet(1000).Factorizer(1000)
+	testIterator(t, "SmoothNumbers(3, 30)", f.SmoothNumbers(3, 30), []uint64{1, 2, 3, 4, 6, 8, 9, 12, 16, 18, 24, 27})
+	count := 0
+	it := f.SmoothNumbers(997, 2000)
+	for _, ok := it.Next(); ok; _, ok = it.Next() {
+		count++
+	}
+	if count != 1000 {
+		t.Errorf("SmoothNumbers(997, 2000) returned %d numbers instead of 1000", count)
+	}
+}
@@ -0,0 +1,54 @@
+package primes
+
+// SqrtMod returns a square root r of a modulo the prime p, i.e. r^2 = a mod p, using the Tonelli-Shanks algorithm.
+// Of the two roots r and p - r, the smaller one is returned. If p is not prime or a is not a quadratic residue
+// modulo p, the second result is false.
+func (s *set) SqrtMod(a, p uint64) (uint64, bool) {
+	if !s.isPrimeExtended(p) {
+		return 0, false
+	}
+	a %= p
+	if p == 2 || a == 0 {
+		return a, true
+	}
+	if LegendreSymbol(a, p) != 1 {
+		return 0, false
+	}
+	mg := newMontgomery(p)
+	var r uint64
+	if p&3 == 3 {
+		r = mg.from(mg.pow(mg.to(a), (p+1)>>2))
+	} else {
+		// write p - 1 = q * 2^e with odd q and find a quadratic non-residue z
+		e := numberOfTrailingZeroes(p - 1)
+		q := (p - 1) >> e
+		z := uint64(2)
+		for LegendreSymbol(z, p) != -1 {
+			z++
+		}
+		ma := mg.to(a)
+		c := mg.pow(mg.to(z), q)
+		x := mg.pow(ma, (q+1)>>1)
+		t := mg.pow(ma, q)
+		for t != mg.one {
+			// find the least i with t^(2^i) = 1
+			i := uint(0)
+			for tt := t; tt != mg.one; tt = mg.mul(tt, tt) {
+				i++
+			}
+			b := c
+			for j := uint(1); j < e-i; j++ {
+				b = mg.mul(b, b)
+			}
+			x = mg.mul(x, b)
+			c = mg.mul(b, b)
+			t = mg.mul(t, c)
+			e = i
+		}
+		r = mg.from(x)
+	}
+	if p-r < r {
+		r = p - r
+	}
+	return r, true
+}
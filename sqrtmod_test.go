@@ -0,0 +1,26 @@
+package primes
+
+import "testing"
+
+func TestSqrtMod(t *testing.T) {
+	set := NewPrimeSet(10000)
+	for _, p := range []uint64{2, 3, 5, 13, 17, 97, 257, 7681, 65537, 998244353, 18446744069414584321} {
+		residues := 0
+		for a := uint64(0); a < 200; a++ {
+			r, ok := set.SqrtMod(a, p)
+			if ok != (a%p == 0 || p == 2 || LegendreSymbol(a, p) == 1) {
+				t.Errorf("SqrtMod(%d, %d) returned status %t", a, p, ok)
+			} else if ok && MulMod(r, r, p) != a%p {
+				t.Errorf("SqrtMod(%d, %d) = %d is not a square root", a, p, r)
+			} else if ok {
+				residues++
+			}
+		}
+		if residues == 0 {
+			t.Errorf("no quadratic residues found modulo %d", p)
+		}
+	}
+	if _, ok := set.SqrtMod(4, 15); ok {
+		t.Error("SqrtMod should fail for composite moduli")
+	}
+}
@@ -0,0 +1,31 @@
+package primes
+
+// Stats returns a statistical summary of the set, which is computed in a single pass over all primes.
+func (s *set) Stats() SetStats {
+	return newSetStats(s.Iterator(0), s.largestNumber, s.MemoryUsage())
+}
+
+// newSetStats returns the statistical summary of the primes of an iterator over a set reaching up to largestNumber.
+func newSetStats(it Iterator, largestNumber uint64, memoryUsage uint) SetStats {
+	stats := SetStats{
+		LargestNumber: largestNumber,
+		MemoryUsage:   memoryUsage,
+	}
+	first, prev := uint64(0), uint64(0)
+	for p, ok := it.Next(); ok; p, ok = it.Next() {
+		stats.PrimeCount++
+		stats.ResidueCounts4[p%4]++
+		stats.ResidueCounts6[p%6]++
+		if prev == 0 {
+			first = p
+		} else if p-prev > stats.LargestGap.Gap() {
+			stats.LargestGap = GapReport{prev, p}
+		}
+		prev = p
+	}
+	stats.LargestPrime = prev
+	if stats.PrimeCount > 1 {
+		stats.AverageGap = float64(prev-first) / float64(stats.PrimeCount-1)
+	}
+	return stats
+}
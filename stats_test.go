@@ -0,0 +1,21 @@
+package primes
+
+import "testing"
+
+func TestStats(t *testing.T) {
+	set := NewPrimeSet(1000)
+	stats := set.Stats()
+	expected := SetStats{
+		LargestNumber:  set.LargestNumber(),
+		LargestPrime:   1151,
+		PrimeCount:     190,
+		LargestGap:     GapReport{1129, 1151},
+		AverageGap:     1149.0 / 189.0,
+		MemoryUsage:    set.MemoryUsage(),
+		ResidueCounts4: [4]uint64{0, 92, 1, 97},
+		ResidueCounts6: [6]uint64{0, 92, 1, 1, 0, 96},
+	}
+	if stats != expected {
+		t.Errorf("Stats() = %+v instead of %+v", stats, expected)
+	}
+}
@@ -0,0 +1,225 @@
+package primes
+
+import (
+	"time"
+
+	"github.com/docwalter/primes/bitset"
+)
+
+// Bits is the storage of the prime bits of a set, with one bit for every prime candidate that is not divisible by 2
+// or 3. The sets of NewPrimeSet keep their bits in an uint64 array; other storages, e.g. memory-mapped files or
+// compressed blocks, can be plugged in with NewPrimeSetWithBits and PrimeSetFromBits.
+type Bits interface {
+	Get(i uint) bool             // true iff bit i is set
+	Set(i uint, v bool)          // sets bit i to v
+	NextSet(i uint) (uint, bool) // index of the first set bit at or after i
+	Rank(i uint) uint            // number of set bits with an index below i
+	Len() uint                   // number of bits
+}
+
+// wordBits is the implementation of Bits in an uint64 array, as used by the sets in memory.
+type wordBits []uint64
+
+// Get returns true iff bit i is set.
+func (b wordBits) Get(i uint) bool {
+	return bitset.BitSet(b).Get(i)
+}
+
+// Set sets bit i to v.
+func (b wordBits) Set(i uint, v bool) {
+	if v {
+		bitset.BitSet(b).Set(i)
+	} else {
+		bitset.BitSet(b).Clear(i)
+	}
+}
+
+// NextSet returns the index of the first set bit at or after i.
+func (b wordBits) NextSet(i uint) (uint, bool) {
+	return bitset.BitSet(b).NextSet(i)
+}
+
+// Rank returns the number of set bits with an index below i.
+func (b wordBits) Rank(i uint) uint {
+	return bitset.BitSet(b).Count(0, i)
+}
+
+// Len returns the number of bits.
+func (b wordBits) Len() uint {
+	return uint(len(b)) << 6
+}
+
+// NewPrimeSetWithBits creates a new set of prime numbers up to a given limit like NewPrimeSet, but sieves into the
+// given storage instead of an array in memory. It uses the streaming sieve of SievePrimes, so the storage can be much
+// larger than the available memory. The storage needs at least the bits that NewPrimeSet would allocate, i.e. one
+// per number not divisible by 2 or 3 up to the limit, rounded up to whole 64 bit words; further bits are ignored.
+// Checkpoints are not supported for such sets, while WithRankIndex builds the index from the ranks of the storage
+// after sieving. NewPrimeSetWithBits panics if limit < 5 or if the storage is too small.
+func NewPrimeSetWithBits(limit uint64, b Bits, opts ...Option) Set {
+	if limit < 5 {
+		panic("prime set must have at least a size of 5")
+	}
+	n := setWords(limit) << 6
+	if b.Len() < n {
+		panic("storage is too small for the prime bits")
+	}
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	start := time.Now()
+	for i := uint(0); i < n; i++ {
+		b.Set(i, false)
+	}
+	// the streaming sieve needs only the sieving primes in memory, whatever the size of the storage
+	last := indexToNumber(n - 1)
+	SievePrimes(last, func(p uint64) bool {
+		if p >= 3 {
+			b.Set(numberToIndex(p), true)
+		}
+		return true
+	})
+	s := newStoreSet(limitedBits{b, n}, o.metrics)
+	if o.rankIndex {
+		s.buildRankIndex()
+	}
+	if s.metrics != nil {
+		s.metrics.SetBuilt(limit, time.Since(start))
+	}
+	return s
+}
+
+// PrimeSetFromBits creates a set from completely sieved prime bits in the given storage, e.g. the bits of a set
+// written with WriteTo in a memory-mapped file. The set reaches up to the number of the last bit, so the storage
+// must hold whole 64 bit words. Only the options for metrics and WithRankIndex apply, where the rank index is built
+// from the ranks of the storage. PrimeSetFromBits panics if the storage is empty or does not hold whole words.
+func PrimeSetFromBits(b Bits, opts ...Option) Set {
+	if b.Len() == 0 || b.Len()&63 != 0 {
+		panic("storage must hold whole words of prime bits")
+	}
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	s := newStoreSet(b, o.metrics)
+	if o.rankIndex {
+		s.buildRankIndex()
+	}
+	return s
+}
+
+// newStoreSet creates a set from completely sieved prime bits in another storage than an array in memory.
+func newStoreSet(b Bits, metrics Metrics) *set {
+	s := &set{store: b, metrics: metrics}
+	h, _ := s.prevBit(b.Len() - 1)
+	s.largestPrime = indexToNumber(h)
+	s.largestNumber = indexToNumber(b.Len() - 1)
+	return s
+}
+
+// limitedBits restricts a storage to its first n bits.
+type limitedBits struct {
+	Bits      // underlying storage
+	n    uint // number of bits used
+}
+
+// NextSet returns the index of the first set bit at or after i within the first n bits.
+func (b limitedBits) NextSet(i uint) (uint, bool) {
+	if i, found := b.Bits.NextSet(i); found && i < b.n {
+		return i, true
+	}
+	return 0, false
+}
+
+// Rank returns the number of set bits with an index below i within the first n bits.
+func (b limitedBits) Rank(i uint) uint {
+	return b.Bits.Rank(min(i, b.n))
+}
+
+// Len returns the number of bits used.
+func (b limitedBits) Len() uint {
+	return b.n
+}
+
+// bit returns true iff bit i of the set is set.
+func (s *set) bit(i uint) bool {
+	if s.store != nil {
+		return s.store.Get(i)
+	}
+	return s.bits.Get(i)
+}
+
+// nextBit returns the index of the next set bit of the set, starting from i.
+// If there is no bit set at or after index i, the second result is false.
+func (s *set) nextBit(i uint) (uint, bool) {
+	if s.store != nil {
+		return s.store.NextSet(i)
+	}
+	return s.bits.NextSet(i)
+}
+
+// prevBit returns the index of the previous set bit of the set, starting from i downwards.
+// If there is no bit set at or before index i, the second result is false.
+func (s *set) prevBit(i uint) (uint, bool) {
+	if s.store == nil {
+		return s.bits.PrevSet(i)
+	}
+	k := min(i>>6, s.wordCount()-1)
+	w := s.word(k)
+	if k == i>>6 {
+		w &= ^uint64(0) >> (63 - i&63)
+	}
+	for {
+		if w != 0 {
+			return k<<6 + 63 - numberOfLeadingZeroes(w), true
+		}
+		if k == 0 {
+			return 0, false
+		}
+		k--
+		w = s.word(k)
+	}
+}
+
+// count returns the number of set bits of the set with an index in [from, to).
+func (s *set) count(from, to uint) uint {
+	if s.store != nil {
+		return s.store.Rank(to) - s.store.Rank(from)
+	}
+	if s.rank != nil {
+		return s.rankOf(to) - s.rankOf(from)
+	}
+	return s.bits.Count(from, to)
+}
+
+// wordCount returns the number of 64 bit words of the prime bits.
+func (s *set) wordCount() uint {
+	if s.store != nil {
+		return s.store.Len() >> 6
+	}
+	return uint(len(s.bits))
+}
+
+// word returns the k-th 64 bit word of the prime bits, which is assembled from the set bits for other storages.
+func (s *set) word(k uint) uint64 {
+	if s.store == nil {
+		return s.bits[k]
+	}
+	w := uint64(0)
+	for i, found := s.store.NextSet(k << 6); found && i>>6 == k; i, found = s.store.NextSet(i + 1) {
+		w |= 1 << (i & 63)
+	}
+	return w
+}
+
+// words returns the prime bits as an uint64 array, which is shared with the set unless it uses another storage.
+func (s *set) words() []uint64 {
+	if s.store == nil {
+		return s.bits
+	}
+	words := make([]uint64, s.wordCount())
+	for k := range words {
+		words[k] = s.word(uint(k))
+	}
+	return words
+}
@@ -0,0 +1,133 @@
+package primes
+
+import (
+	"bytes"
+	"testing"
+)
+
+// boolBits is a simple storage with one bool per bit for testing.
+type boolBits []bool
+
+func (b boolBits) Get(i uint) bool    { return b[i] }
+func (b boolBits) Set(i uint, v bool) { b[i] = v }
+func (b boolBits) Len() uint          { return uint(len(b)) }
+func (b boolBits) NextSet(i uint) (uint, bool) {
+	for ; i < uint(len(b)); i++ {
+		if b[i] {
+			return i, true
+		}
+	}
+	return 0, false
+}
+func (b boolBits) Rank(i uint) uint {
+	n := uint(0)
+	for _, v := range b[:min(i, uint(len(b)))] {
+		if v {
+			n++
+		}
+	}
+	return n
+}
+
+func TestNewPrimeSetWithBits(t *testing.T) {
+	expected := NewPrimeSet(100000)
+	// more bits than needed, which must be ignored
+	s := NewPrimeSetWithBits(100000, make(boolBits, 40000))
+	if s.LargestNumber() != expected.LargestNumber() || s.LargestPrime() != expected.LargestPrime() {
+		t.Errorf("set reaches up to %d with largest prime %d", s.LargestNumber(), s.LargestPrime())
+	}
+	if s.Checksum() != expected.Checksum() || !Equal(s, expected) {
+		t.Error("set differs from the set in memory")
+	}
+	for n := uint64(0); n <= s.LargestNumber(); n++ {
+		if s.IsPrime(n) != expected.IsPrime(n) {
+			t.Fatalf("IsPrime(%d) = %v", n, s.IsPrime(n))
+		}
+	}
+	it, eit := s.Iterator(0), expected.Iterator(0)
+	for {
+		p, ok := it.Next()
+		q, qok := eit.Next()
+		if p != q || ok != qok {
+			t.Fatalf("Iterator yields %d, %v instead of %d, %v", p, ok, q, qok)
+		}
+		if !ok {
+			break
+		}
+	}
+	for _, n := range []uint64{0, 1, 2, 24, 90, 99990, 100500} {
+		p, ok := s.ClosestPrime(n)
+		q, qok := expected.ClosestPrime(n)
+		if p != q || ok != qok {
+			t.Errorf("ClosestPrime(%d) = %d, %v instead of %d, %v", n, p, ok, q, qok)
+		}
+		p, ok = s.SumPrimes(n)
+		q, qok = expected.SumPrimes(n)
+		if p != q || ok != qok {
+			t.Errorf("SumPrimes(%d) = %d, %v instead of %d, %v", n, p, ok, q, qok)
+		}
+	}
+	if n := s.CountRange(100, 99990); n != expected.CountRange(100, 99990) {
+		t.Errorf("CountRange() = %d", n)
+	}
+	c := s.Compact()
+	for n := uint64(0); n <= s.LargestNumber(); n += 7 {
+		if c.IsPrime(n) != expected.IsPrime(n) {
+			t.Fatalf("compact set differs at %d", n)
+		}
+	}
+	var buf bytes.Buffer
+	s.WriteTo(&buf)
+	read, err := ReadPrimeSet(&buf)
+	if err != nil || !Equal(read, expected) {
+		t.Errorf("read set differs: %v", err)
+	}
+}
+
+func TestPrimeSetFromBits(t *testing.T) {
+	expected := NewPrimeSet(1000000)
+	s := PrimeSetFromBits(wordBits(expected.(*set).bits))
+	if s.LargestNumber() != expected.LargestNumber() || s.LargestPrime() != expected.LargestPrime() || !Equal(s, expected) {
+		t.Error("set differs from the set in memory")
+	}
+	if p, ok := s.ClosestPrime(7925); !ok || p != 7927 {
+		t.Errorf("ClosestPrime(7925) = %d, %v", p, ok)
+	}
+}
+
+func TestStorageRankIndex(t *testing.T) {
+	expected := NewPrimeSet(100000)
+	for name, s := range map[string]Set{
+		"sieved": NewPrimeSetWithBits(100000, make(boolBits, 40000), WithRankIndex()),
+		"bits":   PrimeSetFromBits(wordBits(expected.(*set).bits), WithRankIndex()),
+	} {
+		if s.(*set).rank == nil {
+			t.Errorf("%s: no rank index", name)
+		}
+		for _, n := range []uint64{1, 3, 100, 1000, 9592, 9593} {
+			p, ok := s.NthPrime(n)
+			q, qok := expected.NthPrime(n)
+			if p != q || ok != qok {
+				t.Errorf("%s: NthPrime(%d) = %d, %v instead of %d, %v", name, n, p, ok, q, qok)
+			}
+		}
+	}
+}
+
+func TestStoragePanics(t *testing.T) {
+	for name, fn := range map[string]func(){
+		"too small": func() { NewPrimeSetWithBits(100000, make(boolBits, 100)) },
+		"limit":     func() { NewPrimeSetWithBits(4, make(boolBits, 64)) },
+		"empty":     func() { PrimeSetFromBits(boolBits{}) },
+		"words":     func() { PrimeSetFromBits(make(boolBits, 100)) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: no panic", name)
+				}
+			}()
+			fn()
+		}()
+	}
+}
@@ -0,0 +1,92 @@
+package primes
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// minStrongPrimeBits is the smallest supported size of strong primes.
+const minStrongPrimeBits = 64
+
+// GenerateStrongPrime returns a random prime p with exactly the given number of bits, for which p - 1 has a large
+// prime factor r, r - 1 has a large prime factor t and p + 1 has a large prime factor s. It follows Gordon's algorithm:
+// starting from random primes s and t, it finds a prime r = 2it + 1 and then a prime p = p0 + 2jrs with
+// p0 = 2(s^(r-2) mod r)s - 1, so that p = 1 mod r and p = -1 mod s. Candidates are prescreened by trial division
+// and tested with 20 Miller-Rabin rounds using randomness from rnd, or from crypto/rand.Reader if rnd is nil. Fewer
+// than 64 bits are rejected with an error wrapping ErrLimitTooSmall.
+func GenerateStrongPrime(bits int, rnd io.Reader) (*big.Int, error) {
+	if bits < minStrongPrimeBits {
+		return nil, fmt.Errorf("%w: strong primes need at least %d bits, not %d", ErrLimitTooSmall, minStrongPrimeBits, bits)
+	}
+	if rnd == nil {
+		rnd = rand.Reader
+	}
+	one := big.NewInt(1)
+	two := big.NewInt(2)
+	for {
+		s, err := randomPrime(bits/2-8, rnd)
+		if err != nil {
+			return nil, err
+		}
+		t, err := randomPrime(bits/2-16, rnd)
+		if err != nil {
+			return nil, err
+		}
+
+		// r = 2it + 1 for the first suitable i from a random 12 bit start
+		i, err := rand.Int(rnd, big.NewInt(1<<12))
+		if err != nil {
+			return nil, err
+		}
+		step := new(big.Int).Lsh(t, 1)
+		r := new(big.Int).Mul(step, i.Add(i, one))
+		for r.Add(r, one); ; r.Add(r, step) {
+			prime, err := IsProbablePrime(r, 20, rnd)
+			if err != nil {
+				return nil, err
+			}
+			if prime {
+				break
+			}
+		}
+
+		// p0 = 2(s^(r-2) mod r)s - 1, then p = p0 + 2jrs with the smallest j that gives the requested size
+		p := new(big.Int).Exp(s, new(big.Int).Sub(r, two), r)
+		p.Mul(p, s).Lsh(p, 1).Sub(p, one)
+		rs2 := new(big.Int).Mul(r, s)
+		rs2.Lsh(rs2, 1)
+		if low := new(big.Int).Lsh(one, uint(bits-1)); p.Cmp(low) < 0 {
+			j := new(big.Int).Sub(low, p)
+			j.Add(j, rs2).Sub(j, one).Quo(j, rs2)
+			p.Add(p, j.Mul(j, rs2))
+		}
+		for p.BitLen() == bits {
+			prime, err := IsProbablePrime(p, 20, rnd)
+			if err != nil {
+				return nil, err
+			}
+			if prime {
+				return p, nil
+			}
+			p.Add(p, rs2)
+		}
+		// the size was exceeded, so start over with other primes s and t
+	}
+}
+
+// randomPrime returns a random probable prime with the given number of bits, which must be at least 2.
+func randomPrime(bits int, rnd io.Reader) (*big.Int, error) {
+	for {
+		n, err := rand.Int(rnd, new(big.Int).Lsh(big.NewInt(1), uint(bits-1)))
+		if err != nil {
+			return nil, err
+		}
+		n.SetBit(n, bits-1, 1)
+		p := NextProbablePrime(n)
+		if p.BitLen() == bits {
+			return p, nil
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package primes
+
+import (
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestGenerateStrongPrime(t *testing.T) {
+	rnd := rand.New(rand.NewSource(6))
+	for _, bits := range []int{64, 65, 128, 512} {
+		p, err := GenerateStrongPrime(bits, rnd)
+		if err != nil {
+			t.Fatalf("GenerateStrongPrime(%d) failed: %v", bits, err)
+		}
+		if p.BitLen() != bits || !p.ProbablyPrime(20) {
+			t.Errorf("GenerateStrongPrime(%d) = %s is not a prime of %d bits", bits, p, bits)
+		}
+		// p - 1 and p + 1 must not be smooth
+		for _, q := range []*big.Int{new(big.Int).Sub(p, big.NewInt(1)), new(big.Int).Add(p, big.NewInt(1))} {
+			for _, small := range []int64{2, 3, 5, 7, 11, 13} {
+				m := big.NewInt(small)
+				for new(big.Int).Mod(q, m).Sign() == 0 {
+					q.Quo(q, m)
+				}
+			}
+			if q.BitLen() < bits/2-20 {
+				t.Errorf("GenerateStrongPrime(%d) = %s has a smooth neighbour", bits, p)
+			}
+		}
+	}
+	if _, err := GenerateStrongPrime(32, rnd); !errors.Is(err, ErrLimitTooSmall) {
+		t.Error("GenerateStrongPrime(32) should fail")
+	}
+	if _, err := GenerateStrongPrime(128, failingReader{}); err == nil {
+		t.Error("GenerateStrongPrime should report read errors")
+	}
+}
@@ -0,0 +1,396 @@
+package primes
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc64"
+	"io"
+	"math/big"
+	"math/bits"
+	"slices"
+)
+
+// Clone returns an independent copy of the set.
+func (s *set) Clone() Set {
+	bits := make([]uint64, s.wordCount())
+	copy(bits, s.words())
+	return &set{bits: bits, rank: slices.Clone(s.rank), largestNumber: s.largestNumber, largestPrime: s.largestPrime,
+		metrics: s.metrics}
+}
+
+// SubSet returns a view of the set which contains only the primes in [lo, hi] and shares the prime bits with the
+// set. All methods of the view that query primes, e.g. Iterator, CountRange, NthPrime, Stats or Render, are bounded
+// to the window. The methods of the arithmetic on numbers, i.e. Explain, the factorizers, FactorizeBig,
+// BinomialFactorization, FactorialFactorization, SmallestFactorOf, SqrtMod, IsPrimePower, HighlyCompositeNumbers,
+// GoldbachCount and GoldbachPartitions, use the whole set, and so do MemoryUsage, Verify, WriteTo and Compact, which
+// concern the shared prime bits.
+// SubSet panics if lo > hi or if hi exceeds the set.
+func (s *set) SubSet(lo, hi uint64) Set {
+	return newSubSet(s, lo, hi)
+}
+
+// subSet is a view of a set bounded to a window of numbers.
+type subSet struct {
+	*set                // underlying set
+	lo, hi       uint64 // boundaries of the window
+	largestPrime uint64 // largest prime in the window, or 0
+}
+
+// newSubSet creates a view of the given set bounded to [lo, hi].
+func newSubSet(s *set, lo, hi uint64) *subSet {
+	if lo > hi {
+		panic("subset must not be empty")
+	}
+	if hi > s.largestNumber {
+		panic("subset must not exceed the set")
+	}
+	v := &subSet{set: s, lo: lo, hi: hi}
+	for n := hi; n >= lo && n >= 2; n-- {
+		if s.IsPrime(n) {
+			v.largestPrime = n
+			break
+		}
+	}
+	return v
+}
+
+// Clone returns an independent copy of the view and the primes of the underlying set.
+func (v *subSet) Clone() Set {
+	return &subSet{set: v.set.Clone().(*set), lo: v.lo, hi: v.hi, largestPrime: v.largestPrime}
+}
+
+// SubSet returns a view of the underlying set bounded to the intersection of both windows. It panics if the
+// windows do not intersect.
+func (v *subSet) SubSet(lo, hi uint64) Set {
+	return newSubSet(v.set, max(lo, v.lo), min(hi, v.hi))
+}
+
+// contains returns true iff n lies within the window.
+func (v *subSet) contains(n uint64) bool {
+	return n >= v.lo && n <= v.hi
+}
+
+// IsPrime returns true iff n is a prime number within the window.
+func (v *subSet) IsPrime(n uint64) bool {
+	return v.contains(n) && v.set.IsPrime(n)
+}
+
+// Iterator returns an iterator over all primes of the window from start on.
+func (v *subSet) Iterator(start uint64) Iterator {
+	return upTo(v.set.Iterator(max(start, v.lo)), v.hi)
+}
+
+// Filter returns an iterator over all primes of the window from start on that fulfil the given predicate.
+func (v *subSet) Filter(start uint64, pred func(p uint64) bool) Iterator {
+	return v.set.filterUpTo(max(start, v.lo), v.hi, pred)
+}
+
+// Composites returns an iterator over all composite numbers of the window from start on.
+func (v *subSet) Composites(start uint64) Iterator {
+	return upTo(v.set.Composites(max(start, v.lo)), v.hi)
+}
+
+// Find returns the first prime of the window from start on that fulfils the given predicate.
+func (v *subSet) Find(start uint64, pred func(p uint64) bool) (uint64, bool) {
+	return v.Filter(start, pred).Next()
+}
+
+// CountRange returns the number of primes of the window in [a, b]. It panics if b exceeds the window.
+func (v *subSet) CountRange(a, b uint64) uint64 {
+	if b > v.hi {
+		panic("range exceeds the subset")
+	}
+	if a = max(a, v.lo); a > b {
+		return 0
+	}
+	return v.set.CountRange(a, b)
+}
+
+// ForEach calls fn for all primes of the window in [start, end] in ascending order, until fn returns false.
+func (v *subSet) ForEach(start, end uint64, fn func(p uint64) bool) {
+	v.set.ForEach(max(start, v.lo), min(end, v.hi), fn)
+}
+
+// ForEachParallel calls fn concurrently for all primes of the window in [start, end], until fn returns false.
+func (v *subSet) ForEachParallel(start, end uint64, fn func(p uint64) bool, workers int) {
+	v.set.ForEachParallel(max(start, v.lo), min(end, v.hi), fn, workers)
+}
+
+// LargestNumber returns the upper boundary of the window.
+func (v *subSet) LargestNumber() uint64 {
+	return v.hi
+}
+
+// LargestPrime returns the largest prime number in the window, or 0 if there is none.
+func (v *subSet) LargestPrime() uint64 {
+	return v.largestPrime
+}
+
+// BrunSum returns the partial sum of Brun's constant over the twin primes of the window.
+func (v *subSet) BrunSum() float64 {
+	return brunSum(v.Iterator(3))
+}
+
+// Checksum returns a CRC-64 checksum of the window and the prime bits within it.
+func (v *subSet) Checksum() uint64 {
+	var buf [16]byte
+	binary.LittleEndian.PutUint64(buf[:8], v.lo)
+	binary.LittleEndian.PutUint64(buf[8:], v.hi)
+	sum := crc64.Update(0, crc64Table, buf[:])
+	first, last := numberToIndex(v.lo), numberToIndex(v.hi)
+	if indexToNumber(first) < v.lo {
+		first++
+	}
+	chunk := make([]byte, 0, 8*1024)
+	for k := first >> 6; k <= last>>6 && first <= last; k++ {
+		w := v.set.word(k)
+		if k == first>>6 {
+			w &= ^uint64(0) << (first & 63)
+		}
+		if k == last>>6 {
+			w &= ^uint64(0) >> (63 - last&63)
+		}
+		if chunk = binary.LittleEndian.AppendUint64(chunk, w); len(chunk) == cap(chunk) {
+			sum = crc64.Update(sum, crc64Table, chunk)
+			chunk = chunk[:0]
+		}
+	}
+	return crc64.Update(sum, crc64Table, chunk)
+}
+
+// CircularPrimes returns an iterator over all circular primes of the window up to max.
+func (v *subSet) CircularPrimes(max uint64) Iterator {
+	return v.set.filterUpTo(v.lo, min(max, v.hi), v.set.IsCircularPrime)
+}
+
+// ClosestPrime returns the prime of the window nearest to n, preferring the smaller one on a tie. If n exceeds the
+// window or there is no prime in it, the second result is false.
+func (v *subSet) ClosestPrime(n uint64) (uint64, bool) {
+	if n > v.hi {
+		return 0, false
+	}
+	below, hasBelow := uint64(0), false // largest prime of the window up to n
+	if n >= 3 {
+		if i, found := v.set.prevBit(numberToIndex(n)); found {
+			below, hasBelow = indexToNumber(i), true
+		}
+	}
+	if !hasBelow && n >= 2 {
+		below, hasBelow = 2, true
+	}
+	hasBelow = hasBelow && below >= v.lo
+	above, hasAbove := v.Iterator(n).Next()
+	switch {
+	case hasBelow && hasAbove:
+		if above-n < n-below {
+			return above, true
+		}
+		return below, true
+	case hasBelow:
+		return below, true
+	default:
+		return above, hasAbove
+	}
+}
+
+// Emirps returns an iterator over all emirps of the window up to max.
+func (v *subSet) Emirps(max uint64) Iterator {
+	return v.set.filterUpTo(v.lo, min(max, v.hi), v.set.IsEmirp)
+}
+
+// FindPrimeAP returns the first arithmetic progression of length primes from start on whose terms all lie in the
+// window. It panics if length < 2.
+func (v *subSet) FindPrimeAP(length int, start uint64) (uint64, uint64, bool) {
+	return v.set.findPrimeAP(length, max(start, v.lo), v.hi)
+}
+
+// FirstOccurrenceGaps maps the gap sizes between consecutive primes of the window up to max to the prime where
+// they first occur.
+func (v *subSet) FirstOccurrenceGaps(max uint64) map[uint64]uint64 {
+	return firstOccurrenceGaps(v.Iterator(0), max)
+}
+
+// IsCircularPrime returns true iff n is a circular prime within the window.
+func (v *subSet) IsCircularPrime(n uint64) bool {
+	return v.contains(n) && v.set.IsCircularPrime(n)
+}
+
+// IsEmirp returns true iff n is an emirp within the window.
+func (v *subSet) IsEmirp(n uint64) bool {
+	return v.contains(n) && v.set.IsEmirp(n)
+}
+
+// IsPermutablePrime returns true iff n is a permutable prime within the window.
+func (v *subSet) IsPermutablePrime(n uint64) bool {
+	return v.contains(n) && v.set.IsPermutablePrime(n)
+}
+
+// MaximalGaps returns all gaps between consecutive primes of the window that are larger than any previous gap.
+func (v *subSet) MaximalGaps() []GapRecord {
+	return maximalGaps(v.Iterator(0))
+}
+
+// MersenneExponents returns an iterator over the exponents of Mersenne primes within the window up to max.
+func (v *subSet) MersenneExponents(max uint64) Iterator {
+	return v.set.filterUpTo(v.lo, min(max, v.hi), IsMersennePrime)
+}
+
+// NthPrime returns the n-th prime number of the window, starting with its smallest prime as the first one.
+func (v *subSet) NthPrime(n uint64) (uint64, bool) {
+	if n == 0 {
+		return 0, false
+	}
+	if v.lo > 0 {
+		n += v.set.CountRange(0, v.lo-1)
+	}
+	if p, ok := v.set.NthPrime(n); ok && p <= v.hi {
+		return p, true
+	}
+	return 0, false
+}
+
+// PermutablePrimes returns an iterator over all permutable primes of the window up to max.
+func (v *subSet) PermutablePrimes(max uint64) Iterator {
+	return v.set.filterUpTo(v.lo, min(max, v.hi), v.set.IsPermutablePrime)
+}
+
+// PermutationClasses groups the primes of the window in [lo, hi] by their sorted decimal digits.
+func (v *subSet) PermutationClasses(lo, hi uint64) map[string][]uint64 {
+	return v.set.PermutationClasses(max(lo, v.lo), min(hi, v.hi))
+}
+
+// PrimePowers returns an iterator over all prime powers of the window from start on.
+func (v *subSet) PrimePowers(start uint64) Iterator {
+	return upTo(v.set.PrimePowers(max(start, v.lo)), v.hi)
+}
+
+// Primorial returns the product of all primes of the window up to n. If n exceeds the window, the second result is
+// false.
+func (v *subSet) Primorial(n uint64) (*big.Int, bool) {
+	if n > v.hi {
+		return nil, false
+	}
+	return primorial(v.Iterator(0), n), true
+}
+
+// Pseudoprimes returns an iterator over the Fermat pseudoprimes of the window to the given base up to max.
+func (v *subSet) Pseudoprimes(base, max uint64) Iterator {
+	return v.set.composites(v.lo, min(max, v.hi), isFermatPseudoprime(base))
+}
+
+// Psi returns the second Chebyshev function over the primes of the window up to n. If n exceeds the window, the
+// second result is false.
+func (v *subSet) Psi(n uint64) (float64, bool) {
+	if n > v.hi {
+		return 0, false
+	}
+	return psi(v.Iterator(0), n), true
+}
+
+// Race returns an iterator over the prime race between two residue classes modulo m among the primes of the window.
+// It panics if m == 0.
+func (v *subSet) Race(m, a, b uint64) RaceIterator {
+	return newRaceIterator(v.Iterator(0), m, a, b)
+}
+
+// RamanujanPrimes returns an iterator over all Ramanujan primes of the window up to max.
+func (v *subSet) RamanujanPrimes(max uint64) Iterator {
+	it := v.set.RamanujanPrimes(min(max, v.hi))
+	return funcIterator(func() (uint64, bool) {
+		for p, ok := it.Next(); ok; p, ok = it.Next() {
+			if p >= v.lo {
+				return p, true
+			}
+		}
+		return 0, false
+	})
+}
+
+// RandomPrime returns a uniformly distributed random prime of the window, using crypto/rand if rnd is nil. If
+// there is no prime in the window, an error wrapping ErrOutOfRange is returned.
+func (v *subSet) RandomPrime(rnd io.Reader) (uint64, error) {
+	count := v.CountRange(0, v.hi)
+	if count == 0 {
+		return 0, fmt.Errorf("%w: no prime in [%d, %d]", ErrOutOfRange, v.lo, v.hi)
+	}
+	r, err := randomBelow(rnd, count)
+	if err != nil {
+		return 0, err
+	}
+	p, _ := v.NthPrime(r + 1)
+	return p, nil
+}
+
+// Render writes an image of the primes of the window like Set.Render, where the numbers may not exceed the window.
+func (v *subSet) Render(w io.Writer, width int, opts ...RenderOption) error {
+	return render(w, width, opts, v.hi, v.Iterator, v.IsPrime)
+}
+
+// RepunitExponents returns an iterator over the lengths of repunit primes in the given base that are primes of the
+// window up to max. It panics if base < 2.
+func (v *subSet) RepunitExponents(base, max uint64) Iterator {
+	return v.set.repunitExponents(base, v.lo, min(max, v.hi))
+}
+
+// ResidueCounts returns the number of primes of the window up to upTo per residue class modulo m. It panics if
+// m == 0.
+func (v *subSet) ResidueCounts(m, upTo uint64) map[uint64]uint64 {
+	return residueCounts(v.Iterator(0), m, upTo)
+}
+
+// Stats returns a statistical summary of the primes of the window.
+func (v *subSet) Stats() SetStats {
+	return newSetStats(v.Iterator(0), v.hi, v.set.MemoryUsage())
+}
+
+// StrongPseudoprimes returns an iterator over the strong pseudoprimes of the window to the given base up to max.
+func (v *subSet) StrongPseudoprimes(base, max uint64) Iterator {
+	return v.set.composites(v.lo, min(max, v.hi), isStrongPseudoprime(base))
+}
+
+// SumPrimes returns the sum of all primes of the window up to n. If n exceeds the window or the sum overflows, the
+// second result is false.
+func (v *subSet) SumPrimes(n uint64) (uint64, bool) {
+	if n > v.hi {
+		return 0, false
+	}
+	sum, ok := uint64(0), true
+	v.ForEach(v.lo, n, func(p uint64) bool {
+		var carry uint64
+		sum, carry = bits.Add64(sum, p, 0)
+		ok = carry == 0
+		return ok
+	})
+	return sum, ok
+}
+
+// SumPrimesExtended returns the sum of all primes of the window up to n, also if it overflows 64 bits.
+func (v *subSet) SumPrimesExtended(n uint64) *big.Int {
+	if sum, ok := v.SumPrimes(min(n, v.hi)); ok {
+		return new(big.Int).SetUint64(sum)
+	}
+	sum := v.set.SumPrimesExtended(min(n, v.hi))
+	if v.lo > 0 {
+		sum.Sub(sum, v.set.SumPrimesExtended(v.lo-1))
+	}
+	return sum
+}
+
+// Theta returns the first Chebyshev function over the primes of the window up to n. If n exceeds the window, the
+// second result is false.
+func (v *subSet) Theta(n uint64) (float64, bool) {
+	if n > v.hi {
+		return 0, false
+	}
+	return theta(v.Iterator(0), n), true
+}
+
+// WieferichPrimes returns an iterator over all Wieferich primes of the window in [lo, hi].
+func (v *subSet) WieferichPrimes(lo, hi uint64) Iterator {
+	return v.set.filterUpTo(max(lo, v.lo), min(hi, v.hi), IsWieferichPrime)
+}
+
+// WilsonPrimes returns an iterator over all Wilson primes of the window in [lo, hi].
+func (v *subSet) WilsonPrimes(lo, hi uint64) Iterator {
+	return v.set.filterUpTo(max(lo, v.lo), min(hi, v.hi), IsWilsonPrime)
+}
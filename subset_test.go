@@ -0,0 +1,122 @@
+package primes
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	original := NewPrimeSet(10000)
+	clone := original.Clone()
+	if !Equal(original, clone) || original.Checksum() != clone.Checksum() {
+		t.Error("clone differs from the set")
+	}
+	clone.(*set).bits[10] = 0
+	if Equal(original, clone) {
+		t.Error("clone shares the bits with the set")
+	}
+}
+
+func TestSubSet(t *testing.T) {
+	set := NewPrimeSet(10000)
+	sub := set.SubSet(100, 200)
+	if sub.LargestNumber() != 200 || sub.LargestPrime() != 199 {
+		t.Errorf("subset reaches up to %d with largest prime %d", sub.LargestNumber(), sub.LargestPrime())
+	}
+	if sub.IsPrime(97) || !sub.IsPrime(101) || sub.IsPrime(211) {
+		t.Error("subset is not bounded")
+	}
+	testIterator(t, "Iterator(0)", sub.Iterator(0), []uint64{101, 103, 107, 109, 113, 127, 131, 137, 139, 149, 151, 157,
+		163, 167, 173, 179, 181, 191, 193, 197, 199})
+	testIterator(t, "Iterator(190)", sub.Iterator(190), []uint64{191, 193, 197, 199})
+	testIterator(t, "Filter()", sub.Filter(0, func(p uint64) bool { return p%10 == 1 }), []uint64{101, 131, 151, 181, 191})
+	testIterator(t, "Composites()", sub.Composites(195), []uint64{195, 196, 198, 200})
+
+	inner := sub.SubSet(150, 1000)
+	testIterator(t, "SubSet().Iterator()", inner.Iterator(0), []uint64{151, 157, 163, 167, 173, 179, 181, 191, 193, 197, 199})
+	if empty := set.SubSet(24, 28); empty.LargestPrime() != 0 {
+		t.Errorf("subset without primes has largest prime %d", empty.LargestPrime())
+	}
+	if clone := sub.Clone(); !Equal(sub, clone) {
+		t.Error("clone of the subset differs")
+	}
+	if factors, ok := sub.Factorizer(1000).Factorize(12); !ok || len(factors) != 2 {
+		t.Error("factorizer of the subset does not use the whole set")
+	}
+}
+
+func TestSubSetForEach(t *testing.T) {
+	sub := NewPrimeSet(10000).SubSet(100, 200)
+	count := 0
+	sub.ForEach(0, 10000, func(p uint64) bool {
+		count++
+		return true
+	})
+	if count != 21 {
+		t.Errorf("ForEach() of the subset yields %d primes", count)
+	}
+}
+
+func TestSubSetBounded(t *testing.T) {
+	set := NewPrimeSet(10000)
+	sub := set.SubSet(1000, 2000)
+	var window []uint64
+	for p := uint64(1000); p <= 2000; p++ {
+		if set.IsPrime(p) {
+			window = append(window, p)
+		}
+	}
+
+	if p, ok := sub.NthPrime(1); !ok || p != window[0] {
+		t.Errorf("NthPrime(1) = %d, %t", p, ok)
+	}
+	if _, ok := sub.NthPrime(uint64(len(window)) + 1); ok {
+		t.Error("NthPrime() exceeds the subset")
+	}
+	if p, ok := sub.ClosestPrime(10); !ok || p != window[0] {
+		t.Errorf("ClosestPrime(10) = %d, %t", p, ok)
+	}
+	if _, ok := sub.ClosestPrime(2001); ok {
+		t.Error("ClosestPrime() exceeds the subset")
+	}
+	stats := sub.Stats()
+	if stats.PrimeCount != uint64(len(window)) || stats.LargestPrime != window[len(window)-1] {
+		t.Errorf("Stats() = %+v", stats)
+	}
+	var sum uint64
+	for _, p := range window {
+		sum += p
+	}
+	if s, ok := sub.SumPrimes(2000); !ok || s != sum {
+		t.Errorf("SumPrimes(2000) = %d, %t, expected %d", s, ok, sum)
+	}
+	if s := sub.SumPrimesExtended(1 << 40); s.Uint64() != sum {
+		t.Errorf("SumPrimesExtended() = %d, expected %d", s, sum)
+	}
+	if n := sub.ResidueCounts(4, 10000); n[1]+n[3] != uint64(len(window)) {
+		t.Errorf("ResidueCounts() = %v", n)
+	}
+	if a, _, ok := sub.FindPrimeAP(3, 0); !ok || a < 1000 {
+		t.Errorf("FindPrimeAP() starts at %d", a)
+	}
+	testIterator(t, "PrimePowers()", sub.PrimePowers(1990), []uint64{1993, 1997, 1999})
+	testIterator(t, "Pseudoprimes()", sub.Pseudoprimes(2, 10000), []uint64{1105, 1387, 1729, 1905})
+	testIterator(t, "WieferichPrimes()", sub.WieferichPrimes(0, 10000), []uint64{1093})
+	for i := 0; i < 100; i++ {
+		if p, err := sub.RandomPrime(nil); err != nil || !sub.IsPrime(p) {
+			t.Fatalf("RandomPrime() = %d, %v", p, err)
+		}
+	}
+	if _, err := set.SubSet(24, 28).RandomPrime(nil); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("RandomPrime() of a subset without primes returns %v", err)
+	}
+	if sub.Checksum() == set.Checksum() || sub.Checksum() != set.SubSet(1000, 2000).Checksum() ||
+		sub.Checksum() == set.SubSet(1000, 2001).Checksum() {
+		t.Error("Checksum() is not bounded to the subset")
+	}
+	var buf bytes.Buffer
+	if err := sub.Render(&buf, 100, WithRange(0, 5000)); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Render() beyond the subset returns %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+package primes
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// SumPrimes returns the sum of all primes up to n.
+// If n exceeds the set or the sum does not fit into an uint64, the second result is false.
+func (s *set) SumPrimes(n uint64) (uint64, bool) {
+	if n > s.largestNumber {
+		return 0, false
+	}
+	if n < 5 {
+		return [...]uint64{0, 0, 2, 5, 5}[n], true
+	}
+
+	// scan the bitset word by word, starting after the bit for 3
+	sum := uint64(5)
+	last := numberToIndex(n)
+	for word := uint(0); word <= last>>6; word++ {
+		w := s.word(word)
+		if word == 0 {
+			w &^= 1
+		}
+		if word == last>>6 {
+			w &= 0xffffffffffffffff >> (63 - last&63)
+		}
+		for w != 0 {
+			i := word<<6 + numberOfTrailingZeroes(w)
+			var carry uint64
+			sum, carry = bits.Add64(sum, indexToNumber(i), 0)
+			if carry != 0 {
+				return 0, false
+			}
+			w &= w - 1
+		}
+	}
+	return sum, true
+}
+
+// SumPrimesExtended returns the sum of all primes up to n, even if n exceeds the set.
+// Beyond the set, it uses Lucy_Hedgehog's algorithm, which takes about n^(3/4) steps and memory proportional to sqrt(n).
+func (s *set) SumPrimesExtended(n uint64) *big.Int {
+	if sum, ok := s.SumPrimes(n); ok {
+		return new(big.Int).SetUint64(sum)
+	}
+	return sumPrimesLucy(n)
+}
+
+// sumPrimesLucy returns the sum of all primes up to n using Lucy_Hedgehog's algorithm. Initially, S(v) is the sum
+// of all numbers in [2, v]. For every prime p up to sqrt(n), the multiples of p whose smallest prime factor is p are
+// removed from S(v) for all v >= p², so that in the end S(v) is the sum of all primes up to v. Only the values v = n/i
+// are needed, which are kept in small for v <= sqrt(n) and in large for v = n/i > sqrt(n).
+func sumPrimesLucy(n uint64) *big.Int {
+	if n < 2 {
+		return new(big.Int)
+	}
+	r := Sqrt(n)
+	small := make([]uint64, r+1)  // small[v] = S(v)
+	large := make([]Uint128, r+1) // large[i] = S(n/i)
+	for v := uint64(1); v <= r; v++ {
+		small[v] = v*(v+1)/2 - 1
+	}
+	for i := uint64(1); i <= r; i++ {
+		large[i] = triangular(n / i).sub64(1)
+	}
+	for p := uint64(2); p <= r; p++ {
+		if small[p] == small[p-1] {
+			// p is not prime
+			continue
+		}
+		sp := small[p-1] // sum of all primes below p
+		p2 := p * p
+		for i := uint64(1); i <= r && n/i >= p2; i++ {
+			var q Uint128
+			if i*p <= r {
+				q = large[i*p]
+			} else {
+				q = Uint128{0, small[n/(i*p)]}
+			}
+			large[i] = large[i].sub(q.sub64(sp).mul64(p))
+		}
+		for v := r; v >= p2; v-- {
+			small[v] -= p * (small[v/p] - sp)
+		}
+	}
+	return large[1].Big()
+}
+
+// triangular returns the sum of all numbers in [1, n].
+func triangular(n uint64) Uint128 {
+	a, b := n, n+1
+	if a&1 == 0 {
+		a >>= 1
+	} else {
+		b >>= 1
+	}
+	hi, lo := bits.Mul64(a, b)
+	if n == 0xffffffffffffffff {
+		// n+1 overflowed, so the product is n * 2^63
+		hi, lo = n>>1, n<<63
+	}
+	return Uint128{hi, lo}
+}
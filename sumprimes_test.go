@@ -0,0 +1,50 @@
+package primes
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestSumPrimes(t *testing.T) {
+	set := NewPrimeSet(100000)
+	sum := uint64(0)
+	it := set.Iterator(0)
+	p, _ := it.Next()
+	for n := uint64(0); n <= 100000; n++ {
+		if n == p {
+			sum += p
+			p, _ = it.Next()
+		}
+		if n%997 != 0 && n > 1000 {
+			continue
+		}
+		if s, ok := set.SumPrimes(n); !ok || s != sum {
+			t.Fatalf("SumPrimes(%d) = %d instead of %d", n, s, sum)
+		}
+		if n%997 == 0 || n < 100 {
+			if s := sumPrimesLucy(n); s.Cmp(new(big.Int).SetUint64(sum)) != 0 {
+				t.Fatalf("sumPrimesLucy(%d) = %s instead of %d", n, s, sum)
+			}
+		}
+	}
+	if _, ok := set.SumPrimes(set.LargestNumber() + 1); ok {
+		t.Error("SumPrimes beyond the set should fail")
+	}
+}
+
+func TestSumPrimesExtended(t *testing.T) {
+	set := NewPrimeSet(1000)
+	for _, c := range []struct {
+		n   uint64
+		sum string
+	}{
+		{1000, "76127"},
+		{2000000, "142913828922"},
+		{1000000000, "24739512092254535"},
+		{100000000000, "201467077743744681014"},
+	} {
+		if sum := set.SumPrimesExtended(c.n); sum.String() != c.sum {
+			t.Errorf("SumPrimesExtended(%d) = %s instead of %s", c.n, sum, c.sum)
+		}
+	}
+}
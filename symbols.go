@@ -0,0 +1,44 @@
+package primes
+
+// JacobiSymbol returns the Jacobi symbol (a/n), which is 0, 1 or -1. JacobiSymbol panics if n is not odd.
+func JacobiSymbol(a, n uint64) int {
+	if n&1 == 0 {
+		panic("jacobi symbol requires an odd modulus")
+	}
+	a %= n
+	result := 1
+	for a != 0 {
+		// pull out factors of 2 using (2/n) = -1 iff n = 3 or 5 mod 8
+		for a&1 == 0 {
+			a >>= 1
+			if r := n & 7; r == 3 || r == 5 {
+				result = -result
+			}
+		}
+		// quadratic reciprocity: (a/n) = -(n/a) iff a = n = 3 mod 4
+		a, n = n, a
+		if a&3 == 3 && n&3 == 3 {
+			result = -result
+		}
+		a %= n
+	}
+	if n == 1 {
+		return result
+	}
+	return 0
+}
+
+// LegendreSymbol returns the Legendre symbol (a/p) for an odd prime p, i.e. 0 if p divides a,
+// 1 if a is a quadratic residue modulo p and -1 otherwise. The primality of p is not checked.
+func LegendreSymbol(a, p uint64) int {
+	return JacobiSymbol(a, p)
+}
+
+// IsQuadraticResidue returns true iff a = x^2 mod p has a solution x for a prime p, which includes a = 0 mod p.
+// The primality of p is not checked.
+func IsQuadraticResidue(a, p uint64) bool {
+	if p == 2 {
+		return true
+	}
+	return LegendreSymbol(a, p) >= 0
+}
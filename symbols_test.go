@@ -0,0 +1,44 @@
+package primes
+
+import "testing"
+
+func TestJacobiSymbol(t *testing.T) {
+	// compare against Euler's criterion for odd primes and multiplicativity in n for composites
+	set := NewPrimeSet(200)
+	for p, ok := set.Iterator(3).Next(); ok && p < 200; p, ok = set.Iterator(p + 1).Next() {
+		for a := uint64(0); a < 2*p; a++ {
+			expected := 0
+			switch PowMod(a, (p-1)/2, p) {
+			case 1:
+				expected = 1
+			case p - 1:
+				expected = -1
+			}
+			if j := LegendreSymbol(a, p); j != expected {
+				t.Errorf("LegendreSymbol(%d, %d) = %d instead of %d", a, p, j, expected)
+			}
+		}
+	}
+	for _, c := range []struct {
+		a, n uint64
+		j    int
+	}{{1001, 9907, -1}, {19, 45, 1}, {8, 21, -1}, {5, 21, 1}, {6, 15, 0}, {0, 1, 1}, {30, 1, 1}} {
+		if j := JacobiSymbol(c.a, c.n); j != c.j {
+			t.Errorf("JacobiSymbol(%d, %d) = %d instead of %d", c.a, c.n, j, c.j)
+		}
+	}
+}
+
+func TestIsQuadraticResidue(t *testing.T) {
+	for _, p := range []uint64{2, 3, 5, 7, 11, 101, 65537} {
+		squares := make(map[uint64]bool)
+		for x := uint64(0); x < p; x++ {
+			squares[x*x%p] = true
+		}
+		for a := uint64(0); a < p; a++ {
+			if IsQuadraticResidue(a, p) != squares[a] {
+				t.Fatalf("IsQuadraticResidue(%d, %d) = %t", a, p, !squares[a])
+			}
+		}
+	}
+}
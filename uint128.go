@@ -0,0 +1,140 @@
+package primes
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// Uint128 is an unsigned 128 bit integer, used for numbers beyond uint64 and for intermediate results.
+type Uint128 struct {
+	Hi, Lo uint64 // high and low 64 bits
+}
+
+// Uint128FromBig converts a big integer into an Uint128. If x is negative or does not fit into 128 bits, the second
+// result is false.
+func Uint128FromBig(x *big.Int) (Uint128, bool) {
+	if x.Sign() < 0 || x.BitLen() > 128 {
+		return Uint128{}, false
+	}
+	lo := new(big.Int).And(x, new(big.Int).SetUint64(^uint64(0)))
+	hi := new(big.Int).Rsh(x, 64)
+	return Uint128{hi.Uint64(), lo.Uint64()}, true
+}
+
+// Big returns x as a big integer.
+func (x Uint128) Big() *big.Int {
+	b := new(big.Int).SetUint64(x.Hi)
+	b.Lsh(b, 64)
+	return b.Or(b, new(big.Int).SetUint64(x.Lo))
+}
+
+// String returns x in decimal notation.
+func (x Uint128) String() string {
+	if x.Hi == 0 {
+		return new(big.Int).SetUint64(x.Lo).String()
+	}
+	return x.Big().String()
+}
+
+// Cmp compares x and y and returns -1, 0 or +1.
+func (x Uint128) Cmp(y Uint128) int {
+	switch {
+	case x.Hi < y.Hi || x.Hi == y.Hi && x.Lo < y.Lo:
+		return -1
+	case x == y:
+		return 0
+	}
+	return 1
+}
+
+// add returns x + y, wrapping around on overflow.
+func (x Uint128) add(y Uint128) Uint128 {
+	lo, carry := bits.Add64(x.Lo, y.Lo, 0)
+	hi, _ := bits.Add64(x.Hi, y.Hi, carry)
+	return Uint128{hi, lo}
+}
+
+// sub returns x - y, wrapping around on underflow.
+func (x Uint128) sub(y Uint128) Uint128 {
+	lo, borrow := bits.Sub64(x.Lo, y.Lo, 0)
+	hi, _ := bits.Sub64(x.Hi, y.Hi, borrow)
+	return Uint128{hi, lo}
+}
+
+// sub64 returns x - y, wrapping around on underflow.
+func (x Uint128) sub64(y uint64) Uint128 {
+	return x.sub(Uint128{0, y})
+}
+
+// mul64 returns x * y, wrapping around on overflow.
+func (x Uint128) mul64(y uint64) Uint128 {
+	hi, lo := bits.Mul64(x.Lo, y)
+	return Uint128{hi + x.Hi*y, lo}
+}
+
+// mul returns x * y, wrapping around on overflow.
+func (x Uint128) mul(y Uint128) Uint128 {
+	hi, lo := bits.Mul64(x.Lo, y.Lo)
+	return Uint128{hi + x.Hi*y.Lo + x.Lo*y.Hi, lo}
+}
+
+// mulFull returns the full 256 bit product x * y in four words, starting with the least significant one.
+func (x Uint128) mulFull(y Uint128) [4]uint64 {
+	h00, l00 := bits.Mul64(x.Lo, y.Lo)
+	h01, l01 := bits.Mul64(x.Lo, y.Hi)
+	h10, l10 := bits.Mul64(x.Hi, y.Lo)
+	h11, l11 := bits.Mul64(x.Hi, y.Hi)
+	var r [4]uint64
+	var c1, c2 uint64
+	r[0] = l00
+	r[1], c1 = bits.Add64(h00, l01, 0)
+	r[1], c2 = bits.Add64(r[1], l10, 0)
+	r[2], c1 = bits.Add64(h01, h10, c1)
+	r[3] = h11 + c1
+	r[2], c1 = bits.Add64(r[2], l11, c2)
+	r[3] += c1
+	return r
+}
+
+// rsh returns x >> n for n < 128.
+func (x Uint128) rsh(n uint) Uint128 {
+	if n >= 64 {
+		return Uint128{0, x.Hi >> (n - 64)}
+	}
+	if n == 0 {
+		return x
+	}
+	return Uint128{x.Hi >> n, x.Lo>>n | x.Hi<<(64-n)}
+}
+
+// trailingZeros returns the number of trailing zero bits of x, which must not be 0.
+func (x Uint128) trailingZeros() uint {
+	if x.Lo != 0 {
+		return uint(bits.TrailingZeros64(x.Lo))
+	}
+	return 64 + uint(bits.TrailingZeros64(x.Hi))
+}
+
+// mod64 returns x mod m for m > 0.
+func (x Uint128) mod64(m uint64) uint64 {
+	_, r := bits.Div64(x.Hi%m, x.Lo, m)
+	return r
+}
+
+// div64 returns x / m for m > 0.
+func (x Uint128) div64(m uint64) Uint128 {
+	hi := x.Hi / m
+	lo, _ := bits.Div64(x.Hi%m, x.Lo, m)
+	return Uint128{hi, lo}
+}
+
+// lsh returns x << n for n < 128.
+func (x Uint128) lsh(n uint) Uint128 {
+	if n >= 64 {
+		return Uint128{x.Lo << (n - 64), 0}
+	}
+	if n == 0 {
+		return x
+	}
+	return Uint128{x.Hi<<n | x.Lo>>(64-n), x.Lo << n}
+}
@@ -0,0 +1,83 @@
+package primes
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// verifyWindow is the size of the windows of numbers that Verify sieves again.
+const verifyWindow = 4096
+
+// Verify checks the prime bits of the set for corruption, e.g. after loading a persisted or memory-mapped set. It
+// tests samples random numbers of the set with a deterministic Miller-Rabin test, and it sieves the first window of
+// 4096 numbers and one more random window per 1024 samples again. Randomness is read from rnd, or from
+// crypto/rand.Reader if rnd is nil. The returned error wraps ErrCorrupt with the first wrong number, or it is the
+// error of reading from rnd.
+func (s *set) Verify(samples int, rnd io.Reader) error {
+	if rnd == nil {
+		rnd = rand.Reader
+	}
+	var buf [8]byte
+	random := func(n uint64) (uint64, error) {
+		if _, err := io.ReadFull(rnd, buf[:]); err != nil {
+			return 0, err
+		}
+		return binary.LittleEndian.Uint64(buf[:]) % n, nil
+	}
+
+	// the first window is checked with Miller-Rabin, since all other windows are sieved with its primes
+	for n := uint64(0); n < verifyWindow && n <= s.largestNumber; n++ {
+		if prime, _ := millerRabin(n); s.isPrime(n) != prime {
+			return s.corrupt(n, prime)
+		}
+	}
+	for i := 0; i < samples; i++ {
+		n, err := random(s.largestNumber + 1)
+		if err != nil {
+			return err
+		}
+		if prime, _ := millerRabin(n); s.isPrime(n) != prime {
+			return s.corrupt(n, prime)
+		}
+	}
+	for i := 0; i < samples/1024; i++ {
+		lo, err := random(s.largestNumber + 1)
+		if err != nil {
+			return err
+		}
+		if err := s.verifyWindow(lo, min(lo+verifyWindow-1, s.largestNumber)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyWindow sieves the numbers in [lo, hi] with the primes of the set and compares the result with the set.
+func (s *set) verifyWindow(lo, hi uint64) error {
+	composite := make([]bool, hi-lo+1)
+	root := Sqrt(hi)
+	it := s.Iterator(0)
+	for p, ok := it.Next(); ok && p <= root; p, ok = it.Next() {
+		m := max(p*p, lo+(p-lo%p)%p) // first multiple of p in the window, which is not p itself
+		for i := m - lo; i < uint64(len(composite)); i += p {
+			composite[i] = true
+		}
+	}
+	for i, c := range composite {
+		n := lo + uint64(i)
+		if prime := n >= 2 && !c; s.isPrime(n) != prime {
+			return s.corrupt(n, prime)
+		}
+	}
+	return nil
+}
+
+// corrupt returns the error for a wrong entry of the set.
+func (s *set) corrupt(n uint64, prime bool) error {
+	if prime {
+		return fmt.Errorf("%w: %d is prime, but not in the set", ErrCorrupt, n)
+	}
+	return fmt.Errorf("%w: %d is not prime, but in the set", ErrCorrupt, n)
+}
@@ -0,0 +1,32 @@
+package primes
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestVerify(t *testing.T) {
+	original := NewPrimeSet(1000000)
+	if err := original.Verify(5000, nil); err != nil {
+		t.Errorf("Verify() of a correct set = %v", err)
+	}
+
+	corrupt := original.Clone().(*set)
+	corrupt.bits[1] ^= 1 << 5 // within the first window
+	if err := corrupt.Verify(0, nil); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("Verify() of a corrupt set = %v", err)
+	}
+	corrupt = original.Clone().(*set)
+	for i := 100; i < len(corrupt.bits); i++ {
+		corrupt.bits[i] ^= 1 << 7
+	}
+	if err := corrupt.Verify(5000, nil); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("Verify() of a corrupt set = %v", err)
+	}
+	if err := corrupt.verifyWindow(999000, 1000000); err == nil {
+		t.Error("verifyWindow() should detect a corrupt set")
+	}
+	if err := original.Verify(1, failingReader{}); err == nil || errors.Is(err, ErrCorrupt) {
+		t.Errorf("Verify() should report read errors: %v", err)
+	}
+}
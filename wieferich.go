@@ -0,0 +1,42 @@
+package primes
+
+import "math/bits"
+
+// IsWieferichPrime returns true iff p is a prime with 2^(p-1) = 1 mod p², i.e. 1093 or 3511 as far as known. The
+// power is computed with 128 bit Montgomery multiplication, so all p of 64 bits can be tested.
+func IsWieferichPrime(p uint64) bool {
+	if prime, _ := millerRabin(p); !prime || p == 2 {
+		return false
+	}
+	hi, lo := bits.Mul64(p, p)
+	mg := newMontgomery128(Uint128{hi, lo})
+	return mg.pow(mg.to(Uint128{0, 2}), Uint128{0, p - 1}) == mg.one
+}
+
+// IsWilsonPrime returns true iff p is a prime with (p-1)! = -1 mod p², i.e. 5, 13 or 563 as far as known. The
+// factorial takes p-1 multiplications modulo p², so this is feasible for numbers up to about 10^10 only.
+func IsWilsonPrime(p uint64) bool {
+	if prime, _ := millerRabin(p); !prime || p == 2 {
+		return false
+	}
+	hi, lo := bits.Mul64(p, p)
+	mg := newMontgomery128(Uint128{hi, lo})
+	// k runs through 1, ..., p-1 in Montgomery form by adding one in every step
+	factorial, k := mg.one, mg.one
+	for i := uint64(2); i < p; i++ {
+		k = mg.add(k, mg.one)
+		factorial = mg.mul(factorial, k)
+	}
+	return factorial == mg.m.sub(mg.one)
+}
+
+// WieferichPrimes returns an iterator over all Wieferich primes of the set in [lo, hi].
+func (s *set) WieferichPrimes(lo, hi uint64) Iterator {
+	return s.filterUpTo(lo, hi, IsWieferichPrime)
+}
+
+// WilsonPrimes returns an iterator over all Wilson primes of the set in [lo, hi]. Each prime p takes p-1
+// multiplications, so this is meant for ranges of moderate numbers.
+func (s *set) WilsonPrimes(lo, hi uint64) Iterator {
+	return s.filterUpTo(lo, hi, IsWilsonPrime)
+}
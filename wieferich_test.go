@@ -0,0 +1,27 @@
+package primes
+
+import "testing"
+
+func TestWieferichPrimes(t *testing.T) {
+	for _, p := range []uint64{0, 1, 2, 3, 1091, 1093 * 1093, 3511, 1093, 4294967291, 18446744073709551557} {
+		expected := p == 1093 || p == 3511
+		if IsWieferichPrime(p) != expected {
+			t.Errorf("IsWieferichPrime(%d) != %v", p, expected)
+		}
+	}
+	s := NewPrimeSet(100000)
+	testIterator(t, "WieferichPrimes(0, 100000)", s.WieferichPrimes(0, 100000), []uint64{1093, 3511})
+	testIterator(t, "WieferichPrimes(1094, 3510)", s.WieferichPrimes(1094, 3510), nil)
+}
+
+func TestWilsonPrimes(t *testing.T) {
+	for _, p := range []uint64{0, 1, 2, 3, 4, 5, 7, 13, 25, 561, 563, 569} {
+		expected := p == 5 || p == 13 || p == 563
+		if IsWilsonPrime(p) != expected {
+			t.Errorf("IsWilsonPrime(%d) != %v", p, expected)
+		}
+	}
+	s := NewPrimeSet(20000)
+	testIterator(t, "WilsonPrimes(0, 20000)", s.WilsonPrimes(0, 20000), []uint64{5, 13, 563})
+	testIterator(t, "WilsonPrimes(6, 13)", s.WilsonPrimes(6, 13), []uint64{13})
+}